@@ -0,0 +1,264 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	coreTypes "github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+var (
+	errUnexpectedBlocks = errors.New("unexpected blocks")
+	errBlocksMismatch   = errors.New("blocks mismatch with headers")
+)
+
+// chunkInFlight is a chunk requested from a peer
+type chunkInFlight struct {
+	pid    peer.ID
+	sentAt time.Time
+}
+
+// chunkAssignment is a chunk assigned to a peer to request it from
+type chunkAssignment struct {
+	pid peer.ID
+	idx uint32
+}
+
+// chunkScheduler assigns chunks, indexed from 0, to multiple peers downloading
+// them in parallel. Each peer has at most window chunks in flight, and chunks not
+// responded in timeout are reassigned to other peers. Downloaders embedding it
+// guard their own state with its mutex too.
+type chunkScheduler struct {
+	mtx      sync.Mutex
+	pending  []uint32
+	inFlight map[uint32]*chunkInFlight
+	// number of chunks in flight to each peer
+	peers   map[peer.ID]int
+	window  int
+	timeout time.Duration
+}
+
+func newChunkScheduler(n, window int, timeout time.Duration) chunkScheduler {
+	s := chunkScheduler{
+		inFlight: make(map[uint32]*chunkInFlight),
+		peers:    make(map[peer.ID]int),
+		window:   window,
+		timeout:  timeout,
+	}
+	for i := 0; i < n; i++ {
+		s.pending = append(s.pending, uint32(i))
+	}
+	return s
+}
+
+// addPeers adds peers to download chunks from
+func (s *chunkScheduler) addPeers(pids ...peer.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, pid := range pids {
+		if _, ok := s.peers[pid]; !ok {
+			s.peers[pid] = 0
+		}
+	}
+}
+
+// peerCount returns the number of peers still downloading
+func (s *chunkScheduler) peerCount() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.peers)
+}
+
+// removePeer stops downloading from pid and requeues its chunks in flight
+func (s *chunkScheduler) removePeer(pid peer.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.removePeerLocked(pid)
+}
+
+// stalledPeers removes peers having a chunk in flight for longer than timeout
+// and returns them
+func (s *chunkScheduler) stalledPeers(now time.Time) []peer.ID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stalled := make(map[peer.ID]struct{})
+	for _, f := range s.inFlight {
+		if now.Sub(f.sentAt) > s.timeout {
+			stalled[f.pid] = struct{}{}
+		}
+	}
+	pids := make([]peer.ID, 0, len(stalled))
+	for pid := range stalled {
+		s.removePeerLocked(pid)
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// assignLocked assigns pending chunks to peers round robin until their windows
+// are full
+func (s *chunkScheduler) assignLocked(now time.Time) []*chunkAssignment {
+	pids := make([]peer.ID, 0, len(s.peers))
+	for pid := range s.peers {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	var assignments []*chunkAssignment
+	for assigned := true; assigned && len(s.pending) > 0; {
+		assigned = false
+		for _, pid := range pids {
+			if len(s.pending) == 0 {
+				break
+			}
+			if s.peers[pid] >= s.window {
+				continue
+			}
+			idx := s.pending[0]
+			s.pending = s.pending[1:]
+			s.inFlight[idx] = &chunkInFlight{pid: pid, sentAt: now}
+			s.peers[pid]++
+			assignments = append(assignments, &chunkAssignment{pid: pid, idx: idx})
+			assigned = true
+		}
+	}
+	return assignments
+}
+
+// inFlightToLocked returns if chunk idx is in flight to pid
+func (s *chunkScheduler) inFlightToLocked(pid peer.ID, idx uint32) bool {
+	f, ok := s.inFlight[idx]
+	return ok && f.pid == pid
+}
+
+// doneLocked marks chunk idx in flight as downloaded
+func (s *chunkScheduler) doneLocked(idx uint32) {
+	if f, ok := s.inFlight[idx]; ok {
+		delete(s.inFlight, idx)
+		s.peers[f.pid]--
+	}
+}
+
+func (s *chunkScheduler) removePeerLocked(pid peer.ID) {
+	delete(s.peers, pid)
+	for idx, f := range s.inFlight {
+		if f.pid == pid {
+			delete(s.inFlight, idx)
+			s.pending = append(s.pending, idx)
+		}
+	}
+}
+
+// blockChunk is a run of consecutive block hashes downloaded from one peer at a time
+type blockChunk struct {
+	hashes []*crypto.HashType
+	blocks []*coreTypes.Block
+}
+
+// chunkRequest is a chunk request to be sent to a peer
+type chunkRequest struct {
+	pid       peer.ID
+	idx       uint32
+	beginHash *crypto.HashType
+	length    uint32
+}
+
+// blockDownloader schedules downloading block bodies of validated headers from
+// multiple peers in parallel, and hands out downloaded chunks in order so that
+// blocks can be processed without orphans.
+type blockDownloader struct {
+	chunkScheduler
+	chunks []*blockChunk
+	// index of the next chunk to hand out
+	nextIdx uint32
+}
+
+func newBlockDownloader(hashes []*crypto.HashType, chunkSize, window int,
+	timeout time.Duration) *blockDownloader {
+	dl := &blockDownloader{}
+	for i := 0; i < len(hashes); i += chunkSize {
+		end := i + chunkSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		dl.chunks = append(dl.chunks, &blockChunk{hashes: hashes[i:end]})
+	}
+	dl.chunkScheduler = newChunkScheduler(len(dl.chunks), window, timeout)
+	return dl
+}
+
+// schedule assigns pending chunks to peers, and returns the requests to send
+func (dl *blockDownloader) schedule(now time.Time) []*chunkRequest {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+
+	// lower chunks first, since they block processing
+	sort.Slice(dl.pending, func(i, j int) bool { return dl.pending[i] < dl.pending[j] })
+	var requests []*chunkRequest
+	for _, a := range dl.assignLocked(now) {
+		c := dl.chunks[a.idx]
+		requests = append(requests, &chunkRequest{
+			pid:       a.pid,
+			idx:       a.idx,
+			beginHash: c.hashes[0],
+			length:    uint32(len(c.hashes)),
+		})
+	}
+	return requests
+}
+
+// onBlocks accepts blocks of chunk idx from pid. The peer is removed and its
+// chunks are reassigned if the blocks do not match the chunk.
+func (dl *blockDownloader) onBlocks(pid peer.ID, idx uint32, blocks []*coreTypes.Block) error {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+
+	if !dl.inFlightToLocked(pid, idx) {
+		return errUnexpectedBlocks
+	}
+	c := dl.chunks[idx]
+	if len(blocks) != len(c.hashes) {
+		dl.removePeerLocked(pid)
+		return errBlocksMismatch
+	}
+	for i, b := range blocks {
+		if *b.BlockHash() != *c.hashes[i] {
+			dl.removePeerLocked(pid)
+			return errBlocksMismatch
+		}
+	}
+	c.blocks = blocks
+	dl.doneLocked(idx)
+	return nil
+}
+
+// nextReady returns blocks of the next chunk in order if downloaded, or nil
+func (dl *blockDownloader) nextReady() []*coreTypes.Block {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+
+	if dl.nextIdx >= uint32(len(dl.chunks)) || dl.chunks[dl.nextIdx].blocks == nil {
+		return nil
+	}
+	c := dl.chunks[dl.nextIdx]
+	blocks := c.blocks
+	c.blocks = nil
+	dl.nextIdx++
+	return blocks
+}
+
+// finished returns if all chunks are handed out
+func (dl *blockDownloader) finished() bool {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+	return dl.nextIdx >= uint32(len(dl.chunks))
+}
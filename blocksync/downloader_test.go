@@ -0,0 +1,238 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BOXFoundation/boxd/core/chain"
+	coreTypes "github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// generate n blocks following parent, 5 seconds apart
+func genHeaders(parent *coreTypes.Block, n int) []*coreTypes.Block {
+	blocks := make([]*coreTypes.Block, 0, n)
+	for i := 0; i < n; i++ {
+		b := coreTypes.NewBlock(parent)
+		b.Header.TimeStamp = parent.Header.TimeStamp + 5
+		blocks = append(blocks, b)
+		parent = b
+	}
+	return blocks
+}
+
+func genesisHeader() *coreTypes.Block {
+	return &coreTypes.Block{Header: &coreTypes.BlockHeader{TimeStamp: 100}}
+}
+
+func runTestRequests(t *testing.T, got []*chunkRequest, expect map[uint32]peer.ID) {
+	gotMap := make(map[uint32]peer.ID)
+	for _, r := range got {
+		gotMap[r.idx] = r.pid
+	}
+	if !reflect.DeepEqual(gotMap, expect) {
+		t.Fatalf("want: %v, got: %v", expect, gotMap)
+	}
+}
+
+func TestBlockDownloader(t *testing.T) {
+	blocks := genHeaders(genesisHeader(), 5)
+	hashes := make([]*crypto.HashType, 0, len(blocks))
+	for _, b := range blocks {
+		hashes = append(hashes, b.BlockHash())
+	}
+	now := time.Now()
+	dl := newBlockDownloader(hashes, 2, 1, blocksTimeout)
+	dl.addPeers("a", "b")
+
+	// one chunk in flight to each peer
+	runTestRequests(t, dl.schedule(now), map[uint32]peer.ID{0: "a", 1: "b"})
+	if err := dl.onBlocks("b", 1, blocks[2:4]); err != nil {
+		t.Fatal(err)
+	}
+	if dl.nextReady() != nil {
+		t.Fatal("chunk 1 must wait for chunk 0")
+	}
+	runTestRequests(t, dl.schedule(now), map[uint32]peer.ID{2: "b"})
+
+	// wrong blocks remove the peer and reassign its chunk
+	if err := dl.onBlocks("a", 0, blocks[1:3]); err != errBlocksMismatch {
+		t.Fatalf("want: %v, got: %v", errBlocksMismatch, err)
+	}
+	// stalled peer is removed
+	stalled := dl.stalledPeers(now.Add(blocksTimeout + time.Second))
+	if !reflect.DeepEqual(stalled, []peer.ID{"b"}) || dl.peerCount() != 0 {
+		t.Fatalf("want stalled peer b, got: %v, %d peers left", stalled, dl.peerCount())
+	}
+
+	dl.addPeers("c")
+	runTestRequests(t, dl.schedule(now), map[uint32]peer.ID{0: "c"})
+	if err := dl.onBlocks("c", 0, blocks[0:2]); err != nil {
+		t.Fatal(err)
+	}
+	if got := dl.nextReady(); !reflect.DeepEqual(got, blocks[0:2]) {
+		t.Fatalf("want: %v, got: %v", blocks[0:2], got)
+	}
+	if got := dl.nextReady(); !reflect.DeepEqual(got, blocks[2:4]) {
+		t.Fatalf("want: %v, got: %v", blocks[2:4], got)
+	}
+	if dl.nextReady() != nil || dl.finished() {
+		t.Fatal("chunk 2 is not downloaded yet")
+	}
+
+	runTestRequests(t, dl.schedule(now), map[uint32]peer.ID{2: "c"})
+	// late response from the stalled peer
+	if err := dl.onBlocks("b", 2, blocks[4:]); err != errUnexpectedBlocks {
+		t.Fatalf("want: %v, got: %v", errUnexpectedBlocks, err)
+	}
+	if err := dl.onBlocks("c", 2, blocks[4:]); err != nil {
+		t.Fatal(err)
+	}
+	if got := dl.nextReady(); !reflect.DeepEqual(got, blocks[4:]) {
+		t.Fatalf("want: %v, got: %v", blocks[4:], got)
+	}
+	if !dl.finished() {
+		t.Fatal("all chunks must be finished")
+	}
+}
+
+func TestCheckHeadersChain(t *testing.T) {
+	parent := genesisHeader()
+	headers := genHeaders(parent, 3)
+	now := headers[2].Header.TimeStamp
+	if err := checkHeadersChain(parent, headers, now); err != nil {
+		t.Fatal(err)
+	}
+	// missing header
+	if err := checkHeadersChain(parent, headers[1:], now); err != errHeadersNotContinuous {
+		t.Fatalf("want: %v, got: %v", errHeadersNotContinuous, err)
+	}
+	// header in the far future
+	now = headers[0].Header.TimeStamp - chain.MaxTimeOffsetSeconds - 1
+	if err := checkHeadersChain(parent, headers, now); err != errInvalidHeaderTimestamp {
+		t.Fatalf("want: %v, got: %v", errInvalidHeaderTimestamp, err)
+	}
+	// timestamp not increasing
+	now = headers[2].Header.TimeStamp
+	headers = genHeaders(parent, 2)
+	headers[1].Header.TimeStamp = headers[0].Header.TimeStamp
+	headers[1].Hash = nil
+	if err := checkHeadersChain(parent, headers, now); err != errInvalidHeaderTimestamp {
+		t.Fatalf("want: %v, got: %v", errInvalidHeaderTimestamp, err)
+	}
+}
+
+func TestHeadersAcrossPeriods(t *testing.T) {
+	// headers from the end of a period to the next, beginning at headers[1]
+	parent := genesisHeader()
+	parent.Height = chain.PeriodDuration - 2
+	genPeriodHeaders := func(periodHashes ...crypto.HashType) []*coreTypes.Block {
+		headers := make([]*coreTypes.Block, 0, len(periodHashes))
+		prev := parent
+		for _, hash := range periodHashes {
+			b := coreTypes.NewBlock(prev)
+			b.Header.TimeStamp = prev.Header.TimeStamp + 5
+			b.Header.PeriodHash = hash
+			headers = append(headers, b)
+			prev = b
+		}
+		return headers
+	}
+	zero, next := crypto.HashType{}, crypto.HashType{0x01}
+	headers := genPeriodHeaders(zero, next, next, next)
+	if err := checkHeadersPeriod(parent, headers); err != nil {
+		t.Fatal(err)
+	}
+	// a header within a period must commit to the same one as its parent
+	if err := checkHeadersPeriod(parent, genPeriodHeaders(zero, next, zero)); err != errInvalidHeaderPeriod {
+		t.Fatalf("want: %v, got: %v", errInvalidHeaderPeriod, err)
+	}
+	if err := checkHeadersPeriod(parent, genPeriodHeaders(next, next)); err != errInvalidHeaderPeriod {
+		t.Fatalf("want: %v, got: %v", errInvalidHeaderPeriod, err)
+	}
+
+	// headers are cut before the one beginning a period unless it follows a
+	// local block, and after it, since the period it commits to is unknown
+	if got := headersInKnownPeriods(headers); len(got) != 1 {
+		t.Fatalf("want: 1 header, got: %d", len(got))
+	}
+	if got := headersInKnownPeriods(headers[1:]); len(got) != 1 {
+		t.Fatalf("want: 1 header, got: %d", len(got))
+	}
+	if got := headersInKnownPeriods(headers[2:]); len(got) != 2 {
+		t.Fatalf("want: 2 headers, got: %d", len(got))
+	}
+}
+
+func TestSnapshotDownloader(t *testing.T) {
+	chunks := []*chain.SnapshotChunk{
+		{Keys: [][]byte{[]byte("/utxo/a")}, Values: [][]byte{[]byte("1")}},
+		{Keys: [][]byte{[]byte("/utxo/b")}, Values: [][]byte{[]byte("2")}},
+		{Keys: [][]byte{[]byte("/utxo/c")}, Values: [][]byte{[]byte("3")}},
+	}
+	hashes := make([]*crypto.HashType, 0, len(chunks))
+	for _, c := range chunks {
+		hashes = append(hashes, c.Hash())
+	}
+	now := time.Now()
+	dl := newSnapshotDownloader(hashes, 1, blocksTimeout)
+	dl.addPeers("a", "b")
+
+	reqs := dl.schedule(now)
+	if len(reqs) != 2 || reqs[0].pid != "a" || reqs[0].idx != 0 ||
+		reqs[1].pid != "b" || reqs[1].idx != 1 {
+		t.Fatalf("unexpected requests: %+v, %+v", reqs[0], reqs[1])
+	}
+	// chunks are accepted in any order
+	if err := dl.onChunk("b", 1, chunks[1]); err != nil {
+		t.Fatal(err)
+	}
+	// wrong chunk removes the peer and reassigns its chunk
+	if err := dl.onChunk("a", 0, chunks[1]); err != errChunkMismatch {
+		t.Fatalf("want: %v, got: %v", errChunkMismatch, err)
+	}
+	if dl.peerCount() != 1 {
+		t.Fatalf("want 1 peer left, got: %d", dl.peerCount())
+	}
+	reqs = dl.schedule(now)
+	if len(reqs) != 1 || reqs[0].pid != "b" || reqs[0].idx != 2 {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+	// stalled peer is removed
+	stalled := dl.stalledPeers(now.Add(blocksTimeout + time.Second))
+	if !reflect.DeepEqual(stalled, []peer.ID{"b"}) || dl.peerCount() != 0 {
+		t.Fatalf("want stalled peer b, got: %v, %d peers left", stalled, dl.peerCount())
+	}
+	// late response from the stalled peer
+	if err := dl.onChunk("b", 2, chunks[2]); err != errUnexpectedChunk {
+		t.Fatalf("want: %v, got: %v", errUnexpectedChunk, err)
+	}
+
+	dl.addPeers("c")
+	reqs = dl.schedule(now)
+	if len(reqs) != 1 || reqs[0].pid != "c" {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+	if err := dl.onChunk("c", reqs[0].idx, chunks[reqs[0].idx]); err != nil {
+		t.Fatal(err)
+	}
+	if dl.finished() {
+		t.Fatal("a chunk is not downloaded yet")
+	}
+	reqs = dl.schedule(now)
+	if len(reqs) != 1 || reqs[0].pid != "c" {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+	if err := dl.onChunk("c", reqs[0].idx, chunks[reqs[0].idx]); err != nil {
+		t.Fatal(err)
+	}
+	if !dl.finished() || !reflect.DeepEqual(dl.chunks, chunks) {
+		t.Fatal("all chunks must be downloaded")
+	}
+}
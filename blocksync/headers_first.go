@@ -0,0 +1,344 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BOXFoundation/boxd/consensus/dpos"
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/chain"
+	coreTypes "github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/p2p"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+var (
+	errHeadersNotConnected    = errors.New("headers do not connect to local chain")
+	errHeadersNotContinuous   = errors.New("headers are not continuous")
+	errInvalidHeaderTimestamp = errors.New("invalid header timestamp")
+	errInvalidHeaderSignature = errors.New("invalid header signature")
+	errInvalidHeaderPeriod    = errors.New("invalid header period hash")
+)
+
+const (
+	// max number of peers to download block bodies from in parallel
+	maxBlocksSyncPeers = 8
+	// max number of chunks in flight to a peer
+	maxChunksInFlightPerPeer = 2
+	// interval to check stalled peers while downloading block bodies
+	stallCheckInterval = time.Second
+)
+
+// startHeadersFirstSync syncs block headers from one peer and validates them,
+// then downloads block bodies from multiple peers in parallel.
+func (sm *SyncManager) startHeadersFirstSync() {
+	p2p.UpdateSynced(false)
+	// prevent startSync being executed again
+	sm.setStatus(headersStatus)
+	// sleep 1s to wait for connections to establish
+	time.Sleep(time.Second)
+	//
+	defer func() {
+		sm.consensus.RecoverMint()
+		sm.resetAll()
+		p2p.UpdateSynced(true)
+		logger.Info("headers first sync completed and exit!")
+	}()
+
+	for tries := 0; tries < maxSyncTries; {
+		sm.setStatus(headersStatus)
+		headers, more, err := sm.syncHeaders()
+		if err != nil {
+			tries++
+			logger.Warnf("sync headers error: %s", err)
+			time.Sleep(retryInterval)
+			continue
+		}
+		if len(headers) == 0 {
+			return
+		}
+		logger.Infof("success to sync %d headers, start to sync blocks", len(headers))
+
+		sm.setStatus(blocksStatus)
+		if err := sm.syncBlockBodies(headers); err != nil {
+			tries++
+			logger.Warnf("sync blocks error: %s", err)
+			time.Sleep(retryInterval)
+			continue
+		}
+		logger.Infof("complete to sync %d blocks", len(headers))
+		if !more {
+			return
+		}
+	}
+	logger.Warnf("exceed max retry times(%d)", maxSyncTries)
+}
+
+// syncHeaders fetches headers after the fork point from one peer and validates them.
+// Headers of blocks already on local chain are skipped. It also reports whether
+// there may be more headers to sync after the ones returned.
+func (sm *SyncManager) syncHeaders() ([]*coreTypes.Block, bool, error) {
+	hashes, err := sm.getLatestBlockLocator()
+	if err != nil {
+		return nil, false, err
+	}
+	pid, err := sm.pickOnePeer(headersStatus)
+	if err != nil {
+		return nil, false, err
+	}
+	tryPopSyncBlocksChan(sm.headersCh)
+	sm.stalePeers.Store(pid, headersPeerStatus)
+	logger.Infof("send message[0x%X] (%d hashes) to peer %s",
+		p2p.HeadersRequest, len(hashes), pid.Pretty())
+	if err := sm.p2pNet.SendMessageToPeer(p2p.HeadersRequest,
+		newLocateHeaders(hashes...), pid); err != nil {
+		return nil, false, err
+	}
+
+	timer := time.NewTimer(syncTimeout)
+	defer cleanStopTimer(timer)
+	var sb *SyncBlocks
+	select {
+	case sb = <-sm.headersCh:
+	case <-timer.C:
+		sm.stalePeers.Store(pid, errPeerStatus)
+		return nil, false, fmt.Errorf("timeout for headers from peer %s", pid.Pretty())
+	case <-sm.proc.Closing():
+		return nil, false, errors.New("sync manager is closing")
+	}
+	if sb == nil {
+		return nil, false, fmt.Errorf("no headers from peer %s", pid.Pretty())
+	}
+	more := len(sb.Blocks) >= chain.MaxBlocksPerSync
+
+	headers := sb.Blocks
+	for len(headers) > 0 {
+		if block, _ := sm.chain.LoadBlockByHash(*headers[0].BlockHash()); block == nil {
+			break
+		}
+		headers = headers[1:]
+	}
+	if len(headers) == 0 {
+		return nil, false, nil
+	}
+	headers = headersInKnownPeriods(headers)
+	if len(headers) < len(sb.Blocks) {
+		more = true
+	}
+	if err := sm.verifyHeaders(headers); err != nil {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		return nil, false, err
+	}
+	sm.stalePeers.Store(pid, headersDonePeerStatus)
+	return headers, more, nil
+}
+
+// headersInKnownPeriods returns the leading headers whose producers are scheduled
+// by periods known locally. A header is scheduled by the period its parent
+// commits to, which is only stored once the parent is connected if the parent
+// begins a new period, so headers are cut before a header beginning a period and
+// after it, unless it follows a local block.
+func headersInKnownPeriods(headers []*coreTypes.Block) []*coreTypes.Block {
+	for i := 1; i < len(headers); i++ {
+		if headers[i].Height%chain.PeriodDuration == 0 ||
+			headers[i-1].Height%chain.PeriodDuration == 0 {
+			return headers[:i]
+		}
+	}
+	return headers
+}
+
+// verifyHeaders validates headers following a local block, including their
+// signatures, timestamps, period hashes and miner epochs
+func (sm *SyncManager) verifyHeaders(headers []*coreTypes.Block) error {
+	parent, err := sm.chain.LoadBlockByHash(headers[0].Header.PrevBlockHash)
+	if err != nil || parent == nil {
+		return errHeadersNotConnected
+	}
+	if err := checkHeadersChain(parent, headers, time.Now().Unix()); err != nil {
+		return err
+	}
+	if err := checkHeadersPeriod(parent, headers); err != nil {
+		return err
+	}
+
+	// latest headers before each header, latest first, used to verify miner epoch
+	prevHeaders := make([]*coreTypes.BlockHeader, 0, dpos.PeriodSize)
+	for b := parent; b.Height > 0 && len(prevHeaders) < dpos.PeriodSize; {
+		prevHeaders = append(prevHeaders, b.Header)
+		if b, err = sm.chain.LoadBlockByHash(b.Header.PrevBlockHash); err != nil {
+			return err
+		}
+	}
+	for _, b := range headers {
+		if ok, err := sm.consensus.VerifySign(b); err != nil || !ok {
+			logger.Warnf("Failed to verify header signature. Hash: %v, Height: %d, Err: %v",
+				b.BlockHash(), b.Height, err)
+			return errInvalidHeaderSignature
+		}
+		if err := sm.consensus.VerifyMinerEpochWithHeaders(b.Header, prevHeaders); err != nil {
+			logger.Warnf("Failed to verify header miner epoch. Hash: %v, Height: %d, Err: %v",
+				b.BlockHash(), b.Height, err)
+			return err
+		}
+		prevHeaders = append([]*coreTypes.BlockHeader{b.Header}, prevHeaders...)
+		if len(prevHeaders) > dpos.PeriodSize {
+			prevHeaders = prevHeaders[:dpos.PeriodSize]
+		}
+	}
+	return nil
+}
+
+// checkHeadersChain checks headers link to parent one by one with increasing
+// heights and timestamps, none of which is too far in the future from now
+func checkHeadersChain(parent *coreTypes.Block, headers []*coreTypes.Block, now int64) error {
+	prev := parent
+	for _, b := range headers {
+		if b.Header.PrevBlockHash != *prev.BlockHash() || b.Height != prev.Height+1 {
+			return errHeadersNotContinuous
+		}
+		if b.Header.TimeStamp <= prev.Header.TimeStamp ||
+			b.Header.TimeStamp > now+chain.MaxTimeOffsetSeconds {
+			return errInvalidHeaderTimestamp
+		}
+		prev = b
+	}
+	return nil
+}
+
+// checkHeadersPeriod checks headers within a period commit to the same period as
+// their parents, so that they are verified against the period scheduling them
+func checkHeadersPeriod(parent *coreTypes.Block, headers []*coreTypes.Block) error {
+	prev := parent
+	for _, b := range headers {
+		if b.Height%chain.PeriodDuration != 0 && b.Header.PeriodHash != prev.Header.PeriodHash {
+			return errInvalidHeaderPeriod
+		}
+		prev = b
+	}
+	return nil
+}
+
+// syncBlockBodies downloads bodies of validated headers from multiple peers and
+// processes them in order
+func (sm *SyncManager) syncBlockBodies(headers []*coreTypes.Block) error {
+	hashes := make([]*crypto.HashType, 0, len(headers))
+	for _, b := range headers {
+		hashes = append(hashes, b.BlockHash())
+	}
+	dl := newBlockDownloader(hashes, syncBlockChunkSize, maxChunksInFlightPerPeer, blocksTimeout)
+	sm.setDownloader(dl)
+	defer sm.setDownloader(nil)
+	sm.drainBlocksChan()
+
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+	for {
+		// process downloaded blocks in order
+		for blocks := dl.nextReady(); blocks != nil; blocks = dl.nextReady() {
+			for _, b := range blocks {
+				err := sm.chain.ProcessBlock(b, false, false, "")
+				if err != nil && err != core.ErrBlockExists && err != core.ErrOrphanBlockExists {
+					return err
+				}
+			}
+		}
+		if dl.finished() {
+			return nil
+		}
+		if dl.peerCount() == 0 {
+			pids := sm.pickBlocksSyncPeers(maxBlocksSyncPeers)
+			if len(pids) == 0 {
+				return errNoPeerToSync
+			}
+			dl.addPeers(pids...)
+		}
+		sm.sendChunkRequests(dl, dl.schedule(time.Now()))
+
+		select {
+		case <-sm.blocksDoneCh:
+		case <-ticker.C:
+			for _, pid := range dl.stalledPeers(time.Now()) {
+				logger.Warnf("peer %s stalled in blocks sync, reassign its blocks", pid.Pretty())
+				sm.stalePeers.Store(pid, errPeerStatus)
+			}
+		case <-sm.proc.Closing():
+			return errors.New("sync manager is closing")
+		}
+	}
+}
+
+func (sm *SyncManager) sendChunkRequests(dl *blockDownloader, requests []*chunkRequest) {
+	for _, r := range requests {
+		fbh := newFetchBlockHeaders(r.idx, r.beginHash, r.length)
+		logger.Debugf("send message[0x%X] body:%+v to peer %s", p2p.BlockChunkRequest,
+			fbh, r.pid.Pretty())
+		sm.stalePeers.Store(r.pid, blocksPeerStatus)
+		if err := sm.p2pNet.SendMessageToPeer(p2p.BlockChunkRequest, fbh, r.pid); err != nil {
+			logger.Warnf("send message[0x%X] to peer %s error: %s", p2p.BlockChunkRequest,
+				r.pid.Pretty(), err)
+			sm.stalePeers.Store(r.pid, errPeerStatus)
+			dl.removePeer(r.pid)
+		}
+	}
+}
+
+// pickBlocksSyncPeers picks at most n synced peers not failed in this sync
+func (sm *SyncManager) pickBlocksSyncPeers(n int) []peer.ID {
+	excluded := make([]peer.ID, 0)
+	sm.stalePeers.Range(func(k, v interface{}) bool {
+		if v != nil && v.(peerStatus) == errPeerStatus {
+			excluded = append(excluded, k.(peer.ID))
+		}
+		return true
+	})
+	pids := make([]peer.ID, 0, n)
+	for len(pids) < n {
+		pid := sm.p2pNet.PickOnePeer(excluded...)
+		if pid == peer.ID("") {
+			break
+		}
+		excluded = append(excluded, pid)
+		if synced, _ := sm.p2pNet.PeerSynced(pid); synced {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+func (sm *SyncManager) setDownloader(dl *blockDownloader) {
+	sm.downloaderMtx.Lock()
+	sm.downloader = dl
+	sm.downloaderMtx.Unlock()
+}
+
+func (sm *SyncManager) getDownloader() *blockDownloader {
+	sm.downloaderMtx.RLock()
+	defer sm.downloaderMtx.RUnlock()
+	return sm.downloader
+}
+
+func tryPopSyncBlocksChan(ch <-chan *SyncBlocks) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func tryPushSyncBlocksChan(ch chan<- *SyncBlocks, v *SyncBlocks) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		logger.Info("cannot push SyncBlocks to chan")
+		return false
+	}
+}
@@ -41,10 +41,14 @@ func (s syncStatus) String() string {
 		return "freeStatus"
 	case locateStatus:
 		return "locateStatus"
+	case headersStatus:
+		return "headersStatus"
 	case checkStatus:
 		return "checkStatus"
 	case blocksStatus:
 		return "blocksStatus"
+	case snapshotStatus:
+		return "snapshotStatus"
 	default:
 		return "unknown syncStatus"
 	}
@@ -68,12 +72,18 @@ const (
 	checkedDonePeerStatus
 	blocksPeerStatus
 	blocksDonePeerStatus
+	headersPeerStatus
+	headersDonePeerStatus
+	snapshotPeerStatus
+	snapshotDonePeerStatus
 	errPeerStatus
 
 	freeStatus syncStatus = iota
 	locateStatus
 	checkStatus
+	headersStatus
 	blocksStatus
+	snapshotStatus
 	// err falg
 	errFlagNoHash errFlag = iota
 	errFlagInSync
@@ -83,6 +93,15 @@ const (
 	errFlagRootHashMismatch
 )
 
+// Config defines the configurations of block sync
+type Config struct {
+	// download and validate headers first, then blocks from multiple peers in parallel
+	HeadersFirst bool `mapstructure:"headers_first"`
+	// install the utxo snapshot as of an eternal block from peers instead of
+	// processing all blocks before it, only on a node without blocks
+	Snapshot bool `mapstructure:"snapshot"`
+}
+
 type blockCheckInfo struct {
 	rootHash *crypto.HashType
 	fbh      *FetchBlockHeaders
@@ -109,7 +128,13 @@ type SyncManager struct {
 	blocksSynced int32
 	// server started only once
 	svrStarted int32
+	// downloader of block bodies in headers first sync
+	downloader *blockDownloader
+	// downloader of utxo snapshot chunks in snapshot sync
+	snapshotDownloader *snapshotDownloader
+	downloaderMtx      sync.RWMutex
 
+	cfg       *Config
 	proc      goprocess.Process
 	chain     *chain.BlockChain
 	consensus *dpos.Dpos
@@ -124,6 +149,9 @@ type SyncManager struct {
 	blocksDoneCh      chan struct{}
 	blocksErrCh       chan FetchBlockHeaders
 	blocksProcessedCh chan struct{}
+	headersCh         chan *SyncBlocks
+	manifestCh        chan *manifestResp
+	chunksDoneCh      chan struct{}
 }
 
 func (sm *SyncManager) reset() {
@@ -144,9 +172,10 @@ func (sm *SyncManager) resetAll() {
 
 // NewSyncManager returns new block sync manager.
 func NewSyncManager(blockChain *chain.BlockChain, p2pNet p2p.Net,
-	consensus *dpos.Dpos, parent goprocess.Process) *SyncManager {
+	consensus *dpos.Dpos, parent goprocess.Process, cfg *Config) *SyncManager {
 	return &SyncManager{
 		status:       freeStatus,
+		cfg:          cfg,
 		chain:        blockChain,
 		consensus:    consensus,
 		p2pNet:       p2pNet,
@@ -164,6 +193,9 @@ func NewSyncManager(blockChain *chain.BlockChain, p2pNet p2p.Net,
 			chain.MaxBlocksPerSync/syncBlockChunkSize),
 		blocksProcessedCh: make(chan struct{},
 			chain.MaxBlocksPerSync/syncBlockChunkSize),
+		headersCh:    make(chan *SyncBlocks, 1),
+		manifestCh:   make(chan *manifestResp, maxCheckPeers+1),
+		chunksDoneCh: make(chan struct{}, maxBlocksSyncPeers*maxChunksInFlightPerPeer),
 	}
 }
 
@@ -174,6 +206,14 @@ func (sm *SyncManager) StartSync() {
 	}
 	logger.Info("StartSync")
 	sm.consensus.StopMint()
+	if sm.cfg.Snapshot && sm.chain.TailBlock().Height == 0 {
+		go sm.startSnapshotSync()
+		return
+	}
+	if sm.cfg.HeadersFirst {
+		go sm.startHeadersFirstSync()
+		return
+	}
 	go sm.startSync()
 }
 
@@ -476,8 +516,13 @@ func (sm *SyncManager) getLatestBlockLocator() ([]*crypto.HashType, error) {
 		return []*crypto.HashType{&chain.GenesisHash}, nil
 	}
 	heights := heightLocator(tailHeight)
+	snapshotHeight := sm.chain.SnapshotBlock().Height
 	for _, h := range heights {
 		b, err := sm.chain.LoadBlockByHeight(h)
+		// blocks before the snapshot block are not stored
+		if err != nil && h < snapshotHeight {
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf("LoadBlockByHeight error: %s, h: %d, heights: %v",
 				err, h, heights)
@@ -34,7 +34,7 @@ func (m *LocateHeaders) Reset()         { *m = LocateHeaders{} }
 func (m *LocateHeaders) String() string { return proto.CompactTextString(m) }
 func (*LocateHeaders) ProtoMessage()    {}
 func (*LocateHeaders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{0}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{0}
 }
 func (m *LocateHeaders) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -78,7 +78,7 @@ func (m *SyncHeaders) Reset()         { *m = SyncHeaders{} }
 func (m *SyncHeaders) String() string { return proto.CompactTextString(m) }
 func (*SyncHeaders) ProtoMessage()    {}
 func (*SyncHeaders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{1}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{1}
 }
 func (m *SyncHeaders) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -123,7 +123,7 @@ func (m *CheckHash) Reset()         { *m = CheckHash{} }
 func (m *CheckHash) String() string { return proto.CompactTextString(m) }
 func (*CheckHash) ProtoMessage()    {}
 func (*CheckHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{2}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{2}
 }
 func (m *CheckHash) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -175,7 +175,7 @@ func (m *SyncCheckHash) Reset()         { *m = SyncCheckHash{} }
 func (m *SyncCheckHash) String() string { return proto.CompactTextString(m) }
 func (*SyncCheckHash) ProtoMessage()    {}
 func (*SyncCheckHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{3}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{3}
 }
 func (m *SyncCheckHash) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -221,7 +221,7 @@ func (m *FetchBlockHeaders) Reset()         { *m = FetchBlockHeaders{} }
 func (m *FetchBlockHeaders) String() string { return proto.CompactTextString(m) }
 func (*FetchBlockHeaders) ProtoMessage()    {}
 func (*FetchBlockHeaders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{4}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{4}
 }
 func (m *FetchBlockHeaders) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -280,7 +280,7 @@ func (m *SyncBlocks) Reset()         { *m = SyncBlocks{} }
 func (m *SyncBlocks) String() string { return proto.CompactTextString(m) }
 func (*SyncBlocks) ProtoMessage()    {}
 func (*SyncBlocks) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sync_5244f3e500ccc9a7, []int{5}
+	return fileDescriptor_sync_6caf10ac714581d5, []int{5}
 }
 func (m *SyncBlocks) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -323,6 +323,269 @@ func (m *SyncBlocks) GetBlocks() []*pb.Block {
 	return nil
 }
 
+type FetchSnapshotManifest struct {
+	// hash of the snapshot block, empty for the latest eternal block
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// tail height of the requester, only snapshots after it are served
+	Height uint32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *FetchSnapshotManifest) Reset()         { *m = FetchSnapshotManifest{} }
+func (m *FetchSnapshotManifest) String() string { return proto.CompactTextString(m) }
+func (*FetchSnapshotManifest) ProtoMessage()    {}
+func (*FetchSnapshotManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sync_6caf10ac714581d5, []int{6}
+}
+func (m *FetchSnapshotManifest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchSnapshotManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchSnapshotManifest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *FetchSnapshotManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchSnapshotManifest.Merge(dst, src)
+}
+func (m *FetchSnapshotManifest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchSnapshotManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchSnapshotManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchSnapshotManifest proto.InternalMessageInfo
+
+func (m *FetchSnapshotManifest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *FetchSnapshotManifest) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type SnapshotManifest struct {
+	// root hash of the utxo snapshot, empty if no snapshot is served
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// the snapshot block followed by its ancestors, latest first
+	Blocks      []*pb.Block `protobuf:"bytes,2,rep,name=blocks" json:"blocks,omitempty"`
+	Candidates  []byte      `protobuf:"bytes,3,opt,name=candidates,proto3" json:"candidates,omitempty"`
+	Period      []byte      `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	ChunkHashes [][]byte    `protobuf:"bytes,5,rep,name=chunk_hashes,json=chunkHashes" json:"chunk_hashes,omitempty"`
+	// finality certificate of the snapshot block, i.e., its confirmations by
+	// producers of the period it commits to
+	Signatures [][]byte `protobuf:"bytes,6,rep,name=signatures" json:"signatures,omitempty"`
+}
+
+func (m *SnapshotManifest) Reset()         { *m = SnapshotManifest{} }
+func (m *SnapshotManifest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotManifest) ProtoMessage()    {}
+func (*SnapshotManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sync_6caf10ac714581d5, []int{7}
+}
+func (m *SnapshotManifest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotManifest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotManifest.Merge(dst, src)
+}
+func (m *SnapshotManifest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotManifest proto.InternalMessageInfo
+
+func (m *SnapshotManifest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetBlocks() []*pb.Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetCandidates() []byte {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetPeriod() []byte {
+	if m != nil {
+		return m.Period
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetChunkHashes() [][]byte {
+	if m != nil {
+		return m.ChunkHashes
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+type FetchSnapshotChunk struct {
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Idx  uint32 `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+}
+
+func (m *FetchSnapshotChunk) Reset()         { *m = FetchSnapshotChunk{} }
+func (m *FetchSnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*FetchSnapshotChunk) ProtoMessage()    {}
+func (*FetchSnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sync_6caf10ac714581d5, []int{8}
+}
+func (m *FetchSnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchSnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchSnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *FetchSnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchSnapshotChunk.Merge(dst, src)
+}
+func (m *FetchSnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchSnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchSnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchSnapshotChunk proto.InternalMessageInfo
+
+func (m *FetchSnapshotChunk) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *FetchSnapshotChunk) GetIdx() uint32 {
+	if m != nil {
+		return m.Idx
+	}
+	return 0
+}
+
+type SnapshotChunk struct {
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Idx  uint32 `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	// utxo db keys and marshaled utxo wraps
+	Keys   [][]byte `protobuf:"bytes,3,rep,name=keys" json:"keys,omitempty"`
+	Values [][]byte `protobuf:"bytes,4,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sync_6caf10ac714581d5, []int{9}
+}
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(dst, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetIdx() uint32 {
+	if m != nil {
+		return m.Idx
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*LocateHeaders)(nil), "pb.LocateHeaders")
 	proto.RegisterType((*SyncHeaders)(nil), "pb.SyncHeaders")
@@ -330,6 +593,10 @@ func init() {
 	proto.RegisterType((*SyncCheckHash)(nil), "pb.SyncCheckHash")
 	proto.RegisterType((*FetchBlockHeaders)(nil), "pb.FetchBlockHeaders")
 	proto.RegisterType((*SyncBlocks)(nil), "pb.SyncBlocks")
+	proto.RegisterType((*FetchSnapshotManifest)(nil), "pb.FetchSnapshotManifest")
+	proto.RegisterType((*SnapshotManifest)(nil), "pb.SnapshotManifest")
+	proto.RegisterType((*FetchSnapshotChunk)(nil), "pb.FetchSnapshotChunk")
+	proto.RegisterType((*SnapshotChunk)(nil), "pb.SnapshotChunk")
 }
 func (m *LocateHeaders) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -505,72 +772,239 @@ func (m *SyncBlocks) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintSync(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *FetchSnapshotManifest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *LocateHeaders) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *FetchSnapshotManifest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Hashes) > 0 {
-		for _, b := range m.Hashes {
-			l = len(b)
-			n += 1 + l + sovSync(uint64(l))
-		}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
 	}
-	return n
+	if m.Height != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(m.Height))
+	}
+	return i, nil
 }
 
-func (m *SyncHeaders) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Hashes) > 0 {
-		for _, b := range m.Hashes {
-			l = len(b)
-			n += 1 + l + sovSync(uint64(l))
-		}
+func (m *SnapshotManifest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CheckHash) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SnapshotManifest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.BeginHash)
-	if l > 0 {
-		n += 1 + l + sovSync(uint64(l))
+	if len(m.Root) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
 	}
-	if m.Length != 0 {
-		n += 1 + sovSync(uint64(m.Length))
+	if len(m.Blocks) > 0 {
+		for _, msg := range m.Blocks {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSync(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return n
-}
-
-func (m *SyncCheckHash) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Candidates) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Candidates)))
+		i += copy(dAtA[i:], m.Candidates)
 	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovSync(uint64(l))
+	if len(m.Period) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Period)))
+		i += copy(dAtA[i:], m.Period)
 	}
-	return n
+	if len(m.ChunkHashes) > 0 {
+		for _, b := range m.ChunkHashes {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintSync(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintSync(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *FetchSnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchSnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Root) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if m.Idx != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(m.Idx))
+	}
+	return i, nil
+}
+
+func (m *SnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Root) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if m.Idx != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSync(dAtA, i, uint64(m.Idx))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSync(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintSync(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintSync(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *LocateHeaders) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SyncHeaders) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hashes) > 0 {
+		for _, b := range m.Hashes {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CheckHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.BeginHash)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if m.Length != 0 {
+		n += 1 + sovSync(uint64(m.Length))
+	}
+	return n
+}
+
+func (m *SyncCheckHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	return n
 }
 
 func (m *FetchBlockHeaders) Size() (n int) {
@@ -610,6 +1044,105 @@ func (m *SyncBlocks) Size() (n int) {
 	return n
 }
 
+func (m *FetchSnapshotManifest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovSync(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *SnapshotManifest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if len(m.Blocks) > 0 {
+		for _, e := range m.Blocks {
+			l = e.Size()
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	l = len(m.Candidates)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	l = len(m.Period)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if len(m.ChunkHashes) > 0 {
+		for _, b := range m.ChunkHashes {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FetchSnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if m.Idx != 0 {
+		n += 1 + sovSync(uint64(m.Idx))
+	}
+	return n
+}
+
+func (m *SnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovSync(uint64(l))
+	}
+	if m.Idx != 0 {
+		n += 1 + sovSync(uint64(m.Idx))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovSync(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovSync(x uint64) (n int) {
 	for {
 		n++
@@ -804,15 +1337,415 @@ func (m *CheckHash) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckHash: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckHash: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckHash: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginHash = append(m.BeginHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BeginHash == nil {
+				m.BeginHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncCheckHash) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncCheckHash: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncCheckHash: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchBlockHeaders: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchBlockHeaders: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Idx", wireType)
+			}
+			m.Idx = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Idx |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginHash = append(m.BeginHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BeginHash == nil {
+				m.BeginHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncBlocks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncBlocks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Idx", wireType)
+			}
+			m.Idx = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Idx |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blocks = append(m.Blocks, &pb.Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchSnapshotManifest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchSnapshotManifest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckHash: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FetchSnapshotManifest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -836,16 +1769,16 @@ func (m *CheckHash) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BeginHash = append(m.BeginHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.BeginHash == nil {
-				m.BeginHash = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Length = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSync
@@ -855,7 +1788,7 @@ func (m *CheckHash) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Length |= (uint32(b) & 0x7F) << shift
+				m.Height |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -881,7 +1814,7 @@ func (m *CheckHash) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SyncCheckHash) Unmarshal(dAtA []byte) error {
+func (m *SnapshotManifest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -904,15 +1837,15 @@ func (m *SyncCheckHash) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SyncCheckHash: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotManifest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SyncCheckHash: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotManifest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -936,10 +1869,161 @@ func (m *SyncCheckHash) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blocks = append(m.Blocks, &pb.Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates[:0], dAtA[iNdEx:postIndex]...)
+			if m.Candidates == nil {
+				m.Candidates = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Period", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Period = append(m.Period[:0], dAtA[iNdEx:postIndex]...)
+			if m.Period == nil {
+				m.Period = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkHashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChunkHashes = append(m.ChunkHashes, make([]byte, postIndex-iNdEx))
+			copy(m.ChunkHashes[len(m.ChunkHashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -962,7 +2046,7 @@ func (m *SyncCheckHash) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
+func (m *FetchSnapshotChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -985,34 +2069,15 @@ func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchBlockHeaders: wiretype end group for non-group")
+			return fmt.Errorf("proto: FetchSnapshotChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchBlockHeaders: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FetchSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Idx", wireType)
-			}
-			m.Idx = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSync
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Idx |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1036,16 +2101,16 @@ func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BeginHash = append(m.BeginHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.BeginHash == nil {
-				m.BeginHash = []byte{}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Idx", wireType)
 			}
-			m.Length = 0
+			m.Idx = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSync
@@ -1055,7 +2120,7 @@ func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Length |= (uint32(b) & 0x7F) << shift
+				m.Idx |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1081,7 +2146,7 @@ func (m *FetchBlockHeaders) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SyncBlocks) Unmarshal(dAtA []byte) error {
+func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1104,13 +2169,44 @@ func (m *SyncBlocks) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SyncBlocks: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SyncBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Idx", wireType)
 			}
@@ -1129,11 +2225,11 @@ func (m *SyncBlocks) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSync
@@ -1143,22 +2239,49 @@ func (m *SyncBlocks) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthSync
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Blocks = append(m.Blocks, &pb.Block{})
-			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1286,28 +2409,37 @@ var (
 	ErrIntOverflowSync   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("sync.proto", fileDescriptor_sync_5244f3e500ccc9a7) }
-
-var fileDescriptor_sync_5244f3e500ccc9a7 = []byte{
-	// 309 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0x97, 0x16, 0x8a, 0x7b, 0xb7, 0x82, 0xee, 0x30, 0x8a, 0x62, 0x28, 0x85, 0x61, 0x0f,
-	0xd2, 0xa2, 0x7e, 0x83, 0x8a, 0x63, 0x07, 0x41, 0xa8, 0x17, 0x0f, 0x82, 0x34, 0x69, 0x58, 0xca,
-	0x66, 0x52, 0x9a, 0x0c, 0xb6, 0x6f, 0xe1, 0xc7, 0xf2, 0xb8, 0xa3, 0x47, 0x59, 0xbf, 0x88, 0x24,
-	0x56, 0xfc, 0x83, 0xe2, 0x2d, 0xef, 0xfb, 0xbc, 0xef, 0x93, 0x5f, 0x9e, 0x00, 0xa8, 0x8d, 0xa0,
-	0x49, 0xdd, 0x48, 0x2d, 0x47, 0x4e, 0x4d, 0x0e, 0xcf, 0xe6, 0x95, 0xe6, 0x2b, 0x92, 0x50, 0xf9,
-	0x98, 0x66, 0x37, 0x77, 0x53, 0xb9, 0x12, 0x65, 0xa1, 0x2b, 0x29, 0x52, 0x22, 0xd7, 0x65, 0x4a,
-	0x65, 0xc3, 0xd2, 0x9a, 0xa4, 0x64, 0x29, 0xe9, 0xe2, 0x7d, 0x2d, 0x3a, 0x01, 0xff, 0x5a, 0xd2,
-	0x42, 0xb3, 0x19, 0x2b, 0x4a, 0xd6, 0xa8, 0xd1, 0x18, 0x3c, 0x5e, 0x28, 0xce, 0x54, 0x80, 0x42,
-	0x37, 0x1e, 0xe6, 0x5d, 0x15, 0x4d, 0x60, 0x70, 0xbb, 0x11, 0xf4, 0xbf, 0xb1, 0x0c, 0xfa, 0x97,
-	0x9c, 0xd1, 0xc5, 0xac, 0x50, 0x7c, 0x74, 0x0c, 0x40, 0xd8, 0xbc, 0x12, 0x0f, 0x46, 0x0c, 0x50,
-	0x88, 0xe2, 0x61, 0xde, 0xb7, 0x1d, 0x2b, 0x8f, 0xc1, 0x5b, 0x32, 0x31, 0xd7, 0x3c, 0x70, 0x42,
-	0x14, 0xfb, 0x79, 0x57, 0x45, 0xa7, 0xe0, 0x9b, 0xab, 0x3e, 0x7d, 0x8e, 0xa0, 0xdf, 0x48, 0xa9,
-	0xbf, 0xda, 0xec, 0x99, 0x86, 0x11, 0xa3, 0x7b, 0x38, 0x98, 0x32, 0x4d, 0x79, 0x66, 0x5e, 0xf5,
-	0x81, 0xb7, 0x0f, 0x6e, 0x55, 0xae, 0xed, 0xac, 0x9f, 0x9b, 0xe3, 0x0f, 0x16, 0xe7, 0x6f, 0x16,
-	0xf7, 0x1b, 0xcb, 0x15, 0x80, 0x61, 0xb1, 0xe6, 0xbf, 0xd9, 0x4e, 0xc0, 0xb3, 0x71, 0xaa, 0xc0,
-	0x09, 0xdd, 0x78, 0x70, 0xee, 0x27, 0x26, 0xe5, 0x9a, 0x24, 0x76, 0x23, 0xef, 0xc4, 0x2c, 0x78,
-	0xde, 0x61, 0xb4, 0xdd, 0x61, 0xf4, 0xba, 0xc3, 0xe8, 0xa9, 0xc5, 0xbd, 0x6d, 0x8b, 0x7b, 0x2f,
-	0x2d, 0xee, 0x11, 0xcf, 0xfe, 0xc3, 0xc5, 0x5b, 0x00, 0x00, 0x00, 0xff, 0xff, 0x58, 0x26, 0x2b,
-	0xb0, 0xcc, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("sync.proto", fileDescriptor_sync_6caf10ac714581d5) }
+
+var fileDescriptor_sync_6caf10ac714581d5 = []byte{
+	// 458 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x41, 0x8b, 0xd3, 0x40,
+	0x14, 0x6e, 0xd2, 0x1a, 0xec, 0x6b, 0x03, 0x6b, 0xc0, 0x25, 0x28, 0x86, 0x1a, 0x58, 0xec, 0x41,
+	0x1a, 0xd4, 0x9b, 0xc7, 0x16, 0x97, 0x1e, 0x14, 0x21, 0x7b, 0xf1, 0x20, 0x2c, 0x33, 0x93, 0x31,
+	0x33, 0xb4, 0xce, 0x84, 0xcc, 0x44, 0xb6, 0xff, 0xc2, 0x9f, 0xb5, 0xc7, 0x3d, 0x7a, 0x94, 0xf6,
+	0x8f, 0xc8, 0x9b, 0xa6, 0x9a, 0x4a, 0x45, 0xf1, 0x36, 0xef, 0x7b, 0xef, 0x7d, 0xf9, 0xbe, 0x8f,
+	0x17, 0x00, 0xb3, 0x51, 0x6c, 0x56, 0xd5, 0xda, 0xea, 0xc8, 0xaf, 0xe8, 0xa3, 0x17, 0xa5, 0xb4,
+	0xa2, 0xa1, 0x33, 0xa6, 0x3f, 0x67, 0xf3, 0xf7, 0x1f, 0x2e, 0x75, 0xa3, 0x0a, 0x62, 0xa5, 0x56,
+	0x19, 0xd5, 0x37, 0x45, 0xc6, 0x74, 0xcd, 0xb3, 0x8a, 0x66, 0x74, 0xad, 0xd9, 0x6a, 0xbf, 0x96,
+	0x3e, 0x83, 0xf0, 0xad, 0x66, 0xc4, 0xf2, 0x25, 0x27, 0x05, 0xaf, 0x4d, 0x74, 0x0e, 0x81, 0x20,
+	0x46, 0x70, 0x13, 0x7b, 0x93, 0xfe, 0x74, 0x9c, 0xb7, 0x55, 0x7a, 0x01, 0xa3, 0xab, 0x8d, 0x62,
+	0x7f, 0x1b, 0x9b, 0xc3, 0x70, 0x21, 0x38, 0x5b, 0x2d, 0x89, 0x11, 0xd1, 0x13, 0x00, 0xca, 0x4b,
+	0xa9, 0xae, 0xb1, 0x19, 0x7b, 0x13, 0x6f, 0x3a, 0xce, 0x87, 0x0e, 0x71, 0xed, 0x73, 0x08, 0xd6,
+	0x5c, 0x95, 0x56, 0xc4, 0xfe, 0xc4, 0x9b, 0x86, 0x79, 0x5b, 0xa5, 0xcf, 0x21, 0xc4, 0x4f, 0xfd,
+	0xe2, 0x79, 0x0c, 0xc3, 0x5a, 0x6b, 0xdb, 0xa5, 0xb9, 0x8f, 0x00, 0x36, 0xd3, 0x8f, 0xf0, 0xe0,
+	0x92, 0x5b, 0x26, 0xe6, 0xe8, 0xea, 0x20, 0xef, 0x0c, 0xfa, 0xb2, 0xb8, 0x71, 0xb3, 0x61, 0x8e,
+	0xcf, 0xdf, 0xb4, 0xf8, 0x7f, 0xd6, 0xd2, 0x3f, 0xd2, 0xf2, 0x06, 0x00, 0xb5, 0x38, 0xf2, 0x53,
+	0xb4, 0x17, 0x10, 0xb8, 0x38, 0x4d, 0xec, 0x4f, 0xfa, 0xd3, 0xd1, 0xcb, 0x70, 0x86, 0x29, 0x57,
+	0x74, 0xe6, 0x36, 0xf2, 0xb6, 0x99, 0x2e, 0xe0, 0xa1, 0x13, 0x79, 0xa5, 0x48, 0x65, 0x84, 0xb6,
+	0xef, 0x88, 0x92, 0x9f, 0xb8, 0xb1, 0x51, 0x04, 0x83, 0x8e, 0xab, 0x81, 0x68, 0xb5, 0x08, 0x2e,
+	0x4b, 0x61, 0x0f, 0xb9, 0xec, 0xab, 0xf4, 0xd6, 0x83, 0xb3, 0x53, 0x04, 0x18, 0xc5, 0x81, 0x00,
+	0xdf, 0xff, 0x28, 0x2a, 0x4a, 0x00, 0x18, 0x51, 0x85, 0x2c, 0x88, 0xe5, 0xc6, 0xf9, 0x1e, 0xe7,
+	0x1d, 0x04, 0x75, 0x54, 0xbc, 0x96, 0xba, 0x88, 0x07, 0xae, 0xd7, 0x56, 0xd1, 0x53, 0x18, 0x33,
+	0xd1, 0xa8, 0xd5, 0x75, 0x7b, 0x01, 0xf7, 0xdc, 0x05, 0x8c, 0x1c, 0xb6, 0x74, 0x10, 0x52, 0x1b,
+	0x59, 0x2a, 0x62, 0x9b, 0x9a, 0x9b, 0x38, 0x70, 0x03, 0x1d, 0x24, 0x7d, 0x0d, 0xd1, 0x51, 0x1e,
+	0x0b, 0xdc, 0x3d, 0xe9, 0xa5, 0x8d, 0xdc, 0xff, 0x19, 0x79, 0x4a, 0x20, 0xfc, 0x8f, 0x35, 0x9c,
+	0x5a, 0xf1, 0x0d, 0xfa, 0x44, 0x31, 0xee, 0x8d, 0x0e, 0xbf, 0x90, 0x75, 0xc3, 0x4d, 0x3c, 0xd8,
+	0x5f, 0xf1, 0xbe, 0x9a, 0xc7, 0xb7, 0xdb, 0xc4, 0xbb, 0xdb, 0x26, 0xde, 0xf7, 0x6d, 0xe2, 0x7d,
+	0xdd, 0x25, 0xbd, 0xbb, 0x5d, 0xd2, 0xfb, 0xb6, 0x4b, 0x7a, 0x34, 0x70, 0xbf, 0xcd, 0xab, 0x1f,
+	0x03, 0x00, 0x28, 0xa2, 0xe7, 0xb6, 0x7b, 0x03, 0x00, 0x00,
 }
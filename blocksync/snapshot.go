@@ -0,0 +1,404 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BOXFoundation/boxd/consensus/dpos"
+	"github.com/BOXFoundation/boxd/core/chain"
+	coreTypes "github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/p2p"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+var (
+	errNoSnapshot              = errors.New("no utxo snapshot to sync")
+	errInvalidSnapshotManifest = errors.New("invalid snapshot manifest")
+	errSnapshotRootMismatch    = errors.New("snapshot root mismatch")
+	errUnexpectedChunk         = errors.New("unexpected snapshot chunk")
+	errChunkMismatch           = errors.New("snapshot chunk mismatch with its hash")
+	errSnapshotNotFinal        = errors.New("snapshot block without valid finality certificate")
+)
+
+// snapshotBlocks returns number of blocks carried in a snapshot, i.e., the snapshot
+// block and its ancestors needed to verify miner epochs of blocks after it
+func snapshotBlocks() int {
+	return dpos.PeriodSize
+}
+
+// manifestResp is a snapshot manifest responded by a peer
+type manifestResp struct {
+	pid      peer.ID
+	manifest *SnapshotManifest
+}
+
+// snapshotDownloader schedules downloading chunks of a utxo snapshot from
+// multiple peers in parallel. Chunks can be downloaded in any order since they
+// are installed all at once.
+type snapshotDownloader struct {
+	chunkScheduler
+	hashes     []*crypto.HashType
+	chunks     []*chain.SnapshotChunk
+	downloaded int
+}
+
+func newSnapshotDownloader(hashes []*crypto.HashType, window int,
+	timeout time.Duration) *snapshotDownloader {
+	return &snapshotDownloader{
+		chunkScheduler: newChunkScheduler(len(hashes), window, timeout),
+		hashes:         hashes,
+		chunks:         make([]*chain.SnapshotChunk, len(hashes)),
+	}
+}
+
+// schedule assigns pending chunks to peers, and returns the requests to send
+func (dl *snapshotDownloader) schedule(now time.Time) []*chunkAssignment {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+	return dl.assignLocked(now)
+}
+
+// onChunk accepts chunk idx from pid. The peer is removed and its chunks are
+// reassigned if the chunk does not match its hash.
+func (dl *snapshotDownloader) onChunk(pid peer.ID, idx uint32, chunk *chain.SnapshotChunk) error {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+
+	if !dl.inFlightToLocked(pid, idx) {
+		return errUnexpectedChunk
+	}
+	if *chunk.Hash() != *dl.hashes[idx] {
+		dl.removePeerLocked(pid)
+		return errChunkMismatch
+	}
+	dl.chunks[idx] = chunk
+	dl.doneLocked(idx)
+	dl.downloaded++
+	return nil
+}
+
+// finished returns if all chunks are downloaded
+func (dl *snapshotDownloader) finished() bool {
+	dl.mtx.Lock()
+	defer dl.mtx.Unlock()
+	return dl.downloaded == len(dl.chunks)
+}
+
+// startSnapshotSync installs the utxo snapshot as of an eternal block served by
+// peers to a node having no blocks, then syncs blocks after the snapshot block.
+// It falls back to sync from genesis if no snapshot can be installed.
+func (sm *SyncManager) startSnapshotSync() {
+	p2p.UpdateSynced(false)
+	// prevent startSync being executed again
+	sm.setStatus(snapshotStatus)
+	// sleep 1s to wait for connections to establish
+	time.Sleep(time.Second)
+
+	for tries := 0; tries < maxSyncTries; tries++ {
+		err := sm.syncSnapshot()
+		if err == nil {
+			break
+		}
+		if err == errNoSnapshot {
+			logger.Info("no utxo snapshot to sync, sync from genesis")
+			break
+		}
+		logger.Warnf("sync utxo snapshot error: %s", err)
+		time.Sleep(retryInterval)
+	}
+
+	sm.reset()
+	sm.stalePeers = new(sync.Map)
+	if sm.cfg.HeadersFirst {
+		sm.startHeadersFirstSync()
+		return
+	}
+	sm.startSync()
+}
+
+// syncSnapshot fetches a snapshot manifest from one peer, verifies its finality
+// certificate, confirms its root with other peers, then downloads chunks from
+// them in parallel and installs it
+func (sm *SyncManager) syncSnapshot() error {
+	pids := sm.pickBlocksSyncPeers(maxCheckPeers + 1)
+	if len(pids) == 0 {
+		return errNoPeerToSync
+	}
+	manifest, pid, err := sm.fetchSnapshotManifest(pids)
+	if err != nil {
+		return err
+	}
+	snapshot, err := sm.verifySnapshotManifest(manifest)
+	if err != nil {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		return err
+	}
+	others := make([]peer.ID, 0, len(pids)-1)
+	for _, id := range pids {
+		if id != pid {
+			others = append(others, id)
+		}
+	}
+	confirmed, err := sm.confirmSnapshotManifest(manifest, others)
+	if err != nil {
+		return err
+	}
+	block := snapshot.Block()
+	logger.Infof("utxo snapshot %s at block %s height %d with %d chunks confirmed by "+
+		"%d peers, start to sync chunks", manifest.Root, block.BlockHash(), block.Height,
+		len(snapshot.ChunkHashes), len(confirmed))
+
+	if err := sm.syncSnapshotChunks(snapshot, manifest.Root,
+		append(confirmed, pid)); err != nil {
+		return err
+	}
+	if err := sm.chain.InstallUtxoSnapshot(snapshot); err != nil {
+		return err
+	}
+	return sm.consensus.LoadSnapshotContext()
+}
+
+// fetchSnapshotManifest fetches the manifest of the latest snapshot from pids one
+// by one until a peer serves one
+func (sm *SyncManager) fetchSnapshotManifest(pids []peer.ID) (
+	*SnapshotManifest, peer.ID, error) {
+	fsm := newFetchSnapshotManifest(nil, sm.chain.TailBlock().Height)
+	for _, pid := range pids {
+		sm.drainManifestChan()
+		sm.stalePeers.Store(pid, snapshotPeerStatus)
+		logger.Infof("send message[0x%X] to peer %s", p2p.SnapshotManifestRequest,
+			pid.Pretty())
+		if err := sm.p2pNet.SendMessageToPeer(p2p.SnapshotManifestRequest, fsm, pid); err != nil {
+			sm.stalePeers.Store(pid, errPeerStatus)
+			continue
+		}
+		resps := sm.waitSnapshotManifests(1)
+		if len(resps) == 0 {
+			sm.stalePeers.Store(pid, errPeerStatus)
+			continue
+		}
+		sm.stalePeers.Store(pid, snapshotDonePeerStatus)
+		if *resps[0].manifest.Root != *zeroHash {
+			return resps[0].manifest, pid, nil
+		}
+	}
+	return nil, peer.ID(""), errNoSnapshot
+}
+
+// verifySnapshotManifest checks manifest root and blocks, and returns the
+// snapshot it describes without chunks. The snapshot block is trusted with its
+// finality certificate by producers of the period it commits to, which comes
+// with the manifest since a node syncing a snapshot has no period contexts, and
+// its ancestors with the hash chain to it.
+func (sm *SyncManager) verifySnapshotManifest(manifest *SnapshotManifest) (
+	*chain.UtxoSnapshot, error) {
+	if len(manifest.Blocks) == 0 || len(manifest.Blocks) > snapshotBlocks() {
+		return nil, errInvalidSnapshotManifest
+	}
+	snapshot := &chain.UtxoSnapshot{
+		Blocks:      manifest.Blocks,
+		Candidates:  manifest.Candidates,
+		Period:      manifest.Period,
+		ChunkHashes: manifest.ChunkHashes,
+	}
+	if *snapshot.Root() != *manifest.Root {
+		return nil, errSnapshotRootMismatch
+	}
+	if snapshot.Block().Height <= sm.chain.TailBlock().Height {
+		return nil, errNoSnapshot
+	}
+	// blocks are latest first
+	n := len(manifest.Blocks)
+	headers := make([]*coreTypes.Block, 0, n-1)
+	for i := n - 2; i >= 0; i-- {
+		headers = append(headers, manifest.Blocks[i])
+	}
+	if err := checkHeadersChain(manifest.Blocks[n-1], headers, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	if err := checkHeadersPeriod(manifest.Blocks[n-1], headers); err != nil {
+		return nil, err
+	}
+
+	block := snapshot.Block()
+	period, err := dpos.CommittedPeriodContext(block.Header, manifest.Period)
+	if err != nil {
+		return nil, err
+	}
+	periodHash := &block.Header.PeriodHash
+	if *periodHash == *zeroHash {
+		if periodHash, err = dpos.GenesisPeriodHash(); err != nil {
+			return nil, err
+		}
+	}
+	cert := &dpos.FinalityCertificate{
+		Header:        block.Header,
+		Height:        block.Height,
+		PeriodContext: period,
+		Signatures:    manifest.Signatures,
+	}
+	if err := cert.Verify(periodHash); err != nil {
+		logger.Warnf("Failed to verify finality certificate of snapshot block %v "+
+			"at height %d: %v", block.BlockHash(), block.Height, err)
+		return nil, errSnapshotNotFinal
+	}
+	// blocks after the period begins are produced by its producers, the one
+	// beginning it and earlier ones are scheduled by periods before
+	for _, b := range manifest.Blocks {
+		if b.Header.PeriodHash != block.Header.PeriodHash || b.Height%chain.PeriodDuration == 0 {
+			break
+		}
+		if ok, err := dpos.VerifySignWithPeriod(b, period); err != nil || !ok {
+			logger.Warnf("Failed to verify snapshot block signature. Hash: %v, "+
+				"Height: %d, Err: %v", b.BlockHash(), b.Height, err)
+			return nil, errInvalidHeaderSignature
+		}
+	}
+	return snapshot, nil
+}
+
+// confirmSnapshotManifest asks pids for their snapshots at the same block, and
+// returns peers serving a snapshot with the same root. It fails if any peer
+// serves a different one.
+func (sm *SyncManager) confirmSnapshotManifest(manifest *SnapshotManifest,
+	pids []peer.ID) ([]peer.ID, error) {
+	sm.drainManifestChan()
+	fsm := newFetchSnapshotManifest(manifest.Blocks[0].BlockHash(),
+		sm.chain.TailBlock().Height)
+	for _, pid := range pids {
+		sm.stalePeers.Store(pid, snapshotPeerStatus)
+		logger.Infof("send message[0x%X] body[%+v] to peer %s",
+			p2p.SnapshotManifestRequest, fsm, pid.Pretty())
+		if err := sm.p2pNet.SendMessageToPeer(p2p.SnapshotManifestRequest, fsm, pid); err != nil {
+			sm.stalePeers.Store(pid, errPeerStatus)
+		}
+	}
+	confirmed := make([]peer.ID, 0, len(pids))
+	for _, r := range sm.waitSnapshotManifests(len(pids)) {
+		sm.stalePeers.Store(r.pid, snapshotDonePeerStatus)
+		// the peer may not have the snapshot block eternal yet
+		if *r.manifest.Root == *zeroHash {
+			continue
+		}
+		if *r.manifest.Root != *manifest.Root {
+			logger.Warnf("snapshot root %s from peer %s mismatch with %s",
+				r.manifest.Root, r.pid.Pretty(), manifest.Root)
+			return nil, errSnapshotRootMismatch
+		}
+		confirmed = append(confirmed, r.pid)
+	}
+	return confirmed, nil
+}
+
+// waitSnapshotManifests waits for n manifests until timeout
+func (sm *SyncManager) waitSnapshotManifests(n int) []*manifestResp {
+	timer := time.NewTimer(syncTimeout)
+	defer cleanStopTimer(timer)
+	resps := make([]*manifestResp, 0, n)
+	for len(resps) < n {
+		select {
+		case r := <-sm.manifestCh:
+			resps = append(resps, r)
+		case <-timer.C:
+			sm.setTimeoutPeersErrStatus(snapshotPeerStatus)
+			return resps
+		case <-sm.proc.Closing():
+			return resps
+		}
+	}
+	return resps
+}
+
+// syncSnapshotChunks downloads all chunks of snapshot with root from pids
+func (sm *SyncManager) syncSnapshotChunks(snapshot *chain.UtxoSnapshot,
+	root *crypto.HashType, pids []peer.ID) error {
+	dl := newSnapshotDownloader(snapshot.ChunkHashes, maxChunksInFlightPerPeer, blocksTimeout)
+	dl.addPeers(pids...)
+	sm.setSnapshotDownloader(dl)
+	defer sm.setSnapshotDownloader(nil)
+	sm.drainChunksChan()
+
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+	for !dl.finished() {
+		if dl.peerCount() == 0 {
+			return errNoPeerToSync
+		}
+		sm.sendSnapshotChunkRequests(dl, root, dl.schedule(time.Now()))
+
+		select {
+		case <-sm.chunksDoneCh:
+		case <-ticker.C:
+			for _, pid := range dl.stalledPeers(time.Now()) {
+				logger.Warnf("peer %s stalled in snapshot sync, reassign its chunks", pid.Pretty())
+				sm.stalePeers.Store(pid, errPeerStatus)
+			}
+		case <-sm.proc.Closing():
+			return errors.New("sync manager is closing")
+		}
+	}
+	snapshot.Chunks = dl.chunks
+	return nil
+}
+
+func (sm *SyncManager) sendSnapshotChunkRequests(dl *snapshotDownloader,
+	root *crypto.HashType, requests []*chunkAssignment) {
+	for _, r := range requests {
+		fsc := newFetchSnapshotChunk(root, r.idx)
+		logger.Debugf("send message[0x%X] body:%+v to peer %s", p2p.SnapshotChunkRequest,
+			fsc, r.pid.Pretty())
+		sm.stalePeers.Store(r.pid, snapshotPeerStatus)
+		if err := sm.p2pNet.SendMessageToPeer(p2p.SnapshotChunkRequest, fsc, r.pid); err != nil {
+			logger.Warnf("send message[0x%X] to peer %s error: %s", p2p.SnapshotChunkRequest,
+				r.pid.Pretty(), err)
+			sm.stalePeers.Store(r.pid, errPeerStatus)
+			dl.removePeer(r.pid)
+		}
+	}
+}
+
+func (sm *SyncManager) setSnapshotDownloader(dl *snapshotDownloader) {
+	sm.downloaderMtx.Lock()
+	sm.snapshotDownloader = dl
+	sm.downloaderMtx.Unlock()
+}
+
+func (sm *SyncManager) getSnapshotDownloader() *snapshotDownloader {
+	sm.downloaderMtx.RLock()
+	defer sm.downloaderMtx.RUnlock()
+	return sm.snapshotDownloader
+}
+
+func (sm *SyncManager) drainManifestChan() {
+	for {
+		select {
+		case <-sm.manifestCh:
+		default:
+			return
+		}
+	}
+}
+
+func (sm *SyncManager) drainChunksChan() {
+	for {
+		if !tryPopEmptyChan(sm.chunksDoneCh) {
+			break
+		}
+	}
+}
+
+func tryPushManifestChan(ch chan<- *manifestResp, v *manifestResp) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		logger.Info("cannot push snapshot manifest to chan")
+		return false
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/BOXFoundation/boxd/core"
 	"github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/p2p"
 	"github.com/BOXFoundation/boxd/util"
 )
@@ -39,6 +40,12 @@ func (sm *SyncManager) subscribeMessageNotifiee() {
 	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.BlockChunkResponse, p2p.Repeatable, sm.messageCh))
 	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.LightSyncRequest, p2p.Repeatable, sm.messageCh))
 	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.LightSyncReponse, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.HeadersRequest, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.HeadersResponse, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.SnapshotManifestRequest, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.SnapshotManifestResponse, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.SnapshotChunkRequest, p2p.Repeatable, sm.messageCh))
+	sm.p2pNet.Subscribe(p2p.NewNotifiee(p2p.SnapshotChunkResponse, p2p.Repeatable, sm.messageCh))
 }
 
 func (sm *SyncManager) handleSyncMessage() {
@@ -64,6 +71,18 @@ func (sm *SyncManager) handleSyncMessage() {
 				err = sm.onLightSyncRequest(msg)
 			case p2p.LightSyncReponse:
 				err = sm.onLightSyncResponse(msg)
+			case p2p.HeadersRequest:
+				err = sm.onHeadersRequest(msg)
+			case p2p.HeadersResponse:
+				err = sm.onHeadersResponse(msg)
+			case p2p.SnapshotManifestRequest:
+				err = sm.onSnapshotManifestRequest(msg)
+			case p2p.SnapshotManifestResponse:
+				err = sm.onSnapshotManifestResponse(msg)
+			case p2p.SnapshotChunkRequest:
+				err = sm.onSnapshotChunkRequest(msg)
+			case p2p.SnapshotChunkResponse:
+				err = sm.onSnapshotChunkResponse(msg)
 			default:
 				logger.Warn("Failed to handle sync msg, unknow msg code")
 			}
@@ -262,6 +281,9 @@ func (sm *SyncManager) onBlocksRequest(msg p2p.Message) (err error) {
 }
 
 func (sm *SyncManager) onBlocksResponse(msg p2p.Message) error {
+	if dl := sm.getDownloader(); dl != nil {
+		return sm.onDownloaderBlocksResponse(msg, dl)
+	}
 	if sm.getStatus() != blocksStatus {
 		return fmt.Errorf("onBlocksResponse returns since now status is %s",
 			sm.getStatus())
@@ -324,6 +346,203 @@ func (sm *SyncManager) onBlocksResponse(msg p2p.Message) error {
 	return nil
 }
 
+// onDownloaderBlocksResponse hands blocks to the downloader in headers first sync
+func (sm *SyncManager) onDownloaderBlocksResponse(msg p2p.Message, dl *blockDownloader) error {
+	pid := msg.From()
+	// wake up the sync loop to process blocks or reassign chunks
+	defer tryPushEmptyChan(sm.blocksDoneCh)
+	sb := new(SyncBlocks)
+	if err := sb.Unmarshal(msg.Body()); err != nil || sb.Idx == math.MaxUint32 {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		dl.removePeer(pid)
+		return fmt.Errorf("Failed to unmarshal syncblocks. Err: %v or msg.From is "+
+			"in wrong status(Idx: %d)", err, sb.Idx)
+	}
+	if err := dl.onBlocks(pid, sb.Idx, sb.Blocks); err != nil {
+		if err == errBlocksMismatch {
+			sm.stalePeers.Store(pid, errPeerStatus)
+		}
+		return fmt.Errorf("onBlocksResponse from peer %s with idx %d: %s",
+			pid.Pretty(), sb.Idx, err)
+	}
+	sm.stalePeers.Store(pid, blocksDonePeerStatus)
+	logger.Infof("receive %d blocks with idx %d from peer %s", len(sb.Blocks),
+		sb.Idx, pid.Pretty())
+	return nil
+}
+
+func (sm *SyncManager) onHeadersRequest(msg p2p.Message) error {
+	sm.chain.Bus().Publish(eventbus.TopicConnEvent, msg.From(), eventbus.SyncMsgEvent)
+	// not to been sync when the node is in sync status
+	if sm.getStatus() != freeStatus {
+		logger.Infof("now be in sync, send message[0x%X] without headers to peer %s",
+			p2p.HeadersResponse, msg.From().Pretty())
+		return sm.p2pNet.SendMessageToPeer(p2p.HeadersResponse,
+			newSyncBlocks(math.MaxUint32), msg.From())
+	}
+	lh := new(LocateHeaders)
+	if err := lh.Unmarshal(msg.Body()); err != nil {
+		return err
+	}
+	hashes, err := sm.chain.LocateForkPointAndFetchHeaders(lh.Hashes)
+	if err != nil {
+		logger.Warnf("onHeadersRequest fetch headers error: %s, hashes: %+v",
+			err, lh.Hashes)
+		return err
+	}
+	// blocks carrying header and signature only
+	headers := make([]*types.Block, 0, len(hashes))
+	for _, hash := range hashes {
+		block, err := sm.chain.LoadBlockByHash(*hash)
+		if err != nil {
+			return err
+		}
+		headers = append(headers, &types.Block{
+			Header:    block.Header,
+			Signature: block.Signature,
+			Height:    block.Height,
+		})
+	}
+	logger.Infof("onHeadersRequest send message[0x%X] (%d headers) to peer %s",
+		p2p.HeadersResponse, len(headers), msg.From().Pretty())
+	return sm.p2pNet.SendMessageToPeer(p2p.HeadersResponse,
+		newSyncBlocks(0, headers...), msg.From())
+}
+
+func (sm *SyncManager) onHeadersResponse(msg p2p.Message) error {
+	if sm.getStatus() != headersStatus {
+		return fmt.Errorf("onHeadersResponse returns since now status is %s",
+			sm.getStatus())
+	}
+	pid := msg.From()
+	if !sm.verifyPeerStatus(headersPeerStatus, pid) {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		return fmt.Errorf("receive HeadersResponse from non-sync peer[%s]",
+			pid.Pretty())
+	}
+	sb := new(SyncBlocks)
+	if err := sb.Unmarshal(msg.Body()); err != nil || sb.Idx == math.MaxUint32 {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		tryPushSyncBlocksChan(sm.headersCh, nil)
+		return fmt.Errorf("Failed to unmarshal headers. Err: %v or msg.From is "+
+			"in sync(Idx: %d)", err, sb.Idx)
+	}
+	logger.Infof("onHeadersResponse receive %d headers", len(sb.Blocks))
+	tryPushSyncBlocksChan(sm.headersCh, sb)
+	return nil
+}
+
+func (sm *SyncManager) onSnapshotManifestRequest(msg p2p.Message) error {
+	sm.chain.Bus().Publish(eventbus.TopicConnEvent, msg.From(), eventbus.SyncMsgEvent)
+	// not to been sync when the node is in sync status
+	if sm.getStatus() != freeStatus {
+		logger.Infof("now be in sync, send message[0x%X] without snapshot to peer %s",
+			p2p.SnapshotManifestResponse, msg.From().Pretty())
+		return sm.p2pNet.SendMessageToPeer(p2p.SnapshotManifestResponse,
+			newSnapshotManifest(nil, nil), msg.From())
+	}
+	fsm := new(FetchSnapshotManifest)
+	if err := fsm.Unmarshal(msg.Body()); err != nil {
+		return err
+	}
+	var hash *crypto.HashType
+	if *fsm.Hash != *zeroHash {
+		hash = fsm.Hash
+	}
+	snapshot, err := sm.chain.UtxoSnapshot(hash, snapshotBlocks()-1)
+	if err != nil || snapshot.Block().Height <= fsm.Height {
+		logger.Infof("onSnapshotManifestRequest no snapshot for %+v, err: %v", fsm, err)
+		snapshot = nil
+	}
+	// a snapshot is only trusted with finality certificate of its block
+	var signatures [][]byte
+	if snapshot != nil {
+		cert, err := sm.consensus.FinalityCertificate(snapshot.Block().BlockHash(), 0)
+		if err != nil {
+			logger.Infof("onSnapshotManifestRequest no finality certificate of snapshot "+
+				"block %s, err: %v", snapshot.Block().BlockHash(), err)
+			snapshot = nil
+		} else {
+			signatures = cert.Signatures
+		}
+	}
+	logger.Infof("onSnapshotManifestRequest send message[0x%X] to peer %s",
+		p2p.SnapshotManifestResponse, msg.From().Pretty())
+	return sm.p2pNet.SendMessageToPeer(p2p.SnapshotManifestResponse,
+		newSnapshotManifest(snapshot, signatures), msg.From())
+}
+
+func (sm *SyncManager) onSnapshotManifestResponse(msg p2p.Message) error {
+	if sm.getStatus() != snapshotStatus {
+		return fmt.Errorf("onSnapshotManifestResponse returns since now status is %s",
+			sm.getStatus())
+	}
+	pid := msg.From()
+	if !sm.verifyPeerStatus(snapshotPeerStatus, pid) {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		return fmt.Errorf("receive SnapshotManifestResponse from non-sync peer[%s]",
+			pid.Pretty())
+	}
+	manifest := new(SnapshotManifest)
+	if err := manifest.Unmarshal(msg.Body()); err != nil {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		tryPushManifestChan(sm.manifestCh, &manifestResp{pid: pid,
+			manifest: newSnapshotManifest(nil, nil)})
+		return fmt.Errorf("Failed to unmarshal snapshot manifest. Err: %v", err)
+	}
+	logger.Infof("onSnapshotManifestResponse receive snapshot %s with %d chunks "+
+		"from peer %s", manifest.Root, len(manifest.ChunkHashes), pid.Pretty())
+	tryPushManifestChan(sm.manifestCh, &manifestResp{pid: pid, manifest: manifest})
+	return nil
+}
+
+func (sm *SyncManager) onSnapshotChunkRequest(msg p2p.Message) error {
+	sm.chain.Bus().Publish(eventbus.TopicConnEvent, msg.From(), eventbus.SyncMsgEvent)
+	fsc := new(FetchSnapshotChunk)
+	if err := fsc.Unmarshal(msg.Body()); err != nil {
+		return err
+	}
+	// only the snapshot whose manifest is requested lately is served
+	snapshot := sm.chain.CachedUtxoSnapshot(fsc.Root)
+	if snapshot == nil || fsc.Idx >= uint32(len(snapshot.Chunks)) {
+		sm.p2pNet.SendMessageToPeer(p2p.SnapshotChunkResponse,
+			newSnapshotChunk(fsc.Root, math.MaxUint32, nil), msg.From())
+		return fmt.Errorf("onSnapshotChunkRequest no snapshot chunk for %+v", fsc)
+	}
+	logger.Debugf("onSnapshotChunkRequest send message[0x%X] (chunk %d) to peer %s",
+		p2p.SnapshotChunkResponse, fsc.Idx, msg.From().Pretty())
+	return sm.p2pNet.SendMessageToPeer(p2p.SnapshotChunkResponse,
+		newSnapshotChunk(fsc.Root, fsc.Idx, snapshot.Chunks[fsc.Idx]), msg.From())
+}
+
+func (sm *SyncManager) onSnapshotChunkResponse(msg p2p.Message) error {
+	dl := sm.getSnapshotDownloader()
+	if dl == nil {
+		return fmt.Errorf("onSnapshotChunkResponse returns since no snapshot in sync")
+	}
+	pid := msg.From()
+	// wake up the sync loop to reassign chunks or finish
+	defer tryPushEmptyChan(sm.chunksDoneCh)
+	sc := new(SnapshotChunk)
+	if err := sc.Unmarshal(msg.Body()); err != nil || sc.Idx == math.MaxUint32 {
+		sm.stalePeers.Store(pid, errPeerStatus)
+		dl.removePeer(pid)
+		return fmt.Errorf("Failed to unmarshal snapshot chunk. Err: %v or msg.From "+
+			"has no snapshot(Idx: %d)", err, sc.Idx)
+	}
+	if err := dl.onChunk(pid, sc.Idx, &sc.SnapshotChunk); err != nil {
+		if err == errChunkMismatch {
+			sm.stalePeers.Store(pid, errPeerStatus)
+		}
+		return fmt.Errorf("onSnapshotChunkResponse from peer %s with idx %d: %s",
+			pid.Pretty(), sc.Idx, err)
+	}
+	sm.stalePeers.Store(pid, snapshotDonePeerStatus)
+	logger.Debugf("receive %d utxos in chunk %d from peer %s", len(sc.Keys),
+		sc.Idx, pid.Pretty())
+	return nil
+}
+
 func (sm *SyncManager) onLightSyncRequest(msg p2p.Message) error {
 
 	locateHeaders := new(LocateHeaders)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/BOXFoundation/boxd/blocksync/pb"
+	"github.com/BOXFoundation/boxd/core/chain"
 	corepb "github.com/BOXFoundation/boxd/core/pb"
 	coreTypes "github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
@@ -33,6 +34,14 @@ var (
 	_ conv.Serializable = (*FetchBlockHeaders)(nil)
 	_ conv.Convertible  = (*SyncBlocks)(nil)
 	_ conv.Serializable = (*SyncBlocks)(nil)
+	_ conv.Convertible  = (*FetchSnapshotManifest)(nil)
+	_ conv.Serializable = (*FetchSnapshotManifest)(nil)
+	_ conv.Convertible  = (*SnapshotManifest)(nil)
+	_ conv.Serializable = (*SnapshotManifest)(nil)
+	_ conv.Convertible  = (*FetchSnapshotChunk)(nil)
+	_ conv.Serializable = (*FetchSnapshotChunk)(nil)
+	_ conv.Convertible  = (*SnapshotChunk)(nil)
+	_ conv.Serializable = (*SnapshotChunk)(nil)
 )
 
 // LocateHeaders includes hashes sent to a peer to locate fork point
@@ -80,6 +89,42 @@ type SyncBlocks struct {
 	Blocks []*coreTypes.Block
 }
 
+// FetchSnapshotManifest is sent to a peer to fetch the manifest of its utxo
+// snapshot as of an eternal block
+type FetchSnapshotManifest struct {
+	// hash of the snapshot block, zero hash for the latest eternal block
+	Hash *crypto.HashType
+	// tail height of local chain, only snapshots after it are wanted
+	Height uint32
+}
+
+// SnapshotManifest describes a utxo snapshot served by a peer, that is, its
+// root, blocks, consensus context and chunk hashes. Root is zero hash if the
+// peer serves no snapshot
+type SnapshotManifest struct {
+	Root        *crypto.HashType
+	Blocks      []*coreTypes.Block
+	Candidates  []byte
+	Period      []byte
+	ChunkHashes []*crypto.HashType
+	// finality certificate signatures of the snapshot block
+	Signatures [][]byte
+}
+
+// FetchSnapshotChunk is sent to a peer to fetch a chunk of the utxo snapshot
+// with root
+type FetchSnapshotChunk struct {
+	Root *crypto.HashType
+	Idx  uint32
+}
+
+// SnapshotChunk includes utxos in a chunk of the utxo snapshot with root
+type SnapshotChunk struct {
+	Root *crypto.HashType
+	Idx  uint32
+	chain.SnapshotChunk
+}
+
 func newLocateHeaders(hashes ...*crypto.HashType) *LocateHeaders {
 	if hashes == nil {
 		hashes = make([]*crypto.HashType, 0)
@@ -116,6 +161,44 @@ func newSyncBlocks(idx uint32, blocks ...*coreTypes.Block) *SyncBlocks {
 	return &SyncBlocks{Idx: idx, Blocks: blocks}
 }
 
+func newFetchSnapshotManifest(hash *crypto.HashType, height uint32) *FetchSnapshotManifest {
+	if hash == nil {
+		hash = &crypto.HashType{}
+	}
+	return &FetchSnapshotManifest{Hash: hash, Height: height}
+}
+
+func newSnapshotManifest(snapshot *chain.UtxoSnapshot, signatures [][]byte) *SnapshotManifest {
+	if snapshot == nil {
+		return &SnapshotManifest{Root: &crypto.HashType{}}
+	}
+	return &SnapshotManifest{
+		Root:        snapshot.Root(),
+		Blocks:      snapshot.Blocks,
+		Candidates:  snapshot.Candidates,
+		Period:      snapshot.Period,
+		ChunkHashes: snapshot.ChunkHashes,
+		Signatures:  signatures,
+	}
+}
+
+func newFetchSnapshotChunk(root *crypto.HashType, idx uint32) *FetchSnapshotChunk {
+	if root == nil {
+		root = &crypto.HashType{}
+	}
+	return &FetchSnapshotChunk{Root: root, Idx: idx}
+}
+
+func newSnapshotChunk(root *crypto.HashType, idx uint32, chunk *chain.SnapshotChunk) *SnapshotChunk {
+	if root == nil {
+		root = &crypto.HashType{}
+	}
+	if chunk == nil {
+		chunk = &chain.SnapshotChunk{}
+	}
+	return &SnapshotChunk{Root: root, Idx: idx, SnapshotChunk: *chunk}
+}
+
 // ToProtoMessage converts LocateHeaders to proto message.
 func (lh *LocateHeaders) ToProtoMessage() (proto.Message, error) {
 	if lh == nil {
@@ -392,6 +475,200 @@ func (sb *SyncBlocks) Unmarshal(data []byte) error {
 	return sb.FromProtoMessage(msg)
 }
 
+// ToProtoMessage converts FetchSnapshotManifest to proto message.
+func (fsm *FetchSnapshotManifest) ToProtoMessage() (proto.Message, error) {
+	if fsm == nil {
+		fsm = newFetchSnapshotManifest(nil, 0)
+	}
+	return &pb.FetchSnapshotManifest{
+		Hash:   fsm.Hash.GetBytes(),
+		Height: fsm.Height,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to FetchSnapshotManifest
+func (fsm *FetchSnapshotManifest) FromProtoMessage(message proto.Message) error {
+	if m, ok := message.(*pb.FetchSnapshotManifest); ok {
+		if m != nil {
+			fsm.Hash = new(crypto.HashType)
+			if err := fsm.Hash.SetBytes(m.Hash); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			fsm.Height = m.Height
+			return nil
+		}
+		return errEmptyProtoMessage
+	}
+	return errInvalidProtoMessage
+}
+
+// Marshal method marshal FetchSnapshotManifest object to binary
+func (fsm *FetchSnapshotManifest) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(fsm)
+}
+
+// Unmarshal method unmarshal binary data to FetchSnapshotManifest object
+func (fsm *FetchSnapshotManifest) Unmarshal(data []byte) error {
+	msg := &pb.FetchSnapshotManifest{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return fsm.FromProtoMessage(msg)
+}
+
+// ToProtoMessage converts SnapshotManifest to proto message.
+func (mf *SnapshotManifest) ToProtoMessage() (proto.Message, error) {
+	if mf == nil {
+		mf = newSnapshotManifest(nil, nil)
+	}
+	blocks, err := ConvBlocksToPbBlocks(mf.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SnapshotManifest{
+		Root:        mf.Root.GetBytes(),
+		Blocks:      blocks,
+		Candidates:  mf.Candidates,
+		Period:      mf.Period,
+		ChunkHashes: ConvHashesToBytesArray(mf.ChunkHashes),
+		Signatures:  mf.Signatures,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to SnapshotManifest
+func (mf *SnapshotManifest) FromProtoMessage(message proto.Message) error {
+	if m, ok := message.(*pb.SnapshotManifest); ok {
+		if m != nil {
+			var err error
+			mf.Root = new(crypto.HashType)
+			if err = mf.Root.SetBytes(m.Root); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			if mf.Blocks, err = ConvPbBlocksToBlocks(m.Blocks); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			if mf.ChunkHashes, err = ConvBytesArrayToHashes(m.ChunkHashes); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			mf.Candidates = m.Candidates
+			mf.Period = m.Period
+			mf.Signatures = m.Signatures
+			return nil
+		}
+		return errEmptyProtoMessage
+	}
+	return errInvalidProtoMessage
+}
+
+// Marshal method marshal SnapshotManifest object to binary
+func (mf *SnapshotManifest) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(mf)
+}
+
+// Unmarshal method unmarshal binary data to SnapshotManifest object
+func (mf *SnapshotManifest) Unmarshal(data []byte) error {
+	msg := &pb.SnapshotManifest{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return mf.FromProtoMessage(msg)
+}
+
+// ToProtoMessage converts FetchSnapshotChunk to proto message.
+func (fsc *FetchSnapshotChunk) ToProtoMessage() (proto.Message, error) {
+	if fsc == nil {
+		fsc = newFetchSnapshotChunk(nil, 0)
+	}
+	return &pb.FetchSnapshotChunk{
+		Root: fsc.Root.GetBytes(),
+		Idx:  fsc.Idx,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to FetchSnapshotChunk
+func (fsc *FetchSnapshotChunk) FromProtoMessage(message proto.Message) error {
+	if m, ok := message.(*pb.FetchSnapshotChunk); ok {
+		if m != nil {
+			fsc.Root = new(crypto.HashType)
+			if err := fsc.Root.SetBytes(m.Root); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			fsc.Idx = m.Idx
+			return nil
+		}
+		return errEmptyProtoMessage
+	}
+	return errInvalidProtoMessage
+}
+
+// Marshal method marshal FetchSnapshotChunk object to binary
+func (fsc *FetchSnapshotChunk) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(fsc)
+}
+
+// Unmarshal method unmarshal binary data to FetchSnapshotChunk object
+func (fsc *FetchSnapshotChunk) Unmarshal(data []byte) error {
+	msg := &pb.FetchSnapshotChunk{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return fsc.FromProtoMessage(msg)
+}
+
+// ToProtoMessage converts SnapshotChunk to proto message.
+func (sc *SnapshotChunk) ToProtoMessage() (proto.Message, error) {
+	if sc == nil {
+		sc = newSnapshotChunk(nil, 0, nil)
+	}
+	return &pb.SnapshotChunk{
+		Root:   sc.Root.GetBytes(),
+		Idx:    sc.Idx,
+		Keys:   sc.Keys,
+		Values: sc.Values,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to SnapshotChunk
+func (sc *SnapshotChunk) FromProtoMessage(message proto.Message) error {
+	if m, ok := message.(*pb.SnapshotChunk); ok {
+		if m != nil {
+			sc.Root = new(crypto.HashType)
+			if err := sc.Root.SetBytes(m.Root); err != nil {
+				logger.Error(err.Error())
+				return errInvalidProtoMessage
+			}
+			if len(m.Keys) != len(m.Values) {
+				return errInvalidProtoMessage
+			}
+			sc.Idx = m.Idx
+			sc.Keys = m.Keys
+			sc.Values = m.Values
+			return nil
+		}
+		return errEmptyProtoMessage
+	}
+	return errInvalidProtoMessage
+}
+
+// Marshal method marshal SnapshotChunk object to binary
+func (sc *SnapshotChunk) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(sc)
+}
+
+// Unmarshal method unmarshal binary data to SnapshotChunk object
+func (sc *SnapshotChunk) Unmarshal(data []byte) error {
+	msg := &pb.SnapshotChunk{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return sc.FromProtoMessage(msg)
+}
+
 // ConvHashesToBytesArray convert []*crypto.HashType to [][]byte
 func ConvHashesToBytesArray(hashes []*crypto.HashType) [][]byte {
 	bytesArray := make([][]byte, len(hashes))
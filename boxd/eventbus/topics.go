@@ -11,6 +11,14 @@ const (
 	TopicUpdateNetworkID = "rpc:updatenetworkid"
 	// TopicGetAddressBook is topic for listing p2p peer status
 	TopicGetAddressBook = "rpc:getaddressbook"
+	// TopicGenerateBlocks is topic for generating blocks on demand in dev mode
+	TopicGenerateBlocks = "rpc:generateblocks"
+	// TopicGetValidators is topic for listing performance of validators
+	TopicGetValidators = "rpc:getvalidators"
+	// TopicGetCandidates is topic for listing candidates
+	TopicGetCandidates = "rpc:getcandidates"
+	// TopicGetFinalityCertificate is topic for getting finality certificate of a block
+	TopicGetFinalityCertificate = "rpc:getfinalitycertificate"
 
 	//TopicP2PPeerAddr is a event topic for new peer addr found or peer addr updated
 	TopicP2PPeerAddr = "p2p:peeraddr"
@@ -19,13 +19,14 @@ import (
 	"github.com/BOXFoundation/boxd/consensus/dpos"
 	"github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/txpool"
+	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/log"
 	"github.com/BOXFoundation/boxd/metrics"
 	p2p "github.com/BOXFoundation/boxd/p2p"
 	grpcserver "github.com/BOXFoundation/boxd/rpc/server"
 	storage "github.com/BOXFoundation/boxd/storage"
+	_ "github.com/BOXFoundation/boxd/storage/leveldb" // init leveldb
 	_ "github.com/BOXFoundation/boxd/storage/memdb"   // init memdb
-	_ "github.com/BOXFoundation/boxd/storage/rocksdb" // init rocksdb
 	"github.com/jbenet/goprocess"
 )
 
@@ -102,6 +103,17 @@ func (server *Server) Prepare() {
 	}
 	server.database = database
 
+	// upgrade chain db to the schema version of the binary, refusing newer ones.
+	if err := server.migrate(); err != nil {
+		logger.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	// run on the network of the genesis spec the chain is initialized with, if any.
+	if err := server.applyGenesis(); err != nil {
+		logger.Fatalf("Failed to apply genesis: %v", err)
+	}
+	cfg.P2p.GenesisHash = chain.GenesisHash
+
 	// ########################################################
 	// prepare box peer.
 	peer, err := p2p.NewBoxPeer(database.Proc(), &cfg.P2p, database, server.bus)
@@ -127,7 +139,7 @@ func (server *Server) Prepare() {
 	server.blockChain = blockChain
 
 	// prepare txpool.
-	txPool := txpool.NewTransactionPool(blockChain.Proc(), peer, blockChain, server.bus)
+	txPool := txpool.NewTransactionPool(blockChain.Proc(), peer, blockChain, server.bus, &cfg.TxPool)
 	server.txPool = txPool
 
 	// prepare consensus.
@@ -143,7 +155,7 @@ func (server *Server) Prepare() {
 	}
 
 	// prepare sync manager.
-	syncManager := blocksync.NewSyncManager(blockChain, peer, consensus, blockChain.Proc())
+	syncManager := blocksync.NewSyncManager(blockChain, peer, consensus, blockChain.Proc(), &cfg.Sync)
 	server.syncManager = syncManager
 	server.blockChain.Setup(consensus, syncManager)
 
@@ -215,6 +227,51 @@ func (server *Server) Run() error {
 	return nil
 }
 
+// migrate upgrades chain db with migrations pending, see chain.Migrate.
+func (server *Server) migrate() error {
+	db, err := server.database.Table(chain.BlockTableName)
+	if err != nil {
+		return err
+	}
+	return chain.Migrate(db)
+}
+
+// applyGenesis applies the genesis spec written by `box init --genesis`, which
+// overrides the default genesis and consensus parameters.
+func (server *Server) applyGenesis() error {
+	db, err := server.database.Table(chain.BlockTableName)
+	if err != nil {
+		return err
+	}
+	genesis, err := chain.LoadGenesis(db)
+	if err != nil {
+		return err
+	}
+	// dev mode runs on a network of its own, which is initialized on first start
+	if server.cfg.Dpos.Dev {
+		if genesis, err = dpos.DevGenesis(&server.cfg.Dpos); err != nil {
+			return err
+		}
+		if _, err := chain.InitGenesis(db, genesis); err != nil {
+			return err
+		}
+	}
+	if genesis == nil {
+		return nil
+	}
+	if err := genesis.Apply(); err != nil {
+		return err
+	}
+	if err := dpos.SetGenesisParams(genesis); err != nil {
+		return err
+	}
+	if genesis.Magic != 0 {
+		server.cfg.P2p.Magic = genesis.Magic
+	}
+	logger.Infof("Running on network %s with genesis %s", genesis.Network, chain.GenesisHash)
+	return nil
+}
+
 // Proc returns the goprocess to run the server
 func (server *Server) Proc() goprocess.Process {
 	return server.proc
@@ -237,6 +294,35 @@ func (server *Server) initEventListener() {
 		out <- true
 	}, false)
 
+	// TopicGenerateBlocks
+	server.bus.Reply(eventbus.TopicGenerateBlocks, func(count uint32, out chan<- []*crypto.HashType, errOut chan<- error) {
+		hashes, err := server.consensus.GenerateBlocks(count)
+		errOut <- err
+		out <- hashes
+	}, true)
+
+	// TopicGetValidators
+	server.bus.Reply(eventbus.TopicGetValidators, func(out chan<- []*dpos.ValidatorStats, errOut chan<- error) {
+		validators, err := server.consensus.Validators()
+		errOut <- err
+		out <- validators
+	}, false)
+
+	// TopicGetCandidates
+	server.bus.Reply(eventbus.TopicGetCandidates, func(out chan<- []*dpos.Candidate, errOut chan<- error) {
+		candidates, err := server.consensus.Candidates()
+		errOut <- err
+		out <- candidates
+	}, false)
+
+	// TopicGetFinalityCertificate
+	server.bus.Reply(eventbus.TopicGetFinalityCertificate, func(hash *crypto.HashType, height uint32,
+		out chan<- *dpos.FinalityCertificate, errOut chan<- error) {
+		cert, err := server.consensus.FinalityCertificate(hash, height)
+		errOut <- err
+		out <- cert
+	}, false)
+
 	// TopicGetDatabaseKeys
 	server.bus.Reply(eventbus.TopicGetDatabaseKeys, func(parent context.Context, table string, prefix string, skip int32, limit int32, out chan<- []string) {
 		defer func() {
@@ -24,6 +24,29 @@ type ChainReader interface {
 	GetBlockHash(uint32) (*crypto.HashType, error)
 	LoadBlockByHash(crypto.HashType) (*types.Block, error)
 
+	// interface to read commitment to utxo set as of tail
+	UtxoRoot() (*types.Block, *crypto.HashType)
+	VerifyUtxoSet() error
+
 	// address related search method
 	GetTransactionsByAddr(types.Address) ([]*types.Transaction, error)
+
+	// interface to take a consistent snapshot of chain state
+	StateSnapshot() (ChainSnapshot, error)
+}
+
+// ChainSnapshot is a read-only view of chain state as of a tail block, which is
+// not affected by blocks connected or disconnected afterwards
+type ChainSnapshot interface {
+	// hash and height of the tail block
+	Tail() (*crypto.HashType, uint32)
+
+	// interface to reader utxos
+	ListAllUtxos() (map[types.OutPoint]*types.UtxoWrap, error)
+	LoadUtxoByAddress(types.Address) (map[types.OutPoint]*types.UtxoWrap, error)
+	// utxo of the outpoint, nil if it is not in the snapshot
+	LoadUtxo(types.OutPoint) (*types.UtxoWrap, error)
+
+	// release the snapshot, it must be called to release the snapshot
+	Release()
 }
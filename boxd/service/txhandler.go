@@ -11,4 +11,6 @@ type TxHandler interface {
 	ProcessTx(tx *types.Transaction, broadcast bool) error
 	// GetTransactionsInPool gets all transactions in memory pool
 	GetTransactionsInPool() []*types.Transaction
+	// GetFeePrice returns the minimum fee in box per byte for a tx to be accepted
+	GetFeePrice() uint64
 }
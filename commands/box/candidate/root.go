@@ -0,0 +1,171 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package candidatecmd
+
+import (
+	"fmt"
+	"path"
+
+	root "github.com/BOXFoundation/boxd/commands/box/root"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/rpc/client"
+	"github.com/BOXFoundation/boxd/util"
+	"github.com/BOXFoundation/boxd/wallet"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var walletDir string
+var defaultWalletDir = path.Join(util.HomeDir(), ".box_keystore")
+
+// rootCmd represents the candidate command
+var rootCmd = &cobra.Command{
+	Use:   "candidate",
+	Short: "Candidate subcommand",
+	Long: `Sign up as a candidate for block producers, withdraw from election,
+publish peer id, endpoint and description of a candidate, and list candidates.`,
+}
+
+// Init adds the sub command to the root command.
+func init() {
+	root.RootCmd.AddCommand(rootCmd)
+	rootCmd.PersistentFlags().StringVar(&walletDir, "wallet_dir", defaultWalletDir, "Specify directory to search keystore files")
+	rootCmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "list candidates ordered by votes",
+			Run:   listCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "register [addr] [peerID]",
+			Short: "sign up the address as a candidate",
+			Run:   registerCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "unregister [addr]",
+			Short: "withdraw the candidate from election",
+			Run:   unregisterCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "update [addr] [peerID] [endpoint] [description]",
+			Short: "publish peer id, endpoint and description of the candidate",
+			Run:   updateCmdFunc,
+		},
+	)
+}
+
+func listCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("list called")
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	candidates, err := client.GetCandidates(conn)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%-36s %20s %-52s %s\n", "address", "votes", "peer id", "endpoint")
+	for _, v := range candidates {
+		fmt.Printf("%-36s %20d %-52s %s\n", v.Addr, v.Votes, v.PeerId, v.Endpoint)
+		if v.Description != "" {
+			fmt.Printf("  %s\n", v.Description)
+		}
+	}
+}
+
+func registerCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("register called")
+	if len(args) != 2 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	addr, account, err := unlockAccount(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	tx, err := client.RegisterCandidate(conn, addr, args[1], account.PublicKey(), account)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(util.PrettyPrint(tx))
+	}
+}
+
+func unregisterCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("unregister called")
+	if len(args) != 1 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	addr, account, err := unlockAccount(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	tx, err := client.UnregisterCandidate(conn, addr, account.PublicKey(), account)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(util.PrettyPrint(tx))
+	}
+}
+
+func updateCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("update called")
+	if len(args) < 2 || len(args) > 4 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	var endpoint, description string
+	if len(args) > 2 {
+		endpoint = args[2]
+	}
+	if len(args) > 3 {
+		description = args[3]
+	}
+	addr, account, err := unlockAccount(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	tx, err := client.UpdateCandidate(conn, addr, args[1], endpoint, description,
+		account.PublicKey(), account)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(util.PrettyPrint(tx))
+	}
+}
+
+// unlockAccount unlocks the account of addr managed in wallet dir with passphrase
+// read from stdin.
+func unlockAccount(addr string) (types.Address, *wallet.Account, error) {
+	address, err := types.NewAddress(addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid address: %s", addr)
+	}
+	wltMgr, err := wallet.NewWalletManager(walletDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, exists := wltMgr.GetAccount(addr)
+	if !exists {
+		return nil, nil, fmt.Errorf("Account %s not managed", addr)
+	}
+	passphrase, err := wallet.ReadPassphraseStdin()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := account.UnlockWithPassphrase(passphrase); err != nil {
+		return nil, nil, fmt.Errorf("Fail to unlock account: %s", err)
+	}
+	return address, account, nil
+}
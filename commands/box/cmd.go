@@ -8,8 +8,12 @@ import (
 	"fmt"
 	"os"
 
-	_ "github.com/BOXFoundation/boxd/commands/box/ctl" // init ctl cmd
+	_ "github.com/BOXFoundation/boxd/commands/box/candidate"  // init candidate cmd
+	_ "github.com/BOXFoundation/boxd/commands/box/ctl"        // init ctl cmd
+	_ "github.com/BOXFoundation/boxd/commands/box/db"         // init db cmd
+	_ "github.com/BOXFoundation/boxd/commands/box/initialize" // init init cmd
 	root "github.com/BOXFoundation/boxd/commands/box/root"
+	_ "github.com/BOXFoundation/boxd/commands/box/signer"      // init signer cmd
 	_ "github.com/BOXFoundation/boxd/commands/box/start"       // init start cmd
 	_ "github.com/BOXFoundation/boxd/commands/box/token"       // init token cmd
 	_ "github.com/BOXFoundation/boxd/commands/box/transaction" // init transaction cmd
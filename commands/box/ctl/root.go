@@ -6,9 +6,11 @@ package ctl
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strconv"
+	"strings"
 
 	"github.com/BOXFoundation/boxd/commands/box/root"
 	"github.com/BOXFoundation/boxd/core/types"
@@ -44,11 +46,12 @@ func init() {
 			},
 		},
 		&cobra.Command{
-			Use:   "createrawtx",
-			Short: "Create a raw transaction",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("createrawtx called")
-			},
+			Use:   "createrawtx [txhash:index,...] [toaddress] [amount] [toaddress] [amount]...",
+			Short: "Create an unsigned raw transaction from explicit inputs and outputs",
+			Long: `Create an unsigned raw transaction spending the given outpoints.
+No change output is added, so list it explicitly. The hex encoded result
+can be signed offline with "box tx signrawtx" and broadcast with "sendrawtx".`,
+			Run: createRawTxCmdFunc,
 		},
 		&cobra.Command{
 			Use:   "debuglevel [debug|info|warning|error|fatal]",
@@ -61,23 +64,20 @@ func init() {
 			Run:   debugLevelCmdFunc,
 		},
 		&cobra.Command{
-			Use:   "decoderawtx",
-			Short: "A brief description of your command",
-			Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("decoderawtx called")
-			},
+			Use:   "decoderawtx [rawtx]",
+			Short: "Decode a hex encoded raw transaction into json",
+			Run:   decodeRawTxCmdFunc,
 		},
 		&cobra.Command{
 			Use:   "getbalance [address]",
 			Short: "Get the balance for any given address",
 			Run:   getBalanceCmdFunc,
 		},
+		&cobra.Command{
+			Use:   "generate [count]",
+			Short: "Generate blocks right away on a node in dev mode",
+			Run:   generateCmdFunc,
+		},
 		&cobra.Command{
 			Use:   "getblock [hash]",
 			Short: "Get the block with a specific hash",
@@ -122,6 +122,19 @@ to quickly create a Cobra application.`,
 			Short: "Get transactions in pool",
 			Run:   getTxPoolCmdFunc,
 		},
+		&cobra.Command{
+			Use:   "getutxoroot",
+			Short: "Get the commitment to utxo set as of the tail block",
+			Run:   getUtxoRootCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "getvalidators",
+			Short: "Get produced and missed slots of validators",
+			Long: `Get performance of producers of the current period, marked by *, and other
+validators ever seen: blocks produced and slots missed on main chain, blocks
+reverted in reorgs and blocks confirmed eternal.`,
+			Run: getValidatorsCmdFunc,
+		},
 		&cobra.Command{
 			Use:   "searchrawtxs [address]",
 			Short: "Search transactions for a given address",
@@ -131,10 +144,8 @@ to quickly create a Cobra application.`,
 		},
 		&cobra.Command{
 			Use:   "sendrawtx [rawtx]",
-			Short: "Send a raw transaction to the network",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("sendrawtx called")
-			},
+			Short: "Send a signed raw transaction to the network",
+			Run:   sendRawTxCmdFunc,
 		},
 		&cobra.Command{
 			Use:   "signmessage [message] [optional publickey]",
@@ -149,9 +160,8 @@ to quickly create a Cobra application.`,
 		&cobra.Command{
 			Use:   "verifychain",
 			Short: "Verify the local chain",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("verifychain called")
-			},
+			Long:  "Verify the utxo set of the local chain against its commitment",
+			Run:   verifyChainCmdFunc,
 		},
 		&cobra.Command{
 			Use:   "verifymessage [message] [publickey]",
@@ -188,6 +198,104 @@ func updateNetworkID(cmd *cobra.Command, args []string) {
 	client.UpdateNetworkID(conn, id)
 }
 
+func createRawTxCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	outPoints, err := parseOutPoints(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	addrs := make([]types.Address, 0)
+	amounts := make([]uint64, 0)
+	for i := 1; i < len(args); i += 2 {
+		addr, err := types.NewAddress(args[i])
+		if err != nil {
+			fmt.Println("Invalid address: ", args[i])
+			return
+		}
+		amount, err := strconv.ParseUint(args[i+1], 10, 64)
+		if err != nil {
+			fmt.Println("Invalid amount: ", args[i+1])
+			return
+		}
+		addrs = append(addrs, addr)
+		amounts = append(amounts, amount)
+	}
+	tx, err := client.CreateRawTransaction(outPoints, addrs, amounts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	rawTx, err := client.EncodeRawTransaction(tx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(rawTx)
+}
+
+// parseOutPoints parses comma separated outpoints in txhash:index format
+func parseOutPoints(arg string) ([]*types.OutPoint, error) {
+	outPoints := make([]*types.OutPoint, 0)
+	for _, s := range strings.Split(arg, ",") {
+		parts := strings.Split(s, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid outpoint %s, expect txhash:index", s)
+		}
+		hash := crypto.HashType{}
+		if err := hash.SetString(parts[0]); err != nil {
+			return nil, fmt.Errorf("invalid tx hash %s: %v", parts[0], err)
+		}
+		index, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output index %s: %v", parts[1], err)
+		}
+		outPoints = append(outPoints, &types.OutPoint{Hash: hash, Index: uint32(index)})
+	}
+	return outPoints, nil
+}
+
+func decodeRawTxCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Param rawtx required")
+		return
+	}
+	txInfo, err := client.DecodeRawTransaction(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	data, err := json.MarshalIndent(txInfo, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func sendRawTxCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Param rawtx required")
+		return
+	}
+	tx, err := client.ParseRawTransaction(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	if err := client.SendRawTransaction(conn, tx); err != nil {
+		fmt.Println(err)
+		return
+	}
+	hash, _ := tx.TxHash()
+	fmt.Println("Tx Hash:", hash.String())
+}
+
 func getBalanceCmdFunc(cmd *cobra.Command, args []string) {
 	addrs := make([]string, 0)
 	if len(args) < 1 {
@@ -247,6 +355,27 @@ func getBlockCountCmdFunc(cmd *cobra.Command, args []string) {
 	fmt.Println("Current Height: ", height)
 }
 
+func generateCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("generate called")
+	count := uint64(1)
+	if len(args) > 0 {
+		var err error
+		if count, err = strconv.ParseUint(args[0], 10, 32); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	hashes, err := client.GenerateBlocks(conn, uint32(count))
+	for _, hash := range hashes {
+		fmt.Println(hash)
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
 func getBlockHashCmdFunc(cmd *cobra.Command, args []string) {
 	fmt.Println("getblockhash called")
 	if len(args) == 0 {
@@ -286,6 +415,52 @@ func getBlockHeaderCmdFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
+func getUtxoRootCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("getutxoroot called")
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	resp, err := client.GetUtxoRoot(conn, false)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Utxo root as of block %s height %d is %s\n", resp.BlockHash,
+		resp.Height, resp.UtxoRoot)
+}
+
+func getValidatorsCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("getvalidators called")
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	validators, err := client.GetValidators(conn)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("  %-36s %10s %10s %10s %10s\n", "address", "produced", "missed", "reverted", "confirmed")
+	for _, v := range validators {
+		mark := " "
+		if v.Active {
+			mark = "*"
+		}
+		fmt.Printf("%s %-36s %10d %10d %10d %10d\n", mark, v.Addr, v.Produced, v.Missed,
+			v.Reverted, v.Confirmed)
+	}
+}
+
+func verifyChainCmdFunc(cmd *cobra.Command, args []string) {
+	fmt.Println("verifychain called")
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	resp, err := client.GetUtxoRoot(conn, true)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Utxo set as of block %s height %d is verified, utxo root: %s\n",
+		resp.BlockHash, resp.Height, resp.UtxoRoot)
+}
+
 func getRawTxCmdFunc(cmd *cobra.Command, args []string) {
 	fmt.Println("getrawtx called")
 	if len(args) < 1 {
@@ -359,8 +534,7 @@ func validateMessageCmdFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	fmt.Println("validateing address", args[0])
-	addr := &types.AddressPubKeyHash{}
-	if err := addr.SetString(args[0]); err != nil {
+	if _, err := types.NewAddress(args[0]); err != nil {
 		fmt.Println(err)
 	} else {
 		fmt.Println(args[0], " is a valid address")
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"fmt"
+
+	root "github.com/BOXFoundation/boxd/commands/box/root"
+	"github.com/BOXFoundation/boxd/config"
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/storage"
+	_ "github.com/BOXFoundation/boxd/storage/leveldb" // init leveldb
+	_ "github.com/BOXFoundation/boxd/storage/memdb"   // init memdb
+	"github.com/jbenet/goprocess"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// rootCmd represents the db command, to maintain chain database offline.
+var rootCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintain chain database of the node, which must not be running.",
+}
+
+// migrateCmd represents the db migrate command, to upgrade chain database schema.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate chain database to the schema version of the binary.",
+	Long: `Migrate chain database to the schema version of the binary by running pending
+migrations in order, which the node also does on start. An interrupted migration
+resumes from where it stopped. A database of a newer schema version than the
+binary supports is refused.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		cfg := &config.Config{}
+		if err := viper.Unmarshal(cfg); err != nil {
+			return err
+		}
+		cfg.Prepare()
+		database, err := storage.NewDatabase(goprocess.Background(), &cfg.Database)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+		db, err := database.Table(chain.BlockTableName)
+		if err != nil {
+			return err
+		}
+
+		version, pending, err := chain.PendingMigrations(db)
+		if err != nil {
+			return err
+		}
+		current := chain.CurrentSchemaVersion()
+		if version == 0 {
+			fmt.Printf("Chain database %s is empty, and will be created with schema version %d\n", cfg.Database.Path, current)
+			return nil
+		}
+		fmt.Printf("Chain database %s is of schema version %d, and the binary of %d\n", cfg.Database.Path, version, current)
+		for _, migration := range pending {
+			fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+		}
+		if len(pending) == 0 {
+			fmt.Println("No migration to run")
+			return nil
+		}
+		if dryRun {
+			fmt.Printf("%d migrations to run\n", len(pending))
+			return nil
+		}
+		if err := chain.Migrate(db); err != nil {
+			return err
+		}
+		fmt.Printf("Chain database migrated to schema version %d\n", current)
+		return nil
+	},
+}
+
+func init() {
+	root.RootCmd.AddCommand(rootCmd)
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().Bool("dry-run", false, "list pending migrations without running them")
+}
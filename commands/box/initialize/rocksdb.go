@@ -0,0 +1,12 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build cgo
+// +build cgo
+
+package initialize
+
+// rocksdb needs cgo, so builds with CGO_ENABLED=0 are left with the pure go
+// backends.
+import _ "github.com/BOXFoundation/boxd/storage/rocksdb" // init rocksdb
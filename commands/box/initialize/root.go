@@ -0,0 +1,67 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package initialize
+
+import (
+	"fmt"
+
+	root "github.com/BOXFoundation/boxd/commands/box/root"
+	"github.com/BOXFoundation/boxd/config"
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/storage"
+	_ "github.com/BOXFoundation/boxd/storage/leveldb" // init leveldb
+	"github.com/jbenet/goprocess"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// initCmd represents the init command, to initialize chain db of a network.
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize chain database with a genesis spec.",
+	Long: `Initialize chain database of the network with a genesis spec in json or yaml,
+which defines initial allocations, validators with peer ids, block timing and
+network magic. The node started afterwards runs on the network of the spec.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genesisFile, _ := cmd.Flags().GetString("genesis")
+		if genesisFile == "" {
+			return fmt.Errorf("genesis file is required")
+		}
+		genesis, err := chain.ReadGenesisFile(genesisFile)
+		if err != nil {
+			return err
+		}
+
+		cfg := &config.Config{}
+		if err := viper.Unmarshal(cfg); err != nil {
+			return err
+		}
+		cfg.Prepare()
+		database, err := storage.NewDatabase(goprocess.Background(), &cfg.Database)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+		db, err := database.Table(chain.BlockTableName)
+		if err != nil {
+			return err
+		}
+		if err := chain.Migrate(db); err != nil {
+			return err
+		}
+		block, err := chain.InitGenesis(db, genesis)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Chain database %s initialized with genesis %s\n", cfg.Database.Path, block.BlockHash())
+		return nil
+	},
+}
+
+func init() {
+	root.RootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().String("genesis", "", "genesis spec file in json or yaml")
+}
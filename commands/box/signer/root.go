@@ -0,0 +1,66 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	root "github.com/BOXFoundation/boxd/commands/box/root"
+	"github.com/BOXFoundation/boxd/consensus/signer"
+	"github.com/BOXFoundation/boxd/wallet"
+	"github.com/spf13/cobra"
+)
+
+// signerCmd represents the signer command, to run the reference remote signer.
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run a remote signer holding the block producer key.",
+	Long: `Run a remote signer holding the block producer key outside the node, which
+serves signing requests of the node over a unix socket set as dpos.signer in
+the node config. It refuses to sign two different blocks at the same height,
+with the last height signed persisted in the state file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyfile, _ := cmd.Flags().GetString("keyfile")
+		socket, _ := cmd.Flags().GetString("socket")
+		state, _ := cmd.Flags().GetString("state")
+		if keyfile == "" || socket == "" || state == "" {
+			return fmt.Errorf("keyfile, socket and state are required")
+		}
+		account, err := wallet.NewAccountFromFile(keyfile)
+		if err != nil {
+			return err
+		}
+		passphrase, err := wallet.ReadPassphraseStdin()
+		if err != nil {
+			return err
+		}
+		if err := account.UnlockWithPassphrase(passphrase); err != nil {
+			return err
+		}
+		server, err := signer.NewServer(account, state)
+		if err != nil {
+			return err
+		}
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			server.Stop()
+		}()
+		return server.Serve(socket)
+	},
+}
+
+func init() {
+	root.RootCmd.AddCommand(signerCmd)
+
+	signerCmd.Flags().String("keyfile", "", "keystore file of the block producer")
+	signerCmd.Flags().String("socket", "", "unix socket to listen on")
+	signerCmd.Flags().String("state", "", "file to persist the last height signed")
+}
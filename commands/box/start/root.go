@@ -54,5 +54,11 @@ func init() {
 	startCmd.Flags().String("database", "rocksdb", "database name [rocksdb|mem]")
 	viper.BindPFlag("database.name", startCmd.Flags().Lookup("database"))
 
+	startCmd.Flags().Bool("dev", false, "dev mode, where the node produces blocks alone on a network of its own.")
+	viper.BindPFlag("dpos.dev", startCmd.Flags().Lookup("dev"))
+
+	startCmd.Flags().Int("dev-interval", 0, "seconds between blocks in dev mode, 0 to generate blocks on demand only.")
+	viper.BindPFlag("dpos.dev_interval", startCmd.Flags().Lookup("dev-interval"))
+
 	viper.SetDefault("p2p.key_path", "peer.key")
 }
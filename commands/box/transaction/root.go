@@ -5,13 +5,16 @@
 package transactioncmd
 
 import (
+	"encoding/hex"
 	"fmt"
 	"path"
 	"strconv"
 
 	root "github.com/BOXFoundation/boxd/commands/box/root"
 	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/rpc/client"
+	"github.com/BOXFoundation/boxd/script"
 	"github.com/BOXFoundation/boxd/util"
 	"github.com/BOXFoundation/boxd/wallet"
 	"github.com/spf13/cobra"
@@ -21,6 +24,7 @@ import (
 var cfgFile string
 var walletDir string
 var defaultWalletDir = path.Join(util.HomeDir(), ".box_keystore")
+var replaceable bool
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -41,22 +45,26 @@ to quickly create a Cobra application.`,
 func init() {
 	root.RootCmd.AddCommand(rootCmd)
 	rootCmd.PersistentFlags().StringVar(&walletDir, "wallet_dir", defaultWalletDir, "Specify directory to search keystore files")
+	sendFromCmd := &cobra.Command{
+		Use:   "sendfrom [fromaccount] [toaddress] [amount]",
+		Short: "Send coins from an account to an address",
+		Run:   sendFromCmdFunc,
+	}
+	sendFromCmd.Flags().BoolVar(&replaceable, "replaceable", false, "Signal replace-by-fee so that the fee can be bumped")
+	sendManyCmd := &cobra.Command{
+		Use:   "sendmany [fromaccount] [toaddresslist]",
+		Short: "Send coins to multiple addresses",
+		Run:   sendManyCmdFunc,
+	}
+	sendManyCmd.Flags().BoolVar(&replaceable, "replaceable", false, "Signal replace-by-fee so that the fee can be bumped")
 	rootCmd.AddCommand(
 		&cobra.Command{
 			Use:   "listutxos",
 			Short: "list all utxos",
 			Run:   listAllUtxoCmdFunc,
 		},
-		&cobra.Command{
-			Use:   "sendfrom [fromaccount] [toaddress] [amount]",
-			Short: "Send coins from an account to an address",
-			Run:   sendFromCmdFunc,
-		},
-		&cobra.Command{
-			Use:   "sendmany [fromaccount] [toaddresslist]",
-			Short: "Send coins to multiple addresses",
-			Run:   sendManyCmdFunc,
-		},
+		sendFromCmd,
+		sendManyCmd,
 		&cobra.Command{
 			Use:   "sendtoaddress [address]",
 			Short: "Send coins to an address",
@@ -65,11 +73,29 @@ func init() {
 			},
 		},
 		&cobra.Command{
-			Use:   "signrawtx [rawtx]",
-			Short: "Sign a transaction with privatekey and send it to the network",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("signrawtx called")
-			},
+			Use:   "signrawtx [fromaccount] [rawtx]",
+			Short: "Sign a raw transaction with the private key of an account",
+			Long: `Sign all inputs of a raw transaction with the private key of a local account.
+Every input must spend a pay-to-pubkey-hash output of the account. Signing needs
+no connection to boxd, so it can run on a cold wallet.`,
+			Run: signRawTxCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "signmultisigtx [fromaccount] [redeemscript] [rawtx]",
+			Short: "Add the signature of an account to a raw transaction spending a multisig address",
+			Long: `Add the signature of a local account to every input of a raw transaction that
+spends a multisig pay-to-script-hash address. Pass the printed raw transaction to
+the next keyholder until enough signatures are collected, then send it with
+"box ctl sendrawtx".`,
+			Run: signMultisigTxCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "bumpfee [txhash]",
+			Short: "Replace a pending transaction with one paying more fee",
+			Long: `Replace a transaction pending in tx pool with a copy paying more fee, which is
+taken from its change output. The transaction must be sent with --replaceable, and
+the account spending its inputs must be managed locally.`,
+			Run: bumpFeeCmdFunc,
 		},
 	)
 }
@@ -121,7 +147,11 @@ func sendFromCmdFunc(cmd *cobra.Command, args []string) {
 	}
 	conn := client.NewConnectionWithViper(viper.GetViper())
 	defer conn.Close()
-	tx, err := client.CreateTransaction(conn, fromAddr, target, account.PublicKey(), account)
+	createTx := client.CreateTransaction
+	if replaceable {
+		createTx = client.CreateReplaceableTransaction
+	}
+	tx, err := createTx(conn, fromAddr, target, account.PublicKey(), account)
 	if err != nil {
 		fmt.Println(err)
 	} else {
@@ -131,6 +161,100 @@ func sendFromCmdFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
+func signRawTxCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	tx, err := client.ParseRawTransaction(args[1])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	wltMgr, err := wallet.NewWalletManager(walletDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	account, exists := wltMgr.GetAccount(args[0])
+	if !exists {
+		fmt.Printf("Account %s not managed\n", args[0])
+		return
+	}
+	passphrase, err := wallet.ReadPassphraseStdin()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := account.UnlockWithPassphrase(passphrase); err != nil {
+		fmt.Println("Fail to unlock account", err)
+		return
+	}
+	signedTx, err := client.SignRawTransaction(tx, account.PublicKey(), account)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	rawTx, err := client.EncodeRawTransaction(signedTx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(rawTx)
+}
+
+func signMultisigTxCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 3 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	redeemScript, err := hex.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("Invalid redeem script", err)
+		return
+	}
+	tx, err := client.ParseRawTransaction(args[2])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	wltMgr, err := wallet.NewWalletManager(walletDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	account, exists := wltMgr.GetAccount(args[0])
+	if !exists {
+		fmt.Printf("Account %s not managed\n", args[0])
+		return
+	}
+	passphrase, err := wallet.ReadPassphraseStdin()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := account.UnlockWithPassphrase(passphrase); err != nil {
+		fmt.Println("Fail to unlock account", err)
+		return
+	}
+	signedTx, complete, err := client.SignMultisigTransaction(tx, redeemScript, account)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	rawTx, err := client.EncodeRawTransaction(signedTx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(rawTx)
+	if complete {
+		fmt.Println("Transaction is completely signed")
+	} else {
+		fmt.Println("Transaction needs more signatures")
+	}
+}
+
 func parseSendTarget(args []string) (map[types.Address]uint64, error) {
 	targets := make(map[types.Address]uint64)
 	for i := 0; i < len(args)/2; i++ {
@@ -183,7 +307,11 @@ func sendManyCmdFunc(cmd *cobra.Command, args []string) {
 	}
 	conn := client.NewConnectionWithViper(viper.GetViper())
 	defer conn.Close()
-	tx, err := client.CreateTransaction(conn, fromAddr, target, account.PublicKey(), account)
+	createTx := client.CreateTransaction
+	if replaceable {
+		createTx = client.CreateReplaceableTransaction
+	}
+	tx, err := createTx(conn, fromAddr, target, account.PublicKey(), account)
 	if err != nil {
 		fmt.Println(err)
 	} else {
@@ -192,3 +320,76 @@ func sendManyCmdFunc(cmd *cobra.Command, args []string) {
 		fmt.Println(util.PrettyPrint(tx))
 	}
 }
+
+func bumpFeeCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Param txhash required")
+		return
+	}
+	hash := crypto.HashType{}
+	if err := hash.SetString(args[0]); err != nil {
+		fmt.Println("Invalid tx hash", err)
+		return
+	}
+	conn := client.NewConnectionWithViper(viper.GetViper())
+	defer conn.Close()
+	txs, err := client.GetTransactionsInPool(conn)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var tx *types.Transaction
+	for _, poolTx := range txs {
+		if txHash, _ := poolTx.TxHash(); *txHash == hash {
+			tx = poolTx
+			break
+		}
+	}
+	if tx == nil {
+		fmt.Printf("Tx %s not found in tx pool\n", args[0])
+		return
+	}
+	// the inputs are spent by the account whose public key is in the signature scripts
+	pubKeyBytes, err := script.NewScriptFromBytes(tx.Vin[0].ScriptSig).GetSigScriptPubKey()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	pubKey, err := crypto.PublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fromAddr, err := types.NewAddressFromPubKey(pubKey)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	wltMgr, err := wallet.NewWalletManager(walletDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	account, exists := wltMgr.GetAccount(fromAddr.String())
+	if !exists {
+		fmt.Printf("Account %s not managed\n", fromAddr)
+		return
+	}
+	passphrase, err := wallet.ReadPassphraseStdin()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := account.UnlockWithPassphrase(passphrase); err != nil {
+		fmt.Println("Fail to unlock account", err)
+		return
+	}
+	newTx, err := client.BumpFee(conn, tx, fromAddr, account.PublicKey(), account)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	newHash, _ := newTx.TxHash()
+	fmt.Println("Tx Hash:", newHash.String())
+	fmt.Println(util.PrettyPrint(newTx))
+}
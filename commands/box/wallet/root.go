@@ -53,6 +53,19 @@ func init() {
 			Short: "Dump private key for an address",
 			Run:   dumpPrivKeyCmdFunc,
 		},
+		&cobra.Command{
+			Use:   "getpubkey [address]",
+			Short: "Get the public key of a local account",
+			Run:   getPubKeyCmdFunc,
+		},
+		&cobra.Command{
+			Use:   "createmultisig [m] [pubkey]...",
+			Short: "Create an m-of-n multisig pay-to-script-hash address",
+			Long: `Create an m-of-n multisig address from n hex encoded public keys.
+Coins sent to the address can only be spent with signatures of m keyholders,
+collected one by one with "box tx signmultisigtx" on the redeem script printed here.`,
+			Run: createMultisigCmdFunc,
+		},
 		&cobra.Command{
 			Use:   "dumpwallet [filename]",
 			Short: "Dump wallet to a file",
@@ -209,6 +222,64 @@ func dumpPrivKeyCmdFunc(cmd *cobra.Command, args []string) {
 	fmt.Printf("Address: %s\nPrivate Key: %s", addr, privateKey)
 }
 
+func getPubKeyCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println("address needed")
+		return
+	}
+	wltMgr, err := wallet.NewWalletManager(walletDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	account, exists := wltMgr.GetAccount(args[0])
+	if !exists {
+		fmt.Printf("Account %s not managed\n", args[0])
+		return
+	}
+	passphrase, err := wallet.ReadPassphraseStdin()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := account.UnlockWithPassphrase(passphrase); err != nil {
+		fmt.Println("Fail to unlock account", err)
+		return
+	}
+	fmt.Printf("Address: %s\nPublic Key: %s\n", args[0], hex.EncodeToString(account.PublicKey()))
+}
+
+func createMultisigCmdFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		fmt.Println("Invalid argument number")
+		return
+	}
+	m, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Println("Invalid param m", err)
+		return
+	}
+	pubKeys := make([][]byte, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		pubKey, err := hex.DecodeString(arg)
+		if err != nil {
+			fmt.Println("Invalid public key", arg, err)
+			return
+		}
+		if _, err := crypto.PublicKeyFromBytes(pubKey); err != nil {
+			fmt.Println("Invalid public key", arg, err)
+			return
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	addr, redeemScript, err := client.CreateMultisigAddress(m, pubKeys)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Address: %s\nRedeem Script: %s\n", addr, hex.EncodeToString(redeemScript))
+}
+
 func listTransactionsCmdFunc(cmd *cobra.Command, args []string) {
 	var addr string
 	var offset, limit uint32
@@ -10,7 +10,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/BOXFoundation/boxd/blocksync"
 	"github.com/BOXFoundation/boxd/consensus/dpos"
+	"github.com/BOXFoundation/boxd/core/txpool"
 	logtypes "github.com/BOXFoundation/boxd/log/types"
 	"github.com/BOXFoundation/boxd/metrics"
 	"github.com/BOXFoundation/boxd/p2p"
@@ -35,14 +37,16 @@ var GitBranch string
 // Config is a configuration data structure for box blockchain server,
 // which is read from config file or parsed from command line.
 type Config struct {
-	Workspace string          `mapstructure:"workspace"`
-	Network   string          `mapstructure:"network"`
-	Log       logtypes.Config `mapstructure:"log"`
-	P2p       p2p.Config      `mapstructure:"p2p"`
-	RPC       rpc.Config      `mapstructure:"rpc"`
-	Database  storage.Config  `mapstructure:"database"`
-	Dpos      dpos.Config     `mapstructure:"dpos"`
-	Metrics   metrics.Config  `mapstructure:"metrics"`
+	Workspace string           `mapstructure:"workspace"`
+	Network   string           `mapstructure:"network"`
+	Log       logtypes.Config  `mapstructure:"log"`
+	P2p       p2p.Config       `mapstructure:"p2p"`
+	RPC       rpc.Config       `mapstructure:"rpc"`
+	Database  storage.Config   `mapstructure:"database"`
+	Dpos      dpos.Config      `mapstructure:"dpos"`
+	TxPool    txpool.Config    `mapstructure:"txpool"`
+	Sync      blocksync.Config `mapstructure:"sync"`
+	Metrics   metrics.Config   `mapstructure:"metrics"`
 }
 
 var format = `workspace: %s
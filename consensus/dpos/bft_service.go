@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/BOXFoundation/boxd/consensus/signer"
 	chain "github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
@@ -26,15 +27,18 @@ type EternalBlockMsgKeyType [EternalBlockMsgKeySize]byte
 
 // Define const.
 const (
-	EternalBlockMsgChBufferSize        = 65536
-	MaxEternalBlockMsgCacheTime        = 10 * 60
-	MinConfirmMsgNumberForEternalBlock = 2 * PeriodSize / 3
-	EternalBlockMsgKeySize             = crypto.HashSize + 8
+	EternalBlockMsgChBufferSize = 65536
+	MaxEternalBlockMsgCacheTime = 10 * 60
+	EternalBlockMsgKeySize      = crypto.HashSize + 8
 
 	free status = iota
 	underway
 )
 
+// MinConfirmMsgNumberForEternalBlock is the number of confirmations by producers
+// needed for a block to be eternal, which changes with PeriodSize.
+var MinConfirmMsgNumberForEternalBlock = 2 * PeriodSize / 3
+
 // BftService use for quick identification of eternal block.
 type BftService struct {
 	eternalBlockMsgCh       chan p2p.Message
@@ -115,13 +119,13 @@ func (bft *BftService) maybeUpdateEternalBlock() {
 	}()
 	bft.checkStatus = underway
 	bft.tryToUpdateEternal()
-	if bft.chain.TailBlock().Height-bft.chain.EternalBlock().Height > MinConfirmMsgNumberForEternalBlock {
+	if bft.chain.TailBlock().Height-bft.chain.EternalBlock().Height > uint32(MinConfirmMsgNumberForEternalBlock) {
 		block, err := bft.chain.LoadBlockByHeight(bft.chain.EternalBlock().Height + 1)
 		if err != nil {
 			logger.Errorf("Failed to update eternal block. LoadBlockByHeight occurs error: %s", err.Error())
 			return
 		}
-		if err := bft.chain.SetEternal(block); err != nil {
+		if err := bft.setEternal(block); err != nil {
 			logger.Errorf("Failed to setEternal block. Height: %d, Hash: %v, err: %s", block.Height, block.Hash, err.Error())
 			return
 		}
@@ -140,15 +144,17 @@ func (bft *BftService) tryToUpdateEternal() {
 		if len(value) <= MinConfirmMsgNumberForEternalBlock {
 			return true
 		}
-		if bft.updateEternal(value[0]) {
+		if bft.updateEternal(value) {
 			bft.cache.Delete(k)
 		}
 		return true
 	})
 }
 
-func (bft *BftService) updateEternal(msg *EternalBlockMsg) bool {
-	block, err := bft.chain.LoadBlockByHash(msg.hash)
+// updateEternal sets the block confirmed by msgs eternal, and keeps signatures
+// of msgs as its finality certificate.
+func (bft *BftService) updateEternal(msgs []*EternalBlockMsg) bool {
+	block, err := bft.chain.LoadBlockByHash(msgs[0].hash)
 	if err != nil {
 		return false
 	}
@@ -156,13 +162,29 @@ func (bft *BftService) updateEternal(msg *EternalBlockMsg) bool {
 	if block.Height <= bft.chain.EternalBlock().Height {
 		return true
 	}
-	if err := bft.chain.SetEternal(block); err != nil {
+	if err := bft.setEternal(block); err != nil {
 		return false
 	}
+	if err := bft.consensus.storeFinalityCertificate(block.BlockHash(), msgs); err != nil {
+		logger.Warnf("Failed to store finality certificate of block %s. Err: %s", block.BlockHash(), err.Error())
+	}
 	logger.Infof("Eternal block has changed! Hash: %s Height: %d", block.BlockHash(), block.Height)
 	return true
 }
 
+// setEternal sets eternal block of the chain, and records blocks that become
+// eternal for their producers.
+func (bft *BftService) setEternal(block *types.Block) error {
+	lastEternalHeight := bft.chain.EternalBlock().Height
+	if err := bft.chain.SetEternal(block); err != nil {
+		return err
+	}
+	if err := bft.consensus.recordEternal(lastEternalHeight, block); err != nil {
+		logger.Warnf("Failed to record eternal block %s for validators. Err: %s", block.BlockHash(), err.Error())
+	}
+	return nil
+}
+
 func (bft *BftService) generateKey(hash crypto.HashType, timestamp int64) *EternalBlockMsgKeyType {
 	buf := make([]byte, EternalBlockMsgKeySize)
 	copy(buf, hash[:])
@@ -173,14 +195,12 @@ func (bft *BftService) generateKey(hash crypto.HashType, timestamp int64) *Etern
 	return result
 }
 
+// handleEternalBlockMsg collects a confirmation by a producer of the period the
+// block commits to, as its finality certificate is verified against, rather than
+// the active period, which may have changed since.
 func (bft *BftService) handleEternalBlockMsg(msg p2p.Message) error {
 
-	// quick check
 	peerID := msg.From().Pretty()
-	if !util.InArray(peerID, bft.consensus.context.periodContext.periodPeers) {
-		return ErrNotMintPeer
-	}
-
 	eternalBlockMsg := new(EternalBlockMsg)
 	if err := eternalBlockMsg.Unmarshal(msg.Body()); err != nil {
 		return err
@@ -197,14 +217,27 @@ func (bft *BftService) handleEternalBlockMsg(msg p2p.Message) error {
 		return ErrIllegalMsg
 	}
 
-	if pubkey, ok := crypto.RecoverCompact(eternalBlockMsg.hash[:], eternalBlockMsg.signature); ok {
+	block, err := bft.chain.LoadBlockByHash(eternalBlockMsg.hash)
+	if err != nil {
+		return err
+	}
+	periodContext, err := bft.consensus.loadPeriodContext(&block.Header.PeriodHash)
+	if err != nil {
+		return err
+	}
+	if !util.InArray(peerID, periodContext.periodPeers) {
+		return ErrNotMintPeer
+	}
+
+	confirmation := signer.ConfirmationHash(eternalBlockMsg.timestamp, &eternalBlockMsg.hash)
+	if pubkey, ok := crypto.RecoverCompact(confirmation[:], eternalBlockMsg.signature); ok {
 		addrPubKeyHash, err := types.NewAddressFromPubKey(pubkey)
 		if err != nil {
 			return err
 		}
 		addr := *addrPubKeyHash.Hash160()
 		var period *Period
-		for _, v := range bft.consensus.context.periodContext.period {
+		for _, v := range periodContext.period {
 			if v.addr == addr && peerID == v.peerID {
 				period = v
 			}
@@ -213,8 +246,15 @@ func (bft *BftService) handleEternalBlockMsg(msg p2p.Message) error {
 			return err
 		}
 
+		eternalBlockMsg.signer = addr
 		if msg, ok := bft.cache.Load(*key); ok {
 			value := msg.([]*EternalBlockMsg)
+			// a producer confirms a block only once
+			for _, v := range value {
+				if v.signer == addr {
+					return nil
+				}
+			}
 			value = append(value, eternalBlockMsg)
 			bft.cache.Store(*key, value)
 			if len(value) > MinConfirmMsgNumberForEternalBlock {
@@ -5,14 +5,19 @@
 package dpos
 
 import (
+	"bytes"
+	"sort"
+	"sync/atomic"
+
 	"github.com/BOXFoundation/boxd/consensus/dpos/pb"
 	"github.com/BOXFoundation/boxd/core"
 	"github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
 	conv "github.com/BOXFoundation/boxd/p2p/convert"
+	"github.com/BOXFoundation/boxd/script"
+	"github.com/BOXFoundation/boxd/util"
 	proto "github.com/gogo/protobuf/proto"
-	peer "github.com/libp2p/go-libp2p-peer"
 )
 
 // ConsensusContext represents consensus context info.
@@ -34,8 +39,6 @@ type PeriodContext struct {
 func InitPeriodContext() (*PeriodContext, error) {
 
 	periods := make([]*Period, len(chain.GenesisPeriod))
-	periodAddrs := make([]types.AddressHash, len(chain.GenesisPeriod))
-	periodPeers := make([]string, len(chain.GenesisPeriod))
 	for k, v := range chain.GenesisPeriod {
 		period := new(Period)
 		addr, err := types.NewAddress(v["addr"])
@@ -45,14 +48,40 @@ func InitPeriodContext() (*PeriodContext, error) {
 		period.addr = *addr.Hash160()
 		period.peerID = v["peerID"]
 		periods[k] = period
-		periodAddrs[k] = period.addr
-		periodPeers[k] = period.peerID
+	}
+	return newPeriodContext(periods, nil), nil
+}
+
+func newPeriodContext(period []*Period, nextPeriod []*Period) *PeriodContext {
+	periodAddrs := make([]types.AddressHash, len(period))
+	periodPeers := make([]string, len(period))
+	for k, v := range period {
+		periodAddrs[k] = v.addr
+		periodPeers[k] = v.peerID
 	}
 	return &PeriodContext{
-		period:      periods,
+		period:      period,
+		nextPeriod:  nextPeriod,
 		periodAddrs: periodAddrs,
 		periodPeers: periodPeers,
-	}, nil
+	}
+}
+
+// nextPeriodContext returns the period context at the beginning of next period.
+// Producers elected in current period take effect with peer ids they last published
+// as candidates, and the ones for next period are elected from candidates.
+func (pc *PeriodContext) nextPeriodContext(candidateContext *CandidateContext) *PeriodContext {
+	period := pc.period
+	if len(pc.nextPeriod) > 0 {
+		period = pc.nextPeriod
+	}
+	// producers slashed since the election are replaced by electing again
+	if candidateContext.anySlashed(period) {
+		if elected := candidateContext.electPeriod(); len(elected) > 0 {
+			period = elected
+		}
+	}
+	return newPeriodContext(candidateContext.withPeers(period), candidateContext.electPeriod())
 }
 
 var _ conv.Convertible = (*PeriodContext)(nil)
@@ -141,16 +170,26 @@ func (pc *PeriodContext) Unmarshal(data []byte) error {
 	return pc.FromProtoMessage(msg)
 }
 
+// PeriodContextHash calc period context hash, which is committed in block header.
+func (pc *PeriodContext) PeriodContextHash() (*crypto.HashType, error) {
+	bytes, err := pc.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.DoubleHashH(bytes)
+	return &hash, nil
+}
+
 // FindMinerWithTimeStamp find miner in given timestamp
 func (pc *PeriodContext) FindMinerWithTimeStamp(timestamp int64) (*types.AddressHash, error) {
 
 	period := pc.period
-	offsetPeriod := (timestamp * SecondInMs) % (NewBlockTimeInterval * PeriodSize)
+	offsetPeriod := (timestamp * SecondInMs) % (NewBlockTimeInterval * int64(PeriodSize))
 	if (offsetPeriod % NewBlockTimeInterval) != 0 {
 		return nil, ErrWrongTimeToMint
 	}
 	offset := offsetPeriod / NewBlockTimeInterval
-	offset = offset % PeriodSize
+	offset = offset % int64(PeriodSize)
 
 	var miner *types.AddressHash
 	if offset >= 0 && int(offset) < len(period) {
@@ -214,6 +253,8 @@ type CandidateContext struct {
 	height     uint32
 	candidates []*Candidate
 	addrs      []types.AddressHash
+	// candidates removed for equivocation, who may never sign up again
+	slashed []types.AddressHash
 }
 
 // InitCandidateContext init candidate context
@@ -241,9 +282,16 @@ func (candidateContext *CandidateContext) ToProtoMessage() (proto.Message, error
 		}
 	}
 
+	var slashed [][]byte
+	for _, v := range candidateContext.slashed {
+		addr := v
+		slashed = append(slashed, addr[:])
+	}
+
 	return &dpospb.CandidateContext{
 		Height:     candidateContext.height,
 		Candidates: candidates,
+		Slashed:    slashed,
 	}, nil
 }
 
@@ -262,9 +310,16 @@ func (candidateContext *CandidateContext) FromProtoMessage(message proto.Message
 				candidates[k] = candidate
 				addrs[k] = candidate.addr
 			}
+			var slashed []types.AddressHash
+			for _, v := range message.Slashed {
+				var addr types.AddressHash
+				copy(addr[:], v)
+				slashed = append(slashed, addr)
+			}
 			candidateContext.height = message.Height
 			candidateContext.candidates = candidates
 			candidateContext.addrs = addrs
+			candidateContext.slashed = slashed
 			return nil
 		}
 		return core.ErrEmptyProtoMessage
@@ -287,6 +342,243 @@ func (candidateContext *CandidateContext) Unmarshal(data []byte) error {
 	return candidateContext.FromProtoMessage(msg)
 }
 
+// Copy returns a deep copy of candidate context.
+func (candidateContext *CandidateContext) Copy() *CandidateContext {
+	candidates := make([]*Candidate, len(candidateContext.candidates))
+	for k, v := range candidateContext.candidates {
+		candidate := *v
+		candidates[k] = &candidate
+	}
+	addrs := make([]types.AddressHash, len(candidateContext.addrs))
+	copy(addrs, candidateContext.addrs)
+	var slashed []types.AddressHash
+	slashed = append(slashed, candidateContext.slashed...)
+	return &CandidateContext{
+		height:     candidateContext.height,
+		candidates: candidates,
+		addrs:      addrs,
+		slashed:    slashed,
+	}
+}
+
+// periodLoader loads period context of the period hash in block header.
+type periodLoader func(hash *crypto.HashType) (*PeriodContext, error)
+
+// applyTx updates candidate context with register, unregister, update candidate,
+// vote, unvote and evidence tx, where utxos contains utxos spent by tx, and
+// evidences are verified against periods loaded with loadPeriod.
+func (candidateContext *CandidateContext) applyTx(tx *types.Transaction,
+	utxos map[types.OutPoint]*types.UtxoWrap, loadPeriod periodLoader) error {
+
+	if tx.Data == nil {
+		return nil
+	}
+	content := tx.Data.Content
+	switch int(tx.Data.Type) {
+	case types.RegisterCandidateTx:
+		signUpContent := new(types.SignUpContent)
+		if err := signUpContent.Unmarshal(content); err != nil {
+			return err
+		}
+		if util.InArray(signUpContent.Addr(), candidateContext.slashed) {
+			return ErrCandidateSlashed
+		}
+		// a withdrawn candidate signs up again with votes still locked for it
+		if candidate := candidateContext.candidate(signUpContent.Addr()); candidate != nil {
+			if !candidate.withdrawn {
+				return ErrDuplicateSignUpTx
+			}
+			candidate.withdrawn = false
+			candidate.peerID = signUpContent.PeerID()
+			return nil
+		}
+		candidate := &Candidate{
+			addr:   signUpContent.Addr(),
+			votes:  0,
+			peerID: signUpContent.PeerID(),
+		}
+		candidateContext.candidates = append(candidateContext.candidates, candidate)
+		candidateContext.addrs = append(candidateContext.addrs, candidate.addr)
+	case types.UnregisterCandidateTx:
+		unregisterContent := new(types.UnregisterContent)
+		if err := unregisterContent.Unmarshal(content); err != nil {
+			return err
+		}
+		candidate := candidateContext.candidate(unregisterContent.Addr())
+		if candidate == nil || candidate.withdrawn {
+			return ErrCandidateNotFound
+		}
+		candidate.withdrawn = true
+	case types.UpdateCandidateTx:
+		infoContent := new(types.CandidateInfoContent)
+		if err := infoContent.Unmarshal(content); err != nil {
+			return err
+		}
+		candidate := candidateContext.candidate(infoContent.Addr())
+		if candidate == nil || candidate.withdrawn {
+			return ErrCandidateNotFound
+		}
+		candidate.peerID = infoContent.PeerID()
+		candidate.endpoint = infoContent.Endpoint()
+		candidate.description = infoContent.Description()
+	case types.VoteTx:
+		votesContent := new(types.VoteContent)
+		if err := votesContent.Unmarshal(content); err != nil {
+			return err
+		}
+		candidate := candidateContext.candidate(votesContent.Addr())
+		if candidate == nil || candidate.withdrawn {
+			return ErrCandidateNotFound
+		}
+		// votes are coins locked in vote outputs
+		for _, txOut := range tx.Vout {
+			if target := voteCandidate(txOut.ScriptPubKey); target != nil && *target == candidate.addr {
+				atomic.AddInt64(&candidate.votes, int64(txOut.Value))
+			}
+		}
+	case types.UnvoteTx:
+		// votes are withdrawn as vote outputs are spent
+		for _, txIn := range tx.Vin {
+			utxo := utxos[txIn.PrevOutPoint]
+			if utxo == nil {
+				return ErrVoteOutputNotFound
+			}
+			target := voteCandidate(utxo.Output.ScriptPubKey)
+			if target == nil {
+				continue
+			}
+			// stake of a slashed candidate, i.e., every vote output it owns
+			// whichever candidate it votes for, is forfeited and stays locked
+			// forever. Votes of others for it are not, as voters did not equivocate.
+			if util.InArray(voteOwner(utxo.Output.ScriptPubKey), candidateContext.slashed) {
+				return ErrStakeForfeited
+			}
+			if candidate := candidateContext.candidate(*target); candidate != nil {
+				atomic.AddInt64(&candidate.votes, -int64(utxo.Output.Value))
+			}
+		}
+	case types.EvidenceTx:
+		evidence := new(Evidence)
+		if err := evidence.Unmarshal(content); err != nil {
+			return err
+		}
+		offender, err := evidence.Verify(loadPeriod)
+		if err != nil {
+			return err
+		}
+		if util.InArray(*offender, candidateContext.slashed) {
+			return ErrDuplicateEvidence
+		}
+		candidateContext.slash(*offender)
+	default:
+	}
+	return nil
+}
+
+func (candidateContext *CandidateContext) candidate(addr types.AddressHash) *Candidate {
+	for _, v := range candidateContext.candidates {
+		if v.addr == addr {
+			return v
+		}
+	}
+	return nil
+}
+
+// slash removes the offender from candidates and records it as slashed. Votes
+// owned by the offender are forfeited, see applyTx.
+func (candidateContext *CandidateContext) slash(offender types.AddressHash) {
+	for k, v := range candidateContext.candidates {
+		if v.addr == offender {
+			candidateContext.candidates = append(candidateContext.candidates[:k:k], candidateContext.candidates[k+1:]...)
+			candidateContext.addrs = append(candidateContext.addrs[:k:k], candidateContext.addrs[k+1:]...)
+			break
+		}
+	}
+	candidateContext.slashed = append(candidateContext.slashed, offender)
+}
+
+// withPeers returns copies of producers of the period, whose peer ids are the
+// ones last published by them as candidates if any.
+func (candidateContext *CandidateContext) withPeers(period []*Period) []*Period {
+	periods := make([]*Period, len(period))
+	for k, v := range period {
+		periods[k] = &Period{addr: v.addr, peerID: v.peerID}
+		if candidate := candidateContext.candidate(v.addr); candidate != nil && candidate.peerID != "" {
+			periods[k].peerID = candidate.peerID
+		}
+	}
+	return periods
+}
+
+// anySlashed returns if any producer of the period is slashed
+func (candidateContext *CandidateContext) anySlashed(period []*Period) bool {
+	for _, v := range period {
+		if util.InArray(v.addr, candidateContext.slashed) {
+			return true
+		}
+	}
+	return false
+}
+
+// voteOwner returns the address owning the coins locked in the vote script
+func voteOwner(scriptPubKey []byte) types.AddressHash {
+	var owner types.AddressHash
+	addr, err := script.NewScriptFromBytes(scriptPubKey).ExtractAddress()
+	if err == nil {
+		owner = *addr.Hash160()
+	}
+	return owner
+}
+
+// voteCandidate returns the candidate voted for if the script is a vote script, or nil
+func voteCandidate(scriptPubKey []byte) *types.AddressHash {
+	s := script.NewScriptFromBytes(scriptPubKey)
+	if !s.IsVote() {
+		return nil
+	}
+	candidate, err := s.GetVoteCandidate()
+	if err != nil {
+		return nil
+	}
+	return candidate
+}
+
+// electPeriod elects top PeriodSize candidates not withdrawn by votes as producers,
+// and ties are broken by address. It returns nil if there are not enough candidates with votes.
+func (candidateContext *CandidateContext) electPeriod() []*Period {
+
+	candidates := make([]*Candidate, 0, len(candidateContext.candidates))
+	for _, v := range candidateContext.candidates {
+		if v.votes > 0 && !v.withdrawn {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) < PeriodSize {
+		return nil
+	}
+	sortByVotes(candidates)
+
+	periods := make([]*Period, PeriodSize)
+	for k := range periods {
+		periods[k] = &Period{
+			addr:   candidates[k].addr,
+			peerID: candidates[k].peerID,
+		}
+	}
+	return periods
+}
+
+// sortByVotes sorts candidates by votes in descending order, and ties are broken
+// by address.
+func sortByVotes(candidates []*Candidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].votes != candidates[j].votes {
+			return candidates[i].votes > candidates[j].votes
+		}
+		return bytes.Compare(candidates[i].addr[:], candidates[j].addr[:]) < 0
+	})
+}
+
 // CandidateContextHash calc candidate context hash.
 func (candidateContext *CandidateContext) CandidateContextHash() (*crypto.HashType, error) {
 	bytes, err := candidateContext.Marshal()
@@ -302,9 +594,39 @@ func (candidateContext *CandidateContext) CandidateContextHash() (*crypto.HashTy
 
 // Candidate represents possible to be the miner.
 type Candidate struct {
-	addr  types.AddressHash
-	votes int64
-	peer  peer.ID
+	addr        types.AddressHash
+	votes       int64
+	peerID      string
+	endpoint    string
+	description string
+	// withdrawn from election by unregister tx. It is kept to account votes
+	// still locked for it, and may sign up again.
+	withdrawn bool
+}
+
+// Addr returns address of the candidate.
+func (candidate *Candidate) Addr() types.AddressHash {
+	return candidate.addr
+}
+
+// Votes returns votes for the candidate.
+func (candidate *Candidate) Votes() int64 {
+	return candidate.votes
+}
+
+// PeerID returns peer id published by the candidate.
+func (candidate *Candidate) PeerID() string {
+	return candidate.peerID
+}
+
+// Endpoint returns endpoint published by the candidate.
+func (candidate *Candidate) Endpoint() string {
+	return candidate.endpoint
+}
+
+// Description returns description published by the candidate.
+func (candidate *Candidate) Description() string {
+	return candidate.description
 }
 
 var _ conv.Convertible = (*Candidate)(nil)
@@ -313,9 +635,12 @@ var _ conv.Serializable = (*Candidate)(nil)
 // ToProtoMessage converts candidate to proto message.
 func (candidate *Candidate) ToProtoMessage() (proto.Message, error) {
 	return &dpospb.Candidate{
-		Addr:  candidate.addr[:],
-		Votes: candidate.votes,
-		// Peer:  candidate.peer.Pretty(),
+		Addr:        candidate.addr[:],
+		Votes:       candidate.votes,
+		Peer:        candidate.peerID,
+		Endpoint:    candidate.endpoint,
+		Description: candidate.description,
+		Withdrawn:   candidate.withdrawn,
 	}, nil
 }
 
@@ -325,6 +650,10 @@ func (candidate *Candidate) FromProtoMessage(message proto.Message) error {
 		if message != nil {
 			copy(candidate.addr[:], message.Addr)
 			candidate.votes = message.Votes
+			candidate.peerID = message.Peer
+			candidate.endpoint = message.Endpoint
+			candidate.description = message.Description
+			candidate.withdrawn = message.Withdrawn
 			return nil
 		}
 		return core.ErrEmptyProtoMessage
@@ -355,6 +684,8 @@ type EternalBlockMsg struct {
 	hash      crypto.HashType
 	signature []byte
 	timestamp int64
+	// recovered from signature, which is not serialized
+	signer types.AddressHash
 }
 
 var _ conv.Convertible = (*EternalBlockMsg)(nil)
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"math"
+	"time"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/wallet"
+	"github.com/jbenet/goprocess"
+)
+
+// DevAllocation is the amount pre-funded to each account in dev mode
+var DevAllocation = (uint64)(1e6 * math.Pow10(core.Decimals))
+
+// DevGenesis returns the genesis spec of dev mode, where the miner is the only
+// validator and every second is its slot, so it produces blocks alone. The miner
+// and dev accounts are pre-funded.
+func DevGenesis(cfg *Config) (*chain.Genesis, error) {
+	account, err := wallet.NewAccountFromFile(cfg.Keypath)
+	if err != nil {
+		return nil, err
+	}
+	genesis := &chain.Genesis{
+		Network: "dev",
+		Validators: []chain.GenesisValidator{
+			{Addr: account.Addr(), PeerID: "dev"},
+		},
+		Consensus: chain.ConsensusParams{
+			BlockInterval:   SecondInMs,
+			MaxPackedTxTime: SecondInMs / 2,
+		},
+	}
+	for _, addr := range append([]string{account.Addr()}, cfg.DevAccounts...) {
+		genesis.Allocations = append(genesis.Allocations, chain.GenesisAlloc{
+			Addr:  addr,
+			Value: DevAllocation,
+		})
+	}
+	if err := genesis.Validate(); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// devLoop generates blocks every DevInterval seconds in dev mode. Blocks are
+// only generated on demand if it is not set.
+func (dpos *Dpos) devLoop(p goprocess.Process) {
+	if dpos.cfg.DevInterval <= 0 {
+		logger.Info("Generate blocks on demand in dev mode")
+		return
+	}
+	logger.Infof("Generate blocks every %d seconds in dev mode", dpos.cfg.DevInterval)
+	timeChan := time.NewTicker(time.Duration(dpos.cfg.DevInterval) * time.Second)
+	defer timeChan.Stop()
+	for {
+		select {
+		case <-timeChan.C:
+			if _, err := dpos.generateBlock(); err != nil {
+				logger.Warnf("Failed to generate block. err: %s", err.Error())
+			}
+		case <-p.Closing():
+			logger.Info("Stopped generating blocks in dev mode.")
+			return
+		}
+	}
+}
+
+// GenerateBlocks generates count blocks right away in dev mode, and returns
+// hashes of the blocks generated. Since blocks generated in a burst take the
+// following seconds, count is rejected before any block is generated if the
+// last block would be too far in the future to be accepted.
+func (dpos *Dpos) GenerateBlocks(count uint32) ([]*crypto.HashType, error) {
+	if !dpos.cfg.Dev {
+		return nil, ErrNotDevMode
+	}
+	dpos.devLock.Lock()
+	defer dpos.devLock.Unlock()
+
+	if count > maxBlocksToGenerate(time.Now().Unix(), dpos.chain.TailBlock().Header.TimeStamp) {
+		return nil, ErrTooManyBlocksRequested
+	}
+	hashes := make([]*crypto.HashType, 0, count)
+	for i := uint32(0); i < count; i++ {
+		hash, err := dpos.mintNextBlock()
+		if err != nil {
+			return hashes, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// maxBlocksToGenerate returns the max number of blocks generated in a burst at
+// now following tail at timestamp tail, with the last one no more than
+// MaxTimeOffsetSeconds ahead of now.
+func maxBlocksToGenerate(now, tail int64) uint32 {
+	first := now
+	if first <= tail {
+		first = tail + 1
+	}
+	if first > now+chain.MaxTimeOffsetSeconds {
+		return 0
+	}
+	return uint32(now + chain.MaxTimeOffsetSeconds - first + 1)
+}
+
+// generateBlock generates a block in dev mode.
+func (dpos *Dpos) generateBlock() (*crypto.HashType, error) {
+	dpos.devLock.Lock()
+	defer dpos.devLock.Unlock()
+	return dpos.mintNextBlock()
+}
+
+// mintNextBlock mints a block at now, or the second after tail if tail is
+// not earlier, so blocks generated in a burst take the following seconds.
+func (dpos *Dpos) mintNextBlock() (*crypto.HashType, error) {
+	timestamp := time.Now().Unix()
+	if tail := dpos.chain.TailBlock(); timestamp <= tail.Header.TimeStamp {
+		timestamp = tail.Header.TimeStamp + 1
+	}
+	if err := dpos.mint(timestamp); err != nil {
+		return nil, err
+	}
+	return dpos.chain.TailBlock().BlockHash(), nil
+}
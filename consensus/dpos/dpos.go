@@ -5,12 +5,11 @@
 package dpos
 
 import (
-	"container/heap"
 	"errors"
 	"sync"
-	"sync/atomic"
 	"time"
 
+	"github.com/BOXFoundation/boxd/boxd/eventbus"
 	"github.com/BOXFoundation/boxd/boxd/service"
 	"github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/txpool"
@@ -18,8 +17,9 @@ import (
 	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/log"
 	"github.com/BOXFoundation/boxd/p2p"
+	"github.com/BOXFoundation/boxd/storage"
 	"github.com/BOXFoundation/boxd/util"
-	"github.com/BOXFoundation/boxd/wallet"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/jbenet/goprocess"
 )
 
@@ -27,10 +27,20 @@ var logger = log.NewLogger("dpos") // logger
 
 // Define const
 const (
-	SecondInMs           = int64(1000)
+	SecondInMs      = int64(1000)
+	MaxBlockTimeOut = 2
+
+	// size reserved for block header when packing txs
+	blockHeaderReservedSize = 1000
+	// max size of the field tag and length prefix of a tx serialized in a block
+	txEncodingOverhead = 5
+)
+
+// Consensus parameters, which default to the ones of mainnet and are overridden
+// by the genesis spec of a network. See SetGenesisParams.
+var (
 	NewBlockTimeInterval = int64(5000)
 	MaxPackedTxTime      = int64(2000)
-	MaxBlockTimeOut      = 2
 	PeriodSize           = 6
 )
 
@@ -39,6 +49,36 @@ type Config struct {
 	Keypath    string `mapstructure:"keypath"`
 	EnableMint bool   `mapstructure:"enable_mint"`
 	Passphrase string `mapstructure:"passphrase"`
+	// unix socket of the remote signer holding the producer key, which is used
+	// instead of the key in Keypath if set
+	Signer string `mapstructure:"signer"`
+
+	// dev mode, where the node produces blocks alone. See DevGenesis.
+	Dev bool `mapstructure:"dev"`
+	// seconds between blocks generated in dev mode, and 0 for on demand only
+	DevInterval int `mapstructure:"dev_interval"`
+	// accounts pre-funded in dev mode besides the miner
+	DevAccounts []string `mapstructure:"dev_accounts"`
+}
+
+// SetGenesisParams overrides consensus parameters with the ones in genesis spec,
+// where genesis validators make up a period. It must be called before dpos is created.
+func SetGenesisParams(genesis *chain.Genesis) error {
+	blockInterval, maxPackedTxTime := NewBlockTimeInterval, MaxPackedTxTime
+	if genesis.Consensus.BlockInterval > 0 {
+		blockInterval = genesis.Consensus.BlockInterval
+	}
+	if genesis.Consensus.MaxPackedTxTime > 0 {
+		maxPackedTxTime = genesis.Consensus.MaxPackedTxTime
+	}
+	if blockInterval%SecondInMs != 0 || maxPackedTxTime >= blockInterval {
+		return ErrInvalidConsensusParams
+	}
+	NewBlockTimeInterval = blockInterval
+	MaxPackedTxTime = maxPackedTxTime
+	PeriodSize = len(genesis.Validators)
+	MinConfirmMsgNumberForEternalBlock = 2 * PeriodSize / 3
+	return nil
 }
 
 // Dpos define dpos struct
@@ -49,9 +89,18 @@ type Dpos struct {
 	net         p2p.Net
 	proc        goprocess.Process
 	cfg         *Config
-	miner       *wallet.Account
+	signer      Signer
 	enableMint  bool
 	disableMint bool
+	periodLock  sync.RWMutex
+	devLock     sync.Mutex
+	statsLock   sync.Mutex
+
+	// signed headers recently verified, to detect equivocation
+	signedHeaders *lru.Cache
+	// pending evidences to be packed, keyed by offender
+	evidences     *sync.Map
+	evidenceMsgCh chan p2p.Message
 }
 
 // NewDpos new a dpos implement.
@@ -62,7 +111,11 @@ func NewDpos(parent goprocess.Process, chain *chain.BlockChain, txpool *txpool.T
 		net:    net,
 		proc:   goprocess.WithParent(parent),
 		cfg:    cfg,
+
+		evidences:     new(sync.Map),
+		evidenceMsgCh: make(chan p2p.Message, EvidenceMsgChBufferSize),
 	}
+	dpos.signedHeaders, _ = lru.New(SignedHeaderCacheSize)
 
 	context := &ConsensusContext{}
 	dpos.context = context
@@ -72,21 +125,25 @@ func NewDpos(parent goprocess.Process, chain *chain.BlockChain, txpool *txpool.T
 	}
 	context.periodContext = period
 
+	// validator stats are tracked whether minting or not
+	chain.Bus().Subscribe(eventbus.TopicChainUpdate, dpos.receiveChainUpdateMsg)
+	dpos.proc.SetTeardown(dpos.teardown)
+
 	return dpos, nil
 }
 
 // EnableMint return the peer mint status
 func (dpos *Dpos) EnableMint() bool {
-	return dpos.cfg.EnableMint
+	return dpos.cfg.EnableMint || dpos.cfg.Dev
 }
 
 // Setup setup dpos
 func (dpos *Dpos) Setup() error {
-	account, err := wallet.NewAccountFromFile(dpos.cfg.Keypath)
+	signer, err := newSigner(dpos.cfg)
 	if err != nil {
 		return err
 	}
-	dpos.miner = account
+	dpos.signer = signer
 
 	return nil
 }
@@ -97,18 +154,27 @@ var _ service.Server = (*Dpos)(nil)
 // Run start dpos
 func (dpos *Dpos) Run() error {
 	logger.Info("Dpos run")
-	if !dpos.ValidateMiner() {
-		logger.Warn("You have no authority to mint block")
+	if dpos.signer == nil {
 		return ErrNoLegalPowerToMint
 	}
+	// producers change every period, so the peer keeps running to mint once elected.
+	if !dpos.ValidateMiner() {
+		logger.Warn("You have no authority to mint block in current period")
+	}
 
-	// peer can mint, start bftService.
+	// start bftService.
 	bftService, err := NewBftService(dpos)
 	if err != nil {
 		return err
 	}
 	bftService.Start()
-	dpos.proc.Go(dpos.loop)
+	dpos.net.Subscribe(p2p.NewNotifiee(p2p.EvidenceMsg, p2p.Repeatable, dpos.evidenceMsgCh))
+	dpos.proc.Go(dpos.evidenceLoop)
+	if dpos.cfg.Dev {
+		dpos.proc.Go(dpos.devLoop)
+	} else {
+		dpos.proc.Go(dpos.loop)
+	}
 
 	return nil
 }
@@ -123,6 +189,11 @@ func (dpos *Dpos) Stop() {
 	dpos.proc.Close()
 }
 
+// teardown to clean the process
+func (dpos *Dpos) teardown() error {
+	return dpos.chain.Bus().Unsubscribe(eventbus.TopicChainUpdate, dpos.receiveChainUpdateMsg)
+}
+
 // StopMint stops generating blocks.
 func (dpos *Dpos) StopMint() {
 	dpos.disableMint = true
@@ -171,11 +242,11 @@ func (dpos *Dpos) mint(timestamp int64) error {
 // checkMiner check to verify if miner can mint at the timestamp
 func (dpos *Dpos) checkMiner(timestamp int64) error {
 
-	miner, err := dpos.context.periodContext.FindMinerWithTimeStamp(timestamp)
+	miner, err := dpos.activePeriodContext().FindMinerWithTimeStamp(timestamp)
 	if err != nil {
 		return err
 	}
-	addr, err := types.NewAddress(dpos.miner.Addr())
+	addr, err := types.NewAddress(dpos.signer.Addr())
 	if err != nil {
 		return err
 	}
@@ -188,22 +259,15 @@ func (dpos *Dpos) checkMiner(timestamp int64) error {
 // ValidateMiner verifies whether the miner has authority to mint.
 func (dpos *Dpos) ValidateMiner() bool {
 
-	if dpos.miner == nil {
+	if dpos.signer == nil {
 		return false
 	}
 
-	addr, err := types.NewAddress(dpos.miner.Addr())
+	addr, err := types.NewAddress(dpos.signer.Addr())
 	if err != nil {
 		return false
 	}
-	if !util.InArray(*addr.Hash160(), dpos.context.periodContext.periodAddrs) {
-		return false
-	}
-	if err := dpos.miner.UnlockWithPassphrase(dpos.cfg.Passphrase); err != nil {
-		logger.Error(err)
-		return false
-	}
-	return true
+	return util.InArray(*addr.Hash160(), dpos.activePeriodContext().periodAddrs)
 }
 
 func (dpos *Dpos) mintBlock() error {
@@ -212,11 +276,19 @@ func (dpos *Dpos) mintBlock() error {
 	block := types.NewBlock(tail)
 	block.Header.TimeStamp = dpos.context.timestamp
 	if block.Height > 0 && block.Height%chain.PeriodDuration == 0 {
-		// TODO: period changed
+		periodContext, err := dpos.nextPeriodContext(tail)
+		if err != nil {
+			return err
+		}
+		periodHash, err := periodContext.PeriodContextHash()
+		if err != nil {
+			return err
+		}
+		block.Header.PeriodHash = *periodHash
 	} else {
 		block.Header.PeriodHash = tail.Header.PeriodHash
 	}
-	if err := dpos.PackTxs(block, dpos.miner.PubKeyHash()); err != nil {
+	if err := dpos.PackTxs(block, dpos.signer.PubKeyHash()); err != nil {
 		logger.Warnf("Failed to pack txs. err: %s", err.Error())
 		return err
 	}
@@ -231,91 +303,88 @@ func (dpos *Dpos) mintBlock() error {
 	return nil
 }
 
-func lessFunc(queue *util.PriorityQueue, i, j int) bool {
-
-	txi := queue.Items(i).(*chain.TxWrap)
-	txj := queue.Items(j).(*chain.TxWrap)
-	if txi.FeePerKB == txj.FeePerKB {
-		return txi.AddedTimestamp < txj.AddedTimestamp
-	}
-	return txi.FeePerKB < txj.FeePerKB
-}
-
-// sort pending transactions in mempool
-func (dpos *Dpos) sortPendingTxs() []*chain.TxWrap {
-	pool := util.NewPriorityQueue(lessFunc)
-	pendingTxs := dpos.txpool.GetAllTxs()
-	for _, pendingTx := range pendingTxs {
-		// place onto heap sorted by FeePerKB
-		heap.Push(pool, pendingTx)
-	}
-
-	var sortedTxs []*chain.TxWrap
-	for pool.Len() > 0 {
-		txWrap := heap.Pop(pool).(*chain.TxWrap)
-		sortedTxs = append(sortedTxs, txWrap)
-	}
-	return sortedTxs
-}
-
 // PackTxs packed txs and add them to block.
 func (dpos *Dpos) PackTxs(block *types.Block, scriptAddr []byte) error {
 
-	// We sort txs in mempool by fees when packing while ensuring child tx is not packed before parent tx.
-	// otherwise the former's utxo is missing
-	sortedTxs := dpos.sortPendingTxs()
-	// if i-th sortedTxs is packed into the block
-	txPacked := make([]bool, len(sortedTxs))
-
 	var blockTxns []*types.Transaction
-	coinbaseTx, err := chain.CreateCoinbaseTx(scriptAddr, dpos.chain.LongestChainHeight+1)
+	// The coinbase paying fees is created after txs are selected. Its value only
+	// takes a few more bytes, which fits in the reserved size of block header.
+	coinbaseTx, err := chain.CreateCoinbaseTx(scriptAddr, dpos.chain.LongestChainHeight+1, 0)
 	if err != nil || coinbaseTx == nil {
 		logger.Error("Failed to create coinbaseTx")
 		return errors.New("Failed to create coinbaseTx")
 	}
-	blockTxns = append(blockTxns, coinbaseTx)
-	remainTimeInMs := dpos.context.timestamp + MaxPackedTxTime - time.Now().Unix()*SecondInMs
+	coinbaseSize, err := coinbaseTx.SerializeSize()
+	if err != nil {
+		return err
+	}
+	// blocks generated in a burst in dev mode have timestamps ahead of now
+	remainTimeInMs := dpos.context.timestamp*SecondInMs + MaxPackedTxTime - time.Now().Unix()*SecondInMs
+	if remainTimeInMs > MaxPackedTxTime {
+		remainTimeInMs = MaxPackedTxTime
+	}
 	remainTimer := time.NewTimer(time.Duration(remainTimeInMs) * time.Millisecond)
+	defer remainTimer.Stop()
 
 	spendableTxs := new(sync.Map)
-
-PackingTxs:
-	for {
-		select {
-		case <-remainTimer.C:
-			break PackingTxs
-		default:
-			found := true
-			for found {
-				found = false
-				for i, txWrap := range sortedTxs {
-					if txPacked[i] {
-						continue
-					}
-
-					if err := dpos.prepareCandidateContext(txWrap.Tx); err != nil {
-						// TODO: abandon the error tx
-						continue
-					}
-
-					txHash, _ := txWrap.Tx.TxHash()
-					utxoSet, err := chain.GetExtendedTxUtxoSet(txWrap.Tx, dpos.chain.DB(), spendableTxs)
-					if err != nil {
-						logger.Errorf("Could not get extended utxo set for tx %v", txHash)
-						continue
-					}
-					// This is to ensure within mempool, a parent tx is packed before its children txs
-					if !utxoSet.IsTxFunded(txWrap.Tx) {
-						// This can only occur for a mempool tx if its parent txs (also in mempool) are not packed yet
-						continue
-					}
-					spendableTxs.Store(*txHash, txWrap)
-					blockTxns = append(blockTxns, txWrap.Tx)
-					txPacked[i] = true
-					found = true
-				}
-			}
+	totalSigOpCnt := chain.CountSigOps(coinbaseTx)
+	var totalFees uint64
+	tryPack := func(txWrap *chain.TxWrap) bool {
+		txHash, _ := txWrap.Tx.TxHash()
+		utxoSet, err := chain.GetExtendedTxUtxoSet(txWrap.Tx, dpos.chain.DB(), spendableTxs)
+		if err != nil {
+			logger.Errorf("Could not get extended utxo set for tx %v", txHash)
+			return false
+		}
+		// Parents in mempool are always packed before their children, so this only fails if a parent is rejected
+		if !utxoSet.IsTxFunded(txWrap.Tx) {
+			return false
+		}
+		// The fee is validated the same way as the block is connected, since the coinbase claims it
+		fee, err := chain.ValidateTxInputs(utxoSet, txWrap.Tx, block.Height)
+		if err != nil {
+			logger.Warnf("Could not pack tx %v: %v", txHash, err)
+			return false
+		}
+		// Skip the tx if it would exceed the block sigop budget; a cheaper one may still fit
+		sigOpCnt := chain.CountSigOps(txWrap.Tx) + chain.CountP2SHSigOps(utxoSet, txWrap.Tx)
+		if totalSigOpCnt+sigOpCnt > chain.MaxBlockSigOpCnt {
+			return false
 		}
+		// txs failing to apply are mostly rejected by txpool, except those invalidated
+		// by blocks connected afterwards, e.g., votes for candidates unregistered since
+		if err := dpos.prepareCandidateContext(txWrap.Tx, utxoSet.GetUtxos()); err != nil {
+			logger.Warnf("Could not pack tx %v: %v", txHash, err)
+			return false
+		}
+		spendableTxs.Store(*txHash, txWrap)
+		totalSigOpCnt += sigOpCnt
+		totalFees += fee
+		return true
+	}
+
+	// We select txs in mempool by fee rate of packages including their unpacked ancestors,
+	// so a child with high fee pays for its parents, and a parent is always packed before its children.
+	// Evidences are packed first, so candidates slashed in the block get no more votes.
+	evidenceTxs := dpos.packEvidences()
+	maxSize := chain.MaxBlockSize - blockHeaderReservedSize - coinbaseSize - txEncodingOverhead
+	maxCount := chain.MaxTxsPerBlock - 1 - len(evidenceTxs)
+	for _, tx := range evidenceTxs {
+		size, err := tx.SerializeSize()
+		if err != nil {
+			return err
+		}
+		maxSize -= size + txEncodingOverhead
+	}
+	selected := selectTxs(dpos.txpool.GetAllTxs(), maxSize, maxCount, remainTimer.C, tryPack)
+
+	if coinbaseTx, err = chain.CreateCoinbaseTx(scriptAddr, block.Height, totalFees); err != nil {
+		return err
+	}
+	blockTxns = append(blockTxns, coinbaseTx)
+	blockTxns = append(blockTxns, evidenceTxs...)
+	for _, txWrap := range selected {
+		blockTxns = append(blockTxns, txWrap.Tx)
 	}
 
 	candidateHash, err := dpos.context.candidateContext.CandidateContextHash()
@@ -326,7 +395,13 @@ PackingTxs:
 	merkles := chain.CalcTxsHash(blockTxns)
 	block.Header.TxsRoot = *merkles
 	block.Txs = blockTxns
-	logger.Infof("Finish packing txs. Height: %d, TxsNum: %d", block.Height, len(blockTxns))
+	utxoRoot, err := dpos.chain.CalcUtxoRoot(block)
+	if err != nil {
+		return err
+	}
+	block.Header.UtxoRoot = *utxoRoot
+	logger.Infof("Finish packing txs. Height: %d, TxsNum: %d, Fees: %d", block.Height,
+		len(blockTxns), totalFees)
 	return nil
 }
 
@@ -356,19 +431,24 @@ func (dpos *Dpos) LoadPeriodContext() (*PeriodContext, error) {
 	return periodContext, nil
 }
 
-// BroadcastEternalMsgToMiners broadcast eternal message to miners
+// BroadcastEternalMsgToMiners broadcast eternal message to miners of the period
+// block commits to, who collect confirmations of it.
 func (dpos *Dpos) BroadcastEternalMsgToMiners(block *types.Block) error {
 
+	periodContext, err := dpos.loadPeriodContext(&block.Header.PeriodHash)
+	if err != nil {
+		return err
+	}
 	eternalBlockMsg := &EternalBlockMsg{}
 	hash := block.BlockHash()
-	signature, err := crypto.SignCompact(dpos.miner.PrivateKey(), hash[:])
+	signature, err := dpos.signer.SignConfirmation(block.Header.TimeStamp, hash)
 	if err != nil {
 		return err
 	}
 	eternalBlockMsg.hash = *hash
 	eternalBlockMsg.signature = signature
 	eternalBlockMsg.timestamp = block.Header.TimeStamp
-	miners := dpos.context.periodContext.periodPeers
+	miners := periodContext.periodPeers
 
 	return dpos.net.BroadcastToMiners(p2p.EternalBlockMsg, eternalBlockMsg, miners)
 }
@@ -376,98 +456,268 @@ func (dpos *Dpos) BroadcastEternalMsgToMiners(block *types.Block) error {
 // StorePeriodContext store period context
 func (dpos *Dpos) StorePeriodContext() error {
 
-	db := dpos.chain.DB()
-	context, err := dpos.context.periodContext.Marshal()
+	batch := dpos.chain.DB().NewBatch()
+	defer batch.Close()
+	if err := storePeriodContext(dpos.activePeriodContext(), batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func storePeriodContext(periodContext *PeriodContext, batch storage.Batch) error {
+
+	context, err := periodContext.Marshal()
 	if err != nil {
 		return err
 	}
-	return db.Put(chain.PeriodKey, context)
+	// also stored by its hash, which blocks in the period commit to
+	hash := crypto.DoubleHashH(context)
+	batch.Put(chain.PeriodContextKey(&hash), context)
+	batch.Put(chain.PeriodKey, context)
+	return nil
+}
+
+// UpdatePeriodContext updates period context when block is connected to main chain.
+// At the beginning of each period, producers elected in the last period take effect
+// and ones for the next period are elected from candidates as of the parent block.
+// Period hash in block header must commit to the resulting period context, which
+// is written with batch. The returned function, nil if period does not change,
+// switches to the new period context and is called only once batch is written.
+func (dpos *Dpos) UpdatePeriodContext(block *types.Block, batch storage.Batch) (func(), error) {
+
+	parent, err := dpos.chain.LoadBlockByHash(block.Header.PrevBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if block.Height%chain.PeriodDuration != 0 {
+		if block.Header.PeriodHash != parent.Header.PeriodHash {
+			return nil, ErrInvalidPeriodHash
+		}
+		return nil, nil
+	}
+
+	periodContext, err := dpos.nextPeriodContext(parent)
+	if err != nil {
+		return nil, err
+	}
+	periodHash, err := periodContext.PeriodContextHash()
+	if err != nil {
+		return nil, err
+	}
+	if *periodHash != block.Header.PeriodHash {
+		logger.Errorf("block period hash is invalid: %s, calculated %s",
+			block.Header.PeriodHash, periodHash)
+		return nil, ErrInvalidPeriodHash
+	}
+	if err := storePeriodContext(periodContext, batch); err != nil {
+		return nil, err
+	}
+	return func() {
+		dpos.setPeriodContext(periodContext)
+		logger.Infof("Period changed at height %d. Hash: %s", block.Height, periodHash)
+	}, nil
+}
+
+// RevertPeriodContext rolls period context back to the one as of the parent
+// when block is disconnected from main chain, which is written with batch. As
+// UpdatePeriodContext, the returned function switches to it once batch is written.
+func (dpos *Dpos) RevertPeriodContext(block *types.Block, batch storage.Batch) (func(), error) {
+
+	if block.Height%chain.PeriodDuration != 0 {
+		return nil, nil
+	}
+	parent, err := dpos.chain.LoadBlockByHash(block.Header.PrevBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	periodContext, err := dpos.loadPeriodContext(&parent.Header.PeriodHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := storePeriodContext(periodContext, batch); err != nil {
+		return nil, err
+	}
+	return func() { dpos.setPeriodContext(periodContext) }, nil
+}
+
+// nextPeriodContext returns the period context at the beginning of the period
+// following parent.
+func (dpos *Dpos) nextPeriodContext(parent *types.Block) (*PeriodContext, error) {
+
+	periodContext, err := dpos.loadPeriodContext(&parent.Header.PeriodHash)
+	if err != nil {
+		return nil, err
+	}
+	candidateContext, err := dpos.loadCandidateContext(parent.BlockHash())
+	if err != nil {
+		return nil, err
+	}
+	return periodContext.nextPeriodContext(candidateContext), nil
+}
+
+// loadPeriodContext loads period context of the period hash in block header,
+// and zero hash refers to the genesis one.
+func (dpos *Dpos) loadPeriodContext(hash *crypto.HashType) (*PeriodContext, error) {
+
+	if *hash == (crypto.HashType{}) {
+		return InitPeriodContext()
+	}
+	context, err := dpos.chain.DB().Get(chain.PeriodContextKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if context == nil {
+		return nil, ErrPeriodContextNotFound
+	}
+	periodContext := new(PeriodContext)
+	if err := periodContext.Unmarshal(context); err != nil {
+		return nil, err
+	}
+	return periodContext, nil
+}
+
+func (dpos *Dpos) activePeriodContext() *PeriodContext {
+	dpos.periodLock.RLock()
+	defer dpos.periodLock.RUnlock()
+	return dpos.context.periodContext
+}
+
+func (dpos *Dpos) setPeriodContext(periodContext *PeriodContext) {
+	dpos.periodLock.Lock()
+	dpos.context.periodContext = periodContext
+	dpos.periodLock.Unlock()
 }
 
 // LoadCandidates load candidates info.
 func (dpos *Dpos) LoadCandidates() error {
 
 	tail := dpos.chain.TailBlock()
-	db := dpos.chain.DB()
-
-	candidates, err := db.Get(tail.Header.CandidatesHash[:])
+	candidatesContext, err := dpos.loadCandidateContext(tail.BlockHash())
 	if err != nil {
 		return err
 	}
-	if candidates != nil {
-		candidatesContext := new(CandidateContext)
-		if err := candidatesContext.Unmarshal(candidates); err != nil {
-			return err
-		}
-		candidatesContext.height = tail.Height + 1
-		dpos.context.candidateContext = candidatesContext
-		return nil
-	}
-
-	candidatesContext := InitCandidateContext()
+	candidatesContext.height = tail.Height + 1
 	dpos.context.candidateContext = candidatesContext
 	return nil
 }
 
-// StoreCandidateContext store candidate context
-func (dpos *Dpos) StoreCandidateContext(hash *crypto.HashType) error {
-	if dpos.context.candidateContext == nil {
-		if err := dpos.LoadCandidates(); err != nil {
-			return err
+// Candidates returns candidates not withdrawn as of tail, ordered by votes, and
+// ties are broken by address.
+func (dpos *Dpos) Candidates() ([]*Candidate, error) {
+
+	candidateContext, err := dpos.loadCandidateContext(dpos.chain.TailBlock().BlockHash())
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*Candidate, 0, len(candidateContext.candidates))
+	for _, v := range candidateContext.candidates {
+		if !v.withdrawn {
+			candidates = append(candidates, v)
 		}
 	}
-	bytes, err := dpos.context.candidateContext.Marshal()
+	sortByVotes(candidates)
+	return candidates, nil
+}
+
+// LoadSnapshotContext loads period context and candidate context as of tail,
+// which are installed along with a utxo snapshot.
+func (dpos *Dpos) LoadSnapshotContext() error {
+
+	period, err := dpos.LoadPeriodContext()
 	if err != nil {
 		return err
 	}
-	db := dpos.chain.DB()
-	return db.Put(chain.CandidatesKey(hash), bytes)
+	dpos.setPeriodContext(period)
+	if err := dpos.StorePeriodContext(); err != nil {
+		return err
+	}
+	return dpos.LoadCandidates()
 }
 
-// prepareCandidateContext prepare to update CandidateContext.
-func (dpos *Dpos) prepareCandidateContext(tx *types.Transaction) error {
+// loadCandidateContext loads candidate context as of the block hash.
+func (dpos *Dpos) loadCandidateContext(hash *crypto.HashType) (*CandidateContext, error) {
 
-	if tx.Data == nil {
-		return nil
+	candidates, err := dpos.chain.DB().Get(chain.CandidatesKey(hash))
+	if err != nil {
+		return nil, err
 	}
-	content := tx.Data.Content
-	candidateContext := dpos.context.candidateContext
-	switch int(tx.Data.Type) {
-	case types.RegisterCandidateTx:
-		signUpContent := new(types.SignUpContent)
-		if err := signUpContent.Unmarshal(content); err != nil {
+	if candidates == nil {
+		return InitCandidateContext(), nil
+	}
+	candidatesContext := new(CandidateContext)
+	if err := candidatesContext.Unmarshal(candidates); err != nil {
+		return nil, err
+	}
+	return candidatesContext, nil
+}
+
+// StoreCandidateContext store candidate context as of block with batch, which is
+// derived from the one as of its parent with register, vote and unvote txs in block,
+// where utxos contains utxos spent by the block.
+func (dpos *Dpos) StoreCandidateContext(block *types.Block, utxos map[types.OutPoint]*types.UtxoWrap,
+	batch storage.Batch) error {
+
+	candidateContext, err := dpos.loadCandidateContext(&block.Header.PrevBlockHash)
+	if err != nil {
+		return err
+	}
+	for _, tx := range block.Txs {
+		if err := candidateContext.applyTx(tx, utxos, dpos.loadPeriodContext); err != nil {
 			return err
 		}
-		if util.InArray(signUpContent.Addr(), candidateContext.addrs) {
-			return ErrDuplicateSignUpTx
-		}
-		candidate := &Candidate{
-			addr:  signUpContent.Addr(),
-			votes: 0,
-		}
-		candidateContext.candidates = append(candidateContext.candidates, candidate)
-	case types.VoteTx:
-		votesContent := new(types.VoteContent)
-		if err := votesContent.Unmarshal(content); err != nil {
+		// outputs may be spent by later txs in the same block
+		txHash, err := tx.TxHash()
+		if err != nil {
 			return err
 		}
-		if !util.InArray(votesContent.Addr(), dpos.context.candidateContext.addrs) {
-			return ErrCandidateNotFound
-		}
-		for _, v := range candidateContext.candidates {
-			if v.addr == votesContent.Addr() {
-				atomic.AddInt64(&v.votes, votesContent.Votes())
+		for idx, txOut := range tx.Vout {
+			utxos[types.OutPoint{Hash: *txHash, Index: uint32(idx)}] = &types.UtxoWrap{
+				Output:      txOut,
+				BlockHeight: block.Height,
 			}
 		}
-	default:
 	}
+	candidateContext.height = block.Height
+	bytes, err := candidateContext.Marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(chain.CandidatesKey(block.BlockHash()), bytes)
 	return nil
 }
 
+// VerifyCandidateTx checks a register, unregister, update candidate, vote or
+// unvote tx against candidates as of tail, where utxos contains utxos spent by
+// tx, so that a tx which cannot be applied, e.g., a vote for a candidate not
+// registered or an unvote of forfeited stake, is rejected when it enters txpool
+// rather than left there and skipped in every block packed.
+func (dpos *Dpos) VerifyCandidateTx(tx *types.Transaction, utxos map[types.OutPoint]*types.UtxoWrap) error {
+	if tx.Data == nil {
+		return nil
+	}
+	switch int(tx.Data.Type) {
+	case types.RegisterCandidateTx, types.UnregisterCandidateTx, types.UpdateCandidateTx,
+		types.VoteTx, types.UnvoteTx:
+	default:
+		return nil
+	}
+	// a copy loaded from db, which is free to be changed
+	candidateContext, err := dpos.loadCandidateContext(dpos.chain.TailBlock().BlockHash())
+	if err != nil {
+		return err
+	}
+	return candidateContext.applyTx(tx, utxos, dpos.loadPeriodContext)
+}
+
+// prepareCandidateContext prepare to update CandidateContext.
+func (dpos *Dpos) prepareCandidateContext(tx *types.Transaction,
+	utxos map[types.OutPoint]*types.UtxoWrap) error {
+	return dpos.context.candidateContext.applyTx(tx, utxos, dpos.loadPeriodContext)
+}
+
 func (dpos *Dpos) signBlock(block *types.Block) error {
 
-	hash := block.BlockHash()
-	signature, err := crypto.SignCompact(dpos.miner.PrivateKey(), hash[:])
+	signature, err := dpos.signer.SignBlock(block.Height, block.Header)
 	if err != nil {
 		return err
 	}
@@ -475,58 +725,138 @@ func (dpos *Dpos) signBlock(block *types.Block) error {
 	return nil
 }
 
-// VerifyMinerEpoch verifies miner epoch.
+// VerifyMinerEpoch verifies miner epoch of block against its ancestors, which
+// are on main chain or a side chain.
 func (dpos *Dpos) VerifyMinerEpoch(block *types.Block) error {
 
-	tail := dpos.chain.TailBlock()
-	miner, err := dpos.context.periodContext.FindMinerWithTimeStamp(block.Header.TimeStamp)
-	if err != nil {
-		return err
+	prevHeaders := make([]*types.BlockHeader, 0, 2*PeriodSize/3)
+	for hash := block.Header.PrevBlockHash; len(prevHeaders) < 2*PeriodSize/3; {
+		prev, err := dpos.chain.FindBlock(hash)
+		if err != nil {
+			return err
+		}
+		if prev.Height == 0 {
+			break
+		}
+		prevHeaders = append(prevHeaders, prev.Header)
+		hash = prev.Header.PrevBlockHash
 	}
+	return dpos.VerifyMinerEpochWithHeaders(block.Header, prevHeaders)
+}
 
-	for idx := 0; idx < 2*PeriodSize/3; {
-		height := tail.Height - uint32(idx)
-		if height == 0 {
-			break
+// VerifyMinerEpochWithHeaders verifies miner epoch of header against prevHeaders,
+// which are the latest headers before it, latest first, excluding genesis.
+// Unlike VerifyMinerEpoch, the headers need not be on chain, e.g., headers in sync,
+// but the parent of the earliest one must be on chain. Each header is scheduled by the
+// period its parent commits to, so they may span a period boundary.
+func (dpos *Dpos) VerifyMinerEpochWithHeaders(header *types.BlockHeader, prevHeaders []*types.BlockHeader) error {
+
+	headers := append([]*types.BlockHeader{header}, prevHeaders...)
+	if len(headers) > 2*PeriodSize/3+1 {
+		headers = headers[:2*PeriodSize/3+1]
+	}
+	periodContexts := make(map[crypto.HashType]*PeriodContext)
+	miners := make([]*types.AddressHash, len(headers))
+	for idx, h := range headers {
+		var parentPeriodHash crypto.HashType
+		if idx+1 < len(headers) {
+			parentPeriodHash = headers[idx+1].PeriodHash
+		} else {
+			parent, err := dpos.chain.FindBlock(h.PrevBlockHash)
+			if err != nil {
+				return err
+			}
+			parentPeriodHash = parent.Header.PeriodHash
 		}
-		block, err := dpos.chain.LoadBlockByHeight(height)
-		if err != nil {
-			return err
+		periodContext, ok := periodContexts[parentPeriodHash]
+		if !ok {
+			var err error
+			if periodContext, err = dpos.loadPeriodContext(&parentPeriodHash); err != nil {
+				return err
+			}
+			periodContexts[parentPeriodHash] = periodContext
 		}
-		target, err := dpos.context.periodContext.FindMinerWithTimeStamp(block.Header.TimeStamp)
+		miner, err := periodContext.FindMinerWithTimeStamp(h.TimeStamp)
 		if err != nil {
 			return err
 		}
-		if target == miner {
+		miners[idx] = miner
+	}
+
+	for _, target := range miners[1:] {
+		if *target == *miners[0] {
 			return ErrInvalidMinerEpoch
 		}
-		idx++
 	}
 	return nil
 }
 
+// producerPeriodContext returns the period context scheduling producer of block,
+// which is the one its parent commits to. The period hash of a block within a
+// period is the same as its parent's, so only the parent of one at the beginning
+// of a period has to be on main chain or a side chain.
+func (dpos *Dpos) producerPeriodContext(block *types.Block) (*PeriodContext, error) {
+
+	periodHash := block.Header.PeriodHash
+	if block.Height > 0 && block.Height%chain.PeriodDuration == 0 {
+		parent, err := dpos.chain.FindBlock(block.Header.PrevBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		periodHash = parent.Header.PeriodHash
+	}
+	return dpos.loadPeriodContext(&periodHash)
+}
+
 // VerifySign consensus verifies signature info.
 func (dpos *Dpos) VerifySign(block *types.Block) (bool, error) {
 
-	miner, err := dpos.context.periodContext.FindMinerWithTimeStamp(block.Header.TimeStamp)
+	periodContext, err := dpos.producerPeriodContext(block)
 	if err != nil {
 		return false, err
 	}
+	miner, err := verifyProducerSign(block, periodContext)
+	if err != nil || miner == nil {
+		return false, err
+	}
+	if evidence := dpos.detectEquivocation(block, *miner); evidence != nil {
+		dpos.addEvidence(evidence, *miner)
+	}
+	return true, nil
+}
+
+// VerifySignWithPeriod verifies signature of block against periodContext, the
+// period scheduling it, without local period contexts, e.g., blocks of a utxo
+// snapshot synced by a node having no blocks.
+func VerifySignWithPeriod(block *types.Block, periodContext *PeriodContext) (bool, error) {
+
+	miner, err := verifyProducerSign(block, periodContext)
+	return miner != nil, err
+}
+
+// verifyProducerSign returns the producer scheduled in periodContext if it signs
+// block in its time slot, or nil.
+func verifyProducerSign(block *types.Block, periodContext *PeriodContext) (*types.AddressHash, error) {
+
+	miner, err := periodContext.FindMinerWithTimeStamp(block.Header.TimeStamp)
+	if err != nil {
+		return nil, err
+	}
 	if miner == nil {
-		return false, ErrNotFoundMiner
+		return nil, ErrNotFoundMiner
 	}
 
 	if pubkey, ok := crypto.RecoverCompact(block.BlockHash()[:], block.Signature); ok {
 		addr, err := types.NewAddressFromPubKey(pubkey)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		if *addr.Hash160() == *miner {
-			return true, nil
+			return miner, nil
 		}
 	}
 
-	return false, nil
+	return nil, nil
 }
 
 // func (dpos *Dpos) buildMinerEpoch() error {
@@ -5,15 +5,21 @@
 package dpos
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/BOXFoundation/boxd/boxd/eventbus"
 	"github.com/BOXFoundation/boxd/core/chain"
+	corepb "github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/core/txpool"
 	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/p2p"
+	"github.com/BOXFoundation/boxd/script"
 	_ "github.com/BOXFoundation/boxd/storage/memdb"
+	"github.com/BOXFoundation/boxd/wallet"
 	"github.com/facebookgo/ensure"
 )
 
@@ -43,7 +49,7 @@ var (
 func NewDummyDpos(cfg *Config) *DummyDpos {
 
 	blockchain := chain.NewTestBlockChain()
-	txPool := txpool.NewTransactionPool(blockchain.Proc(), p2p.NewDummyPeer(), blockchain, bus)
+	txPool := txpool.NewTransactionPool(blockchain.Proc(), p2p.NewDummyPeer(), blockchain, bus, &txpool.Config{})
 	dpos, _ := NewDpos(txPool.Proc(), blockchain, txPool, p2p.NewDummyPeer(), cfg)
 	blockchain.Setup(dpos, nil)
 	dpos.Setup()
@@ -125,6 +131,16 @@ func TestDpos_signBlock(t *testing.T) {
 	ok, err = dposMiner.dpos.VerifySign(block)
 	ensure.Nil(t, err)
 	ensure.DeepEqual(t, ok, true)
+
+	// verified against a given period without local contexts
+	genesis, err := InitPeriodContext()
+	ensure.Nil(t, err)
+	ok, err = VerifySignWithPeriod(block, genesis)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, ok, true)
+	other := newPeriodContext(genesis.period[1:], nil)
+	ok, _ = VerifySignWithPeriod(block, other)
+	ensure.DeepEqual(t, ok, false)
 }
 
 func TestDpos_LoadPeriodContext(t *testing.T) {
@@ -149,3 +165,580 @@ func TestDpos_LoadPeriodContext(t *testing.T) {
 	ensure.DeepEqual(t, result.period, dposMiner.dpos.context.periodContext.period)
 
 }
+
+func newCandidateTx(txType int, content interface{ Marshal() ([]byte, error) }) *types.Transaction {
+	data, _ := content.Marshal()
+	tx := types.NewTransaction(*types.NewOutPoint(crypto.HashType{}), 1, 0)
+	tx.Data = &corepb.Data{Type: int32(txType), Content: data}
+	return tx
+}
+
+func newVoteTx(candidate types.AddressHash, value uint64) *types.Transaction {
+	tx := newCandidateTx(types.VoteTx, types.NewVoteContent(candidate))
+	tx.Vout[0].Value = value
+	tx.Vout[0].ScriptPubKey = *script.VoteScript(candidate[:], candidate)
+	return tx
+}
+
+func TestCandidateContext_applyTx(t *testing.T) {
+
+	candidateContext := InitCandidateContext()
+	candidate := types.AddressHash{0x01}
+	tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(candidate, "peer"))
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+
+	// votes are coins locked in vote outputs
+	voteTx := newVoteTx(candidate, 100)
+	voteTx.Vout = append(voteTx.Vout, &corepb.TxOut{Value: 50, ScriptPubKey: candidate[:]})
+	ensure.Nil(t, candidateContext.applyTx(voteTx, nil, nil))
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).votes, int64(100))
+
+	// votes are withdrawn when vote outputs are spent by unvote tx
+	voteTxHash, _ := voteTx.TxHash()
+	utxos := make(map[types.OutPoint]*types.UtxoWrap)
+	for idx, txOut := range voteTx.Vout {
+		utxos[types.OutPoint{Hash: *voteTxHash, Index: uint32(idx)}] = &types.UtxoWrap{Output: txOut}
+	}
+	unvoteTx := types.NewTransaction(types.OutPoint{Hash: *voteTxHash, Index: 0}, 1, 0)
+	unvoteTx.Vin = append(unvoteTx.Vin, &types.TxIn{PrevOutPoint: types.OutPoint{Hash: *voteTxHash, Index: 1}})
+	unvoteTx.Data = &corepb.Data{Type: types.UnvoteTx}
+	ensure.DeepEqual(t, candidateContext.Copy().applyTx(unvoteTx, nil, nil), ErrVoteOutputNotFound)
+	ensure.Nil(t, candidateContext.applyTx(unvoteTx, utxos, nil))
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).votes, int64(0))
+}
+
+func TestDpos_VerifyCandidateTx(t *testing.T) {
+
+	// votes for candidates not registered are rejected
+	ensure.DeepEqual(t, dpos.dpos.VerifyCandidateTx(newVoteTx(types.AddressHash{0x0f}, 100), nil), ErrCandidateNotFound)
+	tx := newCandidateTx(types.UnregisterCandidateTx, types.NewUnregisterContent(types.AddressHash{0x0f}))
+	ensure.DeepEqual(t, dpos.dpos.VerifyCandidateTx(tx, nil), ErrCandidateNotFound)
+
+	// unvotes are checked against vote outputs spent
+	tx = types.NewTransaction(types.OutPoint{Hash: crypto.HashType{0x0f}}, 1, 0)
+	tx.Data = &corepb.Data{Type: types.UnvoteTx}
+	ensure.DeepEqual(t, dpos.dpos.VerifyCandidateTx(tx, nil), ErrVoteOutputNotFound)
+
+	tx = newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(types.AddressHash{0x0f}, "peer"))
+	ensure.Nil(t, dpos.dpos.VerifyCandidateTx(tx, nil))
+	ensure.Nil(t, dpos.dpos.VerifyCandidateTx(types.NewTransaction(types.OutPoint{}, 1, 0), nil))
+}
+
+func newEquivocation(t *testing.T) (*types.Block, *types.Block) {
+	a := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824620}}
+	b := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824620, TxsRoot: crypto.HashType{0x01}}}
+	ensure.Nil(t, dposMiner.dpos.signBlock(a))
+	ensure.Nil(t, dposMiner.dpos.signBlock(b))
+	return a, b
+}
+
+func TestEvidence_Verify(t *testing.T) {
+
+	a, b := newEquivocation(t)
+	offender := types.AddressHash{}
+	copy(offender[:], dposMiner.dpos.signer.PubKeyHash())
+
+	evidence := newEvidence(a, b)
+	addr, err := evidence.Verify(dposMiner.dpos.loadPeriodContext)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, *addr, offender)
+	ensure.DeepEqual(t, newEvidence(b, a), evidence)
+
+	// evidence survives serialization
+	data, err := evidence.Marshal()
+	ensure.Nil(t, err)
+	restored := new(Evidence)
+	ensure.Nil(t, restored.Unmarshal(data))
+	addr, err = restored.Verify(dposMiner.dpos.loadPeriodContext)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, *addr, offender)
+
+	_, err = newEvidence(a, a).Verify(dposMiner.dpos.loadPeriodContext)
+	ensure.DeepEqual(t, err, ErrInvalidEvidence)
+	c := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824625}, Signature: b.Signature}
+	_, err = newEvidence(a, c).Verify(dposMiner.dpos.loadPeriodContext)
+	ensure.DeepEqual(t, err, ErrInvalidEvidence)
+
+	// nor are conflicting blocks signed out of the miner's slots
+	c.Signature = nil
+	ensure.Nil(t, dposMiner.dpos.signBlock(c))
+	d := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824625, TxsRoot: crypto.HashType{0x01}}}
+	ensure.Nil(t, dposMiner.dpos.signBlock(d))
+	_, err = newEvidence(c, d).Verify(dposMiner.dpos.loadPeriodContext)
+	ensure.DeepEqual(t, err, ErrInvalidEvidence)
+
+	// equivocation is detected as signed blocks are verified
+	dposMiner.dpos.signedHeaders.Purge()
+	dposMiner.dpos.evidences = new(sync.Map)
+	ok, err := dposMiner.dpos.VerifySign(a)
+	ensure.Nil(t, err)
+	ensure.True(t, ok)
+	_, found := dposMiner.dpos.evidences.Load(offender)
+	ensure.False(t, found)
+	ok, err = dposMiner.dpos.VerifySign(b)
+	ensure.Nil(t, err)
+	ensure.True(t, ok)
+	_, found = dposMiner.dpos.evidences.Load(offender)
+	ensure.True(t, found)
+}
+
+func TestCandidateContext_slash(t *testing.T) {
+
+	a, b := newEquivocation(t)
+	offender := types.AddressHash{}
+	copy(offender[:], dposMiner.dpos.signer.PubKeyHash())
+
+	candidateContext := InitCandidateContext()
+	tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(offender, "peer"))
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	voteTx := newVoteTx(offender, 100)
+	ensure.Nil(t, candidateContext.applyTx(voteTx, nil, nil))
+
+	evidenceTx, err := newEvidenceTx(newEvidence(a, b))
+	ensure.Nil(t, err)
+	ensure.Nil(t, candidateContext.applyTx(evidenceTx, nil, dposMiner.dpos.loadPeriodContext))
+	ensure.True(t, candidateContext.candidate(offender) == nil)
+	ensure.DeepEqual(t, candidateContext.applyTx(evidenceTx, nil, dposMiner.dpos.loadPeriodContext), ErrDuplicateEvidence)
+
+	// slashed candidate may not sign up again
+	ensure.DeepEqual(t, candidateContext.applyTx(tx, nil, nil), ErrCandidateSlashed)
+
+	// and its own stake is forfeited
+	voteTxHash, _ := voteTx.TxHash()
+	outPoint := types.OutPoint{Hash: *voteTxHash, Index: 0}
+	utxos := map[types.OutPoint]*types.UtxoWrap{outPoint: {Output: voteTx.Vout[0]}}
+	unvoteTx := types.NewTransaction(outPoint, 1, 0)
+	unvoteTx.Data = &corepb.Data{Type: types.UnvoteTx}
+	ensure.DeepEqual(t, candidateContext.applyTx(unvoteTx, utxos, nil), ErrStakeForfeited)
+
+	// including votes it owns for other candidates, unlike votes of others for it
+	other := types.AddressHash{0x01}
+	ensure.Nil(t, candidateContext.applyTx(newCandidateTx(types.RegisterCandidateTx,
+		types.NewSignUpContent(other, "other")), nil, nil))
+	ownedVoteTx := newVoteTx(other, 100)
+	ownedVoteTx.Vout[0].ScriptPubKey = *script.VoteScript(offender[:], other)
+	receivedVoteTx := newVoteTx(offender, 50)
+	receivedVoteTx.Vout[0].ScriptPubKey = *script.VoteScript(other[:], offender)
+	unvote := func(voteTx *types.Transaction) *types.Transaction {
+		hash, _ := voteTx.TxHash()
+		outPoint := types.OutPoint{Hash: *hash, Index: 0}
+		utxos[outPoint] = &types.UtxoWrap{Output: voteTx.Vout[0]}
+		tx := types.NewTransaction(outPoint, 1, 0)
+		tx.Data = &corepb.Data{Type: types.UnvoteTx}
+		return tx
+	}
+	ensure.DeepEqual(t, candidateContext.Copy().applyTx(unvote(ownedVoteTx), utxos, nil), ErrStakeForfeited)
+	ensure.Nil(t, candidateContext.Copy().applyTx(unvote(receivedVoteTx), utxos, nil))
+
+	// slashing survives serialization
+	data, err := candidateContext.Marshal()
+	ensure.Nil(t, err)
+	restored := new(CandidateContext)
+	ensure.Nil(t, restored.Unmarshal(data))
+	ensure.DeepEqual(t, restored.slashed, []types.AddressHash{offender})
+	ensure.DeepEqual(t, restored.Copy().applyTx(evidenceTx, nil, dposMiner.dpos.loadPeriodContext), ErrDuplicateEvidence)
+}
+
+func TestCandidateContext_electPeriod(t *testing.T) {
+
+	candidateContext := InitCandidateContext()
+	addrs := make([]types.AddressHash, PeriodSize+1)
+	for i := range addrs {
+		addrs[i] = types.AddressHash{byte(PeriodSize - i)}
+		tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(addrs[i], "peer"))
+		ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	}
+	tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(addrs[0], "peer"))
+	ensure.DeepEqual(t, candidateContext.applyTx(tx, nil, nil), ErrDuplicateSignUpTx)
+	tx = newVoteTx(types.AddressHash{0xff}, 1)
+	ensure.DeepEqual(t, candidateContext.applyTx(tx, nil, nil), ErrCandidateNotFound)
+
+	// not enough candidates with votes
+	for i := 0; i < PeriodSize-1; i++ {
+		tx := newVoteTx(addrs[i], 10)
+		ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	}
+	ensure.True(t, candidateContext.electPeriod() == nil)
+
+	// ties are broken by address
+	tx = newVoteTx(addrs[PeriodSize-1], 5)
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	tx = newVoteTx(addrs[PeriodSize], 5)
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	tx = newVoteTx(addrs[0], 1)
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+
+	periods := candidateContext.electPeriod()
+	ensure.DeepEqual(t, len(periods), PeriodSize)
+	ensure.DeepEqual(t, periods[0].addr, addrs[0])
+	for i := 1; i < PeriodSize-1; i++ {
+		ensure.DeepEqual(t, periods[i].addr, addrs[PeriodSize-1-i])
+	}
+	ensure.DeepEqual(t, periods[PeriodSize-1].addr, addrs[PeriodSize])
+	ensure.DeepEqual(t, periods[0].peerID, "peer")
+
+	// candidate context survives serialization
+	data, err := candidateContext.Marshal()
+	ensure.Nil(t, err)
+	restored := new(CandidateContext)
+	ensure.Nil(t, restored.Unmarshal(data))
+	ensure.DeepEqual(t, restored.electPeriod(), periods)
+}
+
+func TestCandidateContext_unregister(t *testing.T) {
+
+	candidateContext := InitCandidateContext()
+	candidate := types.AddressHash{0x01}
+	tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(candidate, "peer"))
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	voteTx := newVoteTx(candidate, 100)
+	ensure.Nil(t, candidateContext.applyTx(voteTx, nil, nil))
+
+	// candidate publishes its info
+	updateTx := newCandidateTx(types.UpdateCandidateTx,
+		types.NewCandidateInfoContent(candidate, "peer1", "127.0.0.1:19199", "candidate"))
+	ensure.Nil(t, candidateContext.applyTx(updateTx, nil, nil))
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).PeerID(), "peer1")
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).Endpoint(), "127.0.0.1:19199")
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).Description(), "candidate")
+	periods := candidateContext.withPeers([]*Period{{addr: candidate, peerID: "genesis"}})
+	ensure.DeepEqual(t, periods[0].peerID, "peer1")
+
+	// withdrawn candidate takes no votes or updates
+	unregisterTx := newCandidateTx(types.UnregisterCandidateTx, types.NewUnregisterContent(candidate))
+	ensure.Nil(t, candidateContext.applyTx(unregisterTx, nil, nil))
+	ensure.DeepEqual(t, candidateContext.applyTx(unregisterTx, nil, nil), ErrCandidateNotFound)
+	ensure.DeepEqual(t, candidateContext.applyTx(updateTx, nil, nil), ErrCandidateNotFound)
+	ensure.DeepEqual(t, candidateContext.applyTx(voteTx, nil, nil), ErrCandidateNotFound)
+
+	// withdrawal survives serialization
+	data, err := candidateContext.Marshal()
+	ensure.Nil(t, err)
+	restored := new(CandidateContext)
+	ensure.Nil(t, restored.Unmarshal(data))
+	ensure.DeepEqual(t, restored, candidateContext)
+
+	// and it signs up again with votes still locked for it
+	ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	ensure.DeepEqual(t, candidateContext.candidate(candidate).Votes(), int64(100))
+	ensure.DeepEqual(t, candidateContext.applyTx(tx, nil, nil), ErrDuplicateSignUpTx)
+}
+
+func TestPeriodContext_nextPeriodContext(t *testing.T) {
+
+	genesis, err := InitPeriodContext()
+	ensure.Nil(t, err)
+	candidateContext := InitCandidateContext()
+
+	// producers are unchanged without election result
+	periodContext := genesis.nextPeriodContext(candidateContext)
+	ensure.DeepEqual(t, periodContext.period, genesis.period)
+	ensure.DeepEqual(t, len(periodContext.nextPeriod), 0)
+
+	for i := 0; i < PeriodSize; i++ {
+		addr := types.AddressHash{byte(i + 1)}
+		tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(addr, "peer"))
+		ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+		tx = newVoteTx(addr, uint64(i+1))
+		ensure.Nil(t, candidateContext.applyTx(tx, nil, nil))
+	}
+	elected := candidateContext.electPeriod()
+
+	// elected producers take effect in the period after next
+	periodContext = periodContext.nextPeriodContext(candidateContext)
+	ensure.DeepEqual(t, periodContext.period, genesis.period)
+	ensure.DeepEqual(t, periodContext.nextPeriod, elected)
+	hash, err := periodContext.PeriodContextHash()
+	ensure.Nil(t, err)
+
+	data, err := periodContext.Marshal()
+	ensure.Nil(t, err)
+	restored := new(PeriodContext)
+	ensure.Nil(t, restored.Unmarshal(data))
+	restoredHash, err := restored.PeriodContextHash()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, restoredHash, hash)
+
+	periodContext = restored.nextPeriodContext(candidateContext)
+	ensure.DeepEqual(t, periodContext.period, elected)
+	ensure.DeepEqual(t, periodContext.periodAddrs[0], elected[0].addr)
+	ensure.DeepEqual(t, periodContext.periodPeers[0], "peer")
+	miner, err := periodContext.FindMinerWithTimeStamp(1541824620)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, *miner, elected[0].addr)
+}
+
+func TestSelectTxs(t *testing.T) {
+	newTxWrap := func(prevOutPoint *types.OutPoint, fee uint64) *chain.TxWrap {
+		tx := types.NewTransaction(*prevOutPoint, 1, 0)
+		size, _ := tx.SerializeSize()
+		return &chain.TxWrap{Tx: tx, Fee: fee, FeePerKB: fee * 1000 / uint64(size)}
+	}
+	// parent pays little, but its child pays a lot
+	parent := newTxWrap(types.NewOutPoint(crypto.HashType{0x01}), 100)
+	parentHash, _ := parent.Tx.TxHash()
+	child := newTxWrap(types.NewOutPoint(*parentHash), 10000)
+	other := newTxWrap(types.NewOutPoint(crypto.HashType{0x02}), 1000)
+	pendingTxs := []*chain.TxWrap{other, child, parent}
+	packAll := func(*chain.TxWrap) bool { return true }
+
+	// child pays for parent
+	selected := selectTxs(pendingTxs, chain.MaxBlockSize, chain.MaxTxsPerBlock, nil, packAll)
+	ensure.DeepEqual(t, selected, []*chain.TxWrap{parent, child, other})
+
+	// the package of parent and child does not fit
+	selected = selectTxs(pendingTxs, chain.MaxBlockSize, 1, nil, packAll)
+	ensure.DeepEqual(t, selected, []*chain.TxWrap{other})
+
+	// only one tx fits in max size
+	otherSize, _ := other.Tx.SerializeSize()
+	selected = selectTxs(pendingTxs, otherSize+txEncodingOverhead, chain.MaxTxsPerBlock, nil, packAll)
+	ensure.DeepEqual(t, selected, []*chain.TxWrap{other})
+
+	// child is skipped if parent cannot be packed
+	selected = selectTxs(pendingTxs, chain.MaxBlockSize, chain.MaxTxsPerBlock, nil, func(txWrap *chain.TxWrap) bool {
+		return txWrap != parent
+	})
+	ensure.DeepEqual(t, selected, []*chain.TxWrap{other})
+}
+
+func TestDevGenesis(t *testing.T) {
+	devCfg := *cfgMiner
+	devCfg.Dev = true
+	devCfg.DevAccounts = []string{"b1b8bzyci5VYUJVKRU2HRMMQiUXnoULkKAJ"}
+	genesis, err := DevGenesis(&devCfg)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(genesis.Validators), 1)
+	ensure.DeepEqual(t, genesis.Validators[0].Addr, dposMiner.dpos.signer.Addr())
+	ensure.DeepEqual(t, len(genesis.Allocations), 2)
+	ensure.DeepEqual(t, genesis.Allocations[1].Value, DevAllocation)
+	_, err = genesis.Block()
+	ensure.Nil(t, err)
+
+	// blocks are only generated on demand in dev mode
+	_, err = dposMiner.dpos.GenerateBlocks(1)
+	ensure.DeepEqual(t, err, ErrNotDevMode)
+}
+
+func TestDpos_GenerateBlocksLimit(t *testing.T) {
+
+	// blocks in a burst take a second each, up to MaxTimeOffsetSeconds ahead
+	now := int64(1541824620)
+	ensure.DeepEqual(t, maxBlocksToGenerate(now, now-100), uint32(chain.MaxTimeOffsetSeconds+1))
+	ensure.DeepEqual(t, maxBlocksToGenerate(now, now), uint32(chain.MaxTimeOffsetSeconds))
+	ensure.DeepEqual(t, maxBlocksToGenerate(now, now+chain.MaxTimeOffsetSeconds-1), uint32(1))
+	ensure.DeepEqual(t, maxBlocksToGenerate(now, now+chain.MaxTimeOffsetSeconds), uint32(0))
+
+	// too many blocks are rejected before any is generated
+	devCfg := *cfgMiner
+	devCfg.Dev = true
+	dev := NewDummyDpos(&devCfg).dpos
+	tail := dev.chain.TailBlock()
+	hashes, err := dev.GenerateBlocks(chain.MaxTimeOffsetSeconds + 2)
+	ensure.DeepEqual(t, err, ErrTooManyBlocksRequested)
+	ensure.DeepEqual(t, len(hashes), 0)
+	ensure.DeepEqual(t, dev.chain.TailBlock(), tail)
+}
+
+func TestDpos_updateValidatorStats(t *testing.T) {
+
+	dpos := NewDummyDpos(cfgMiner).dpos
+	period := dpos.activePeriodContext().period
+	ensure.DeepEqual(t, period[0].addr[:], dpos.signer.PubKeyHash())
+
+	// the miner produces blocks in its slots a round apart, and others miss theirs
+	parent := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824620}, Height: 1}
+	ensure.Nil(t, dpos.signBlock(parent))
+	ensure.Nil(t, dpos.chain.StoreBlockToDb(parent))
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			PrevBlockHash: *parent.BlockHash(),
+			TimeStamp:     parent.Header.TimeStamp + NewBlockTimeInterval*int64(len(period))/SecondInMs,
+		},
+		Height: 2,
+	}
+	ensure.Nil(t, dpos.signBlock(block))
+
+	ensure.Nil(t, dpos.updateValidatorStats(block, true))
+	validators, err := dpos.Validators()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(validators), len(period))
+	ensure.DeepEqual(t, *validators[0], ValidatorStats{Addr: period[0].addr, Produced: 1, Active: true})
+	for i, stats := range validators[1:] {
+		ensure.DeepEqual(t, *stats, ValidatorStats{Addr: period[i+1].addr, Missed: 1, Active: true})
+	}
+
+	// disconnecting the block undoes it
+	ensure.Nil(t, dpos.updateValidatorStats(block, false))
+	validators, err = dpos.Validators()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, *validators[0], ValidatorStats{Addr: period[0].addr, Reverted: 1, Active: true})
+	ensure.DeepEqual(t, validators[1].Missed, uint64(0))
+
+	ensure.Nil(t, dpos.recordEternal(0, parent))
+	stats, err := dpos.loadValidatorStats(&period[0].addr)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, stats.Confirmed, uint64(1))
+	data, err := stats.Marshal()
+	ensure.Nil(t, err)
+	restored := new(ValidatorStats)
+	ensure.Nil(t, restored.Unmarshal(data))
+	ensure.DeepEqual(t, restored, stats)
+}
+
+func TestFinalityCertificate_Verify(t *testing.T) {
+
+	dpos := NewDummyDpos(cfgMiner).dpos
+	block := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824620}, Height: 1}
+	ensure.Nil(t, dpos.chain.StoreBlockToDb(block))
+
+	// producers of the genesis period confirm the block
+	var msgs []*EternalBlockMsg
+	for i := 1; i <= 5; i++ {
+		account, err := wallet.NewAccountFromFile(fmt.Sprintf("../../keyfile/key%d.keystore", i))
+		ensure.Nil(t, err)
+		ensure.Nil(t, account.UnlockWithPassphrase("1"))
+		signature, err := (&localSigner{account}).SignConfirmation(block.Header.TimeStamp, block.BlockHash())
+		ensure.Nil(t, err)
+		msgs = append(msgs, &EternalBlockMsg{hash: *block.BlockHash(), signature: signature})
+	}
+	ensure.Nil(t, dpos.storeFinalityCertificate(block.BlockHash(), msgs))
+	cert, err := dpos.FinalityCertificate(nil, block.Height)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(cert.Signatures), 5)
+	genesis, err := GenesisPeriodHash()
+	ensure.Nil(t, err)
+	ensure.Nil(t, cert.Verify(genesis))
+
+	// signatures below the quorum, or repeated, do not prove finality
+	signatures := cert.Signatures
+	cert.Signatures = signatures[:4]
+	ensure.DeepEqual(t, cert.Verify(genesis), ErrInvalidFinalityCertificate)
+	cert.Signatures = append(signatures[:4:4], signatures[0])
+	ensure.DeepEqual(t, cert.Verify(genesis), ErrInvalidFinalityCertificate)
+
+	// nor block signatures on the bare block hash
+	cert.Signatures = nil
+	for i := 1; i <= 5; i++ {
+		account, err := wallet.NewAccountFromFile(fmt.Sprintf("../../keyfile/key%d.keystore", i))
+		ensure.Nil(t, err)
+		ensure.Nil(t, account.UnlockWithPassphrase("1"))
+		signature, err := crypto.SignCompact(account.PrivateKey(), block.BlockHash()[:])
+		ensure.Nil(t, err)
+		cert.Signatures = append(cert.Signatures, signature)
+	}
+	ensure.DeepEqual(t, cert.Verify(genesis), ErrInvalidFinalityCertificate)
+
+	// nor a period made up, even if the header commits to it
+	cert.Signatures = signatures
+	period := newPeriodContext(cert.PeriodContext.period[:4], nil)
+	periodHash, err := period.PeriodContextHash()
+	ensure.Nil(t, err)
+	header := *cert.Header
+	header.PeriodHash = *periodHash
+	forged := &FinalityCertificate{Header: &header, Height: cert.Height, PeriodContext: period, Signatures: signatures}
+	ensure.DeepEqual(t, forged.Verify(genesis), ErrInvalidFinalityCertificate)
+	ensure.Nil(t, cert.Verify(genesis))
+
+	// blocks not made eternal by producers have no certificate
+	other := &types.Block{Header: &types.BlockHeader{TimeStamp: 1541824625}, Height: 2}
+	ensure.Nil(t, dpos.chain.StoreBlockToDb(other))
+	_, err = dpos.FinalityCertificate(other.BlockHash(), 0)
+	ensure.DeepEqual(t, err, ErrFinalityCertificateNotFound)
+}
+
+func TestCommittedPeriodContext(t *testing.T) {
+
+	genesis, err := InitPeriodContext()
+	ensure.Nil(t, err)
+	header := &types.BlockHeader{}
+	periodContext, err := CommittedPeriodContext(header, nil)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, periodContext.period, genesis.period)
+
+	next := newPeriodContext(genesis.period[1:], nil)
+	data, err := next.Marshal()
+	ensure.Nil(t, err)
+	_, err = CommittedPeriodContext(header, data)
+	ensure.DeepEqual(t, err, ErrInvalidPeriodHash)
+
+	header.PeriodHash = crypto.DoubleHashH(data)
+	periodContext, err = CommittedPeriodContext(header, data)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, periodContext.period, next.period)
+	_, err = CommittedPeriodContext(header, data[1:])
+	ensure.DeepEqual(t, err, ErrInvalidPeriodHash)
+}
+
+func TestDpos_VerifyAcrossPeriods(t *testing.T) {
+
+	dpos := NewDummyDpos(cfgMiner).dpos
+	genesis := dpos.activePeriodContext().period
+	n := len(genesis)
+	ensure.DeepEqual(t, genesis[0].addr[:], dpos.signer.PubKeyHash())
+
+	// producers of the next period are the genesis ones in reverse order
+	reversed := make([]*Period, n)
+	for i, period := range genesis {
+		reversed[n-1-i] = period
+	}
+	next := newPeriodContext(reversed, nil)
+	data, err := next.Marshal()
+	ensure.Nil(t, err)
+	nextHash, err := next.PeriodContextHash()
+	ensure.Nil(t, err)
+	ensure.Nil(t, dpos.chain.DB().Put(chain.PeriodContextKey(nextHash), data))
+
+	// the local chain ends at the period boundary, and a header batch begins a
+	// new period, whose first header is still scheduled by the genesis period
+	slot := NewBlockTimeInterval / SecondInMs
+	start := int64(1541824620)
+	grandparent := &types.Block{
+		Header: &types.BlockHeader{TimeStamp: start - slot},
+		Height: chain.PeriodDuration - 2,
+	}
+	ensure.Nil(t, dpos.chain.StoreBlockToDb(grandparent))
+	parent := &types.Block{
+		Header: &types.BlockHeader{PrevBlockHash: *grandparent.BlockHash(), TimeStamp: start + slot},
+		Height: chain.PeriodDuration - 1,
+	}
+	ensure.Nil(t, dpos.chain.StoreBlockToDb(parent))
+	first := &types.Block{
+		Header: &types.BlockHeader{
+			PrevBlockHash: *parent.BlockHash(),
+			TimeStamp:     start + int64(n)*slot,
+			PeriodHash:    *nextHash,
+		},
+		Height: chain.PeriodDuration,
+	}
+	ensure.Nil(t, dpos.signBlock(first))
+	ok, err := dpos.VerifySign(first)
+	ensure.Nil(t, err)
+	ensure.True(t, ok)
+	ensure.Nil(t, dpos.VerifyMinerEpochWithHeaders(first.Header, []*types.BlockHeader{parent.Header}))
+
+	// and the following ones by the next period, where the miner is the last
+	// producer, so it has to wait for its slot in the next period
+	second := &types.Block{
+		Header: &types.BlockHeader{
+			PrevBlockHash: *first.BlockHash(),
+			TimeStamp:     start + int64(2*n-1)*slot,
+			PeriodHash:    *nextHash,
+		},
+		Height: chain.PeriodDuration + 1,
+	}
+	ensure.Nil(t, dpos.signBlock(second))
+	ok, err = dpos.VerifySign(second)
+	ensure.Nil(t, err)
+	ensure.True(t, ok)
+	prevHeaders := []*types.BlockHeader{first.Header, parent.Header}
+	ensure.DeepEqual(t, dpos.VerifyMinerEpochWithHeaders(second.Header, prevHeaders), ErrInvalidMinerEpoch)
+
+	// a producer of the next period which produced none of the headers before
+	second.Header.TimeStamp = start + int64(n+1)*slot
+	second.Hash = nil
+	ensure.Nil(t, dpos.VerifyMinerEpochWithHeaders(second.Header, prevHeaders))
+	ok, err = dpos.VerifySign(second)
+	ensure.Nil(t, err)
+	ensure.False(t, ok)
+}
@@ -15,10 +15,24 @@ var (
 	ErrNotFoundMiner          = errors.New("Failed to find miner")
 	ErrDuplicateSignUpTx      = errors.New("Duplicate sign up tx")
 	ErrCandidateNotFound      = errors.New("Candidate not found")
+	ErrVoteOutputNotFound     = errors.New("Vote output spent by unvote tx not found")
 	ErrRepeatedMintAtSameTime = errors.New("Repeated mint at same time")
 	ErrFailedToVerifySign     = errors.New("Failed to verify sign block")
 	ErrNotMintPeer            = errors.New("Invalid mint peer")
 	ErrInvalidMinerEpoch      = errors.New("Invalid miner epoch")
+	ErrInvalidPeriodHash      = errors.New("Invalid period hash")
+	ErrPeriodContextNotFound  = errors.New("Period context not found")
+	ErrInvalidEvidence        = errors.New("Invalid equivocation evidence")
+	ErrDuplicateEvidence      = errors.New("Offender of evidence is already slashed")
+	ErrCandidateSlashed       = errors.New("Candidate is slashed")
+	ErrStakeForfeited         = errors.New("Stake of slashed candidate is forfeited")
+	ErrInvalidConsensusParams = errors.New("Invalid consensus params")
+	ErrNotDevMode             = errors.New("Blocks are only generated on demand in dev mode")
+	ErrTooManyBlocksRequested = errors.New("Too many blocks to generate, which would be too far in the future")
+
+	// finality
+	ErrInvalidFinalityCertificate  = errors.New("Invalid finality certificate")
+	ErrFinalityCertificateNotFound = errors.New("Finality certificate not found")
 
 	// context
 	ErrInvalidCandidateProtoMessage        = errors.New("Invalid candidate proto message")
@@ -27,6 +41,8 @@ var (
 	ErrInvalidPeriodContextProtoMessage    = errors.New("Invalid period contex proto message")
 	ErrInvalidPeriodProtoMessage           = errors.New("Invalid period proto message")
 	ErrInvalidEternalBlockMsgProtoMessage  = errors.New("Invalid eternalBlockMsg proto message")
+	ErrInvalidEvidenceProtoMessage         = errors.New("Invalid evidence proto message")
+	ErrInvalidValidatorStatsProtoMessage   = errors.New("Invalid validator stats proto message")
 
 	// bft_service
 	ErrNoNeedToUpdateEternalBlock = errors.New("No need to update Eternal block")
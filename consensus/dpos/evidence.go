@@ -0,0 +1,272 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"bytes"
+
+	"github.com/BOXFoundation/boxd/consensus/dpos/pb"
+	"github.com/BOXFoundation/boxd/core"
+	corepb "github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/p2p"
+	conv "github.com/BOXFoundation/boxd/p2p/convert"
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/jbenet/goprocess"
+)
+
+// Define const.
+const (
+	EvidenceMsgChBufferSize = 1024
+	// number of signed headers recently seen to detect equivocation with
+	SignedHeaderCacheSize = 1024
+	// max number of evidence txs packed in a block
+	MaxEvidencesPerBlock = 8
+)
+
+// signedHeaderKey identifies a block slot of a miner, for which the miner may
+// only sign one block.
+type signedHeaderKey struct {
+	miner     types.AddressHash
+	timestamp int64
+}
+
+// Evidence proves equivocation of a miner, i.e., two different block headers
+// signed by it for the same timestamp of its slot. It is verified against the
+// periods the headers commit to, so it is valid on any chain knowing them.
+type Evidence struct {
+	headerA    *types.BlockHeader
+	signatureA []byte
+	headerB    *types.BlockHeader
+	signatureB []byte
+}
+
+// newEvidence creates evidence from two conflicting blocks. Headers are ordered
+// by hash, so evidence of the same pair is identical whoever detects it.
+func newEvidence(a, b *types.Block) *Evidence {
+	if bytes.Compare(a.BlockHash()[:], b.BlockHash()[:]) > 0 {
+		a, b = b, a
+	}
+	return &Evidence{
+		headerA:    a.Header,
+		signatureA: a.Signature,
+		headerB:    b.Header,
+		signatureB: b.Signature,
+	}
+}
+
+// Verify checks the two headers are different, share the same timestamp and
+// are signed by the same miner, which is the producer scheduled at the timestamp
+// in the periods the headers commit to, loaded with loadPeriod. So signatures of
+// others, or of the miner out of its slots, are no evidence. It returns address
+// of the offender.
+// The first block of a period is scheduled by the period before, though it
+// commits to the new one, so equivocation there is not provable unless the miner
+// is scheduled at the timestamp in both.
+func (evidence *Evidence) Verify(loadPeriod periodLoader) (*types.AddressHash, error) {
+
+	if evidence.headerA == nil || evidence.headerB == nil ||
+		evidence.headerA.TimeStamp != evidence.headerB.TimeStamp {
+		return nil, ErrInvalidEvidence
+	}
+	hashA := (&types.Block{Header: evidence.headerA}).BlockHash()
+	hashB := (&types.Block{Header: evidence.headerB}).BlockHash()
+	if hashA == nil || hashB == nil || *hashA == *hashB {
+		return nil, ErrInvalidEvidence
+	}
+	signerA, err := recoverSigner(hashA, evidence.signatureA)
+	if err != nil {
+		return nil, err
+	}
+	signerB, err := recoverSigner(hashB, evidence.signatureB)
+	if err != nil {
+		return nil, err
+	}
+	if *signerA != *signerB {
+		return nil, ErrInvalidEvidence
+	}
+	for _, header := range []*types.BlockHeader{evidence.headerA, evidence.headerB} {
+		periodContext, err := loadPeriod(&header.PeriodHash)
+		if err != nil {
+			return nil, err
+		}
+		miner, err := periodContext.FindMinerWithTimeStamp(header.TimeStamp)
+		if err != nil || *miner != *signerA {
+			return nil, ErrInvalidEvidence
+		}
+	}
+	return signerA, nil
+}
+
+func recoverSigner(hash *crypto.HashType, signature []byte) (*types.AddressHash, error) {
+	pubkey, ok := crypto.RecoverCompact(hash[:], signature)
+	if !ok {
+		return nil, ErrInvalidEvidence
+	}
+	addr, err := types.NewAddressFromPubKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Hash160(), nil
+}
+
+var _ conv.Convertible = (*Evidence)(nil)
+var _ conv.Serializable = (*Evidence)(nil)
+
+// ToProtoMessage converts evidence to proto message.
+func (evidence *Evidence) ToProtoMessage() (proto.Message, error) {
+	headerA, err := evidence.headerA.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	headerB, err := evidence.headerB.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &dpospb.Evidence{
+		HeaderA:    headerA,
+		SignatureA: evidence.signatureA,
+		HeaderB:    headerB,
+		SignatureB: evidence.signatureB,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to evidence.
+func (evidence *Evidence) FromProtoMessage(message proto.Message) error {
+	if message, ok := message.(*dpospb.Evidence); ok {
+		if message != nil {
+			headerA := new(types.BlockHeader)
+			if err := headerA.Unmarshal(message.HeaderA); err != nil {
+				return err
+			}
+			headerB := new(types.BlockHeader)
+			if err := headerB.Unmarshal(message.HeaderB); err != nil {
+				return err
+			}
+			evidence.headerA = headerA
+			evidence.signatureA = message.SignatureA
+			evidence.headerB = headerB
+			evidence.signatureB = message.SignatureB
+			return nil
+		}
+		return core.ErrEmptyProtoMessage
+	}
+
+	return ErrInvalidEvidenceProtoMessage
+}
+
+// Marshal method marshal Evidence object to binary
+func (evidence *Evidence) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(evidence)
+}
+
+// Unmarshal method unmarshal binary data to Evidence object
+func (evidence *Evidence) Unmarshal(data []byte) error {
+	msg := &dpospb.Evidence{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return evidence.FromProtoMessage(msg)
+}
+
+// newEvidenceTx creates an evidence tx, which has neither inputs nor outputs.
+func newEvidenceTx(evidence *Evidence) (*types.Transaction, error) {
+	content, err := evidence.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Transaction{
+		Version: 1,
+		Data: &corepb.Data{
+			Type:    types.EvidenceTx,
+			Content: content,
+		},
+	}, nil
+}
+
+// detectEquivocation records the header signed by miner, and returns evidence if
+// miner has signed a different one for the same timestamp.
+func (dpos *Dpos) detectEquivocation(block *types.Block, miner types.AddressHash) *Evidence {
+
+	key := signedHeaderKey{miner: miner, timestamp: block.Header.TimeStamp}
+	if v, ok := dpos.signedHeaders.Get(key); ok {
+		signed := v.(*types.Block)
+		if *signed.BlockHash() == *block.BlockHash() {
+			return nil
+		}
+		return newEvidence(signed, block)
+	}
+	// only header and signature are kept
+	dpos.signedHeaders.Add(key, &types.Block{
+		Hash:      block.BlockHash(),
+		Header:    block.Header,
+		Signature: block.Signature,
+	})
+	return nil
+}
+
+// addEvidence adds verified evidence to the pending ones to be packed, and gossips
+// it the first time evidence against the offender is seen.
+func (dpos *Dpos) addEvidence(evidence *Evidence, offender types.AddressHash) {
+	if _, loaded := dpos.evidences.LoadOrStore(offender, evidence); loaded {
+		return
+	}
+	logger.Warnf("Miner %x signed conflicting blocks at timestamp %d", offender[:],
+		evidence.headerA.TimeStamp)
+	go dpos.net.Broadcast(p2p.EvidenceMsg, evidence)
+}
+
+func (dpos *Dpos) evidenceLoop(p goprocess.Process) {
+	for {
+		select {
+		case msg := <-dpos.evidenceMsgCh:
+			if err := dpos.handleEvidenceMsg(msg); err != nil {
+				logger.Warnf("Failed to handle evidence msg. Err: %s", err.Error())
+			}
+		case <-p.Closing():
+			logger.Info("Quit evidence loop.")
+			return
+		}
+	}
+}
+
+func (dpos *Dpos) handleEvidenceMsg(msg p2p.Message) error {
+	evidence := new(Evidence)
+	if err := evidence.Unmarshal(msg.Body()); err != nil {
+		return err
+	}
+	offender, err := evidence.Verify(dpos.loadPeriodContext)
+	if err != nil {
+		return err
+	}
+	dpos.addEvidence(evidence, *offender)
+	return nil
+}
+
+// packEvidences returns evidence txs of pending evidences and applies them to
+// candidate context. Evidences that can no longer be applied, e.g., the offender
+// has been slashed, are dropped.
+func (dpos *Dpos) packEvidences() []*types.Transaction {
+
+	var txs []*types.Transaction
+	dpos.evidences.Range(func(k, v interface{}) bool {
+		if len(txs) >= MaxEvidencesPerBlock {
+			return false
+		}
+		tx, err := newEvidenceTx(v.(*Evidence))
+		if err == nil {
+			err = dpos.prepareCandidateContext(tx, nil)
+		}
+		if err != nil {
+			logger.Debugf("Drop evidence against %x: %v", k, err)
+			dpos.evidences.Delete(k)
+			return true
+		}
+		txs = append(txs, tx)
+		return true
+	})
+	return txs
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"github.com/BOXFoundation/boxd/consensus/dpos/pb"
+	"github.com/BOXFoundation/boxd/consensus/signer"
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// FinalityCertificate proves a block is eternal with confirmations signed by
+// more than two thirds of producers of the period the block commits to.
+type FinalityCertificate struct {
+	Header        *types.BlockHeader
+	Height        uint32
+	PeriodContext *PeriodContext
+	Signatures    [][]byte
+}
+
+// Verify checks the certificate against periodHash, the hash of the period the
+// caller trusts, e.g., the genesis period or one committed in a header it has
+// verified. The period context and the one committed in block header must both
+// be the trusted one, or anyone could make up a header committing to a period
+// of its own keys. The signatures must be confirmations from distinct producers
+// of the period reaching the quorum. The first period, committed to by zero
+// hash, is the genesis period.
+func (cert *FinalityCertificate) Verify(periodHash *crypto.HashType) error {
+
+	if cert.Header == nil || cert.PeriodContext == nil || periodHash == nil {
+		return ErrInvalidFinalityCertificate
+	}
+	committed := &cert.Header.PeriodHash
+	if *committed == (crypto.HashType{}) {
+		genesis, err := GenesisPeriodHash()
+		if err != nil {
+			return err
+		}
+		committed = genesis
+	}
+	hash, err := cert.PeriodContext.PeriodContextHash()
+	if err != nil {
+		return err
+	}
+	if *committed != *periodHash || *hash != *periodHash {
+		return ErrInvalidFinalityCertificate
+	}
+
+	producers := make(map[types.AddressHash]bool)
+	for _, period := range cert.PeriodContext.period {
+		producers[period.addr] = true
+	}
+	msg := signer.ConfirmationHash(cert.Header.TimeStamp, (&types.Block{Header: cert.Header}).BlockHash())
+	signed := make(map[types.AddressHash]bool)
+	for _, signature := range cert.Signatures {
+		signer, err := recoverSigner(msg, signature)
+		if err != nil {
+			return ErrInvalidFinalityCertificate
+		}
+		if !producers[*signer] || signed[*signer] {
+			return ErrInvalidFinalityCertificate
+		}
+		signed[*signer] = true
+	}
+	if len(signed) <= 2*len(producers)/3 {
+		return ErrInvalidFinalityCertificate
+	}
+	return nil
+}
+
+// GenesisPeriodHash returns hash of the genesis period, which is trusted to
+// verify finality certificates of blocks in the first period.
+func GenesisPeriodHash() (*crypto.HashType, error) {
+	genesis, err := InitPeriodContext()
+	if err != nil {
+		return nil, err
+	}
+	return genesis.PeriodContextHash()
+}
+
+// CommittedPeriodContext returns the period context header commits to from its
+// marshaled form, e.g., carried in a utxo snapshot. It must hash to the period
+// hash in header, or be absent for the genesis period.
+func CommittedPeriodContext(header *types.BlockHeader, data []byte) (*PeriodContext, error) {
+
+	if header.PeriodHash == (crypto.HashType{}) {
+		if data != nil {
+			return nil, ErrInvalidPeriodHash
+		}
+		return InitPeriodContext()
+	}
+	if crypto.DoubleHashH(data) != header.PeriodHash {
+		return nil, ErrInvalidPeriodHash
+	}
+	periodContext := new(PeriodContext)
+	if err := periodContext.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return periodContext, nil
+}
+
+// storeFinalityCertificate persists signatures of eternal block msgs justifying
+// the block of hash.
+func (dpos *Dpos) storeFinalityCertificate(hash *crypto.HashType, msgs []*EternalBlockMsg) error {
+
+	cert := &dpospb.FinalityCertificate{}
+	for _, msg := range msgs {
+		cert.Signatures = append(cert.Signatures, msg.signature)
+	}
+	data, err := proto.Marshal(cert)
+	if err != nil {
+		return err
+	}
+	return dpos.chain.DB().Put(chain.FinalityCertificateKey(hash), data)
+}
+
+// FinalityCertificate returns finality certificate of the block of hash, or the
+// block at height on main chain if hash is nil. Only blocks made eternal by a
+// quorum of producers have one.
+func (dpos *Dpos) FinalityCertificate(hash *crypto.HashType, height uint32) (*FinalityCertificate, error) {
+
+	var block *types.Block
+	var err error
+	if hash != nil {
+		block, err = dpos.chain.LoadBlockByHash(*hash)
+	} else {
+		block, err = dpos.chain.LoadBlockByHeight(height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := dpos.chain.DB().Get(chain.FinalityCertificateKey(block.BlockHash()))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrFinalityCertificateNotFound
+	}
+	cert := &dpospb.FinalityCertificate{}
+	if err := proto.Unmarshal(data, cert); err != nil {
+		return nil, err
+	}
+	periodContext, err := dpos.loadPeriodContext(&block.Header.PeriodHash)
+	if err != nil {
+		return nil, err
+	}
+	return &FinalityCertificate{
+		Header:        block.Header,
+		Height:        block.Height,
+		PeriodContext: periodContext,
+		Signatures:    cert.Signatures,
+	}, nil
+}
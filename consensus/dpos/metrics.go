@@ -11,4 +11,12 @@ import (
 var (
 	// MetricsMintTurnCounter signs whose turn to mint
 	MetricsMintTurnCounter = metrics.NewCounter("box.dpos.mint.turn")
+	// MetricsProducedSlotCounter counts slots with a block connected to main chain
+	MetricsProducedSlotCounter = metrics.NewCounter("box.dpos.slot.produced")
+	// MetricsMissedSlotCounter counts slots without a block on main chain
+	MetricsMissedSlotCounter = metrics.NewCounter("box.dpos.slot.missed")
+	// MetricsRevertedBlockCounter counts blocks disconnected from main chain in reorgs
+	MetricsRevertedBlockCounter = metrics.NewCounter("box.dpos.block.reverted")
+	// MetricsConfirmedBlockCounter counts blocks that have become eternal
+	MetricsConfirmedBlockCounter = metrics.NewCounter("box.dpos.block.confirmed")
 )
@@ -0,0 +1,239 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/util"
+)
+
+// txEntry is a pending tx with its in-pool relatives, which are packed before it
+type txEntry struct {
+	txWrap   *chain.TxWrap
+	size     int
+	parents  []*txEntry
+	children []*txEntry
+
+	// ancestors not packed yet, and their total fee and size including the entry itself
+	ancestors    map[*txEntry]struct{}
+	ancestorFee  uint64
+	ancestorSize int
+	// number of all in-pool ancestors, used to order a package topologically
+	depth int
+
+	packed bool
+	failed bool
+	// bumped every time the ancestor set changes, so outdated heap items are ignored
+	version int
+}
+
+// packageItem is an entry scored by the fee rate of its ancestor package at some version
+type packageItem struct {
+	entry    *txEntry
+	version  int
+	feePerKB uint64
+}
+
+func packageLessFunc(queue *util.PriorityQueue, i, j int) bool {
+	itemi := queue.Items(i).(*packageItem)
+	itemj := queue.Items(j).(*packageItem)
+	// higher package fee rate first, then older tx first
+	if itemi.feePerKB == itemj.feePerKB {
+		return itemi.entry.txWrap.AddedTimestamp < itemj.entry.txWrap.AddedTimestamp
+	}
+	return itemi.feePerKB > itemj.feePerKB
+}
+
+// packageSelector selects pending txs into a block by the fee rate of their ancestor packages,
+// i.e., a tx together with all its unpacked in-pool ancestors, so that a high fee child pays
+// for its low fee parents. Scores of descendants are updated incrementally when a package is
+// packed, instead of rescanning all pending txs.
+type packageSelector struct {
+	entries []*txEntry
+	queue   *util.PriorityQueue
+}
+
+func newPackageSelector(pendingTxs []*chain.TxWrap) *packageSelector {
+	hashToEntry := make(map[crypto.HashType]*txEntry, len(pendingTxs))
+	entries := make([]*txEntry, 0, len(pendingTxs))
+	for _, txWrap := range pendingTxs {
+		txHash, err := txWrap.Tx.TxHash()
+		if err != nil {
+			continue
+		}
+		size, err := txWrap.Tx.SerializeSize()
+		if err != nil {
+			continue
+		}
+		entry := &txEntry{txWrap: txWrap, size: size}
+		hashToEntry[*txHash] = entry
+		entries = append(entries, entry)
+	}
+
+	// link parents and children in pool
+	for _, entry := range entries {
+		linked := make(map[*txEntry]bool)
+		for _, txIn := range entry.txWrap.Tx.Vin {
+			parent, exists := hashToEntry[txIn.PrevOutPoint.Hash]
+			if !exists || linked[parent] {
+				continue
+			}
+			linked[parent] = true
+			entry.parents = append(entry.parents, parent)
+			parent.children = append(parent.children, entry)
+		}
+	}
+
+	selector := &packageSelector{
+		entries: entries,
+		queue:   util.NewPriorityQueue(packageLessFunc),
+	}
+	for _, entry := range entries {
+		selector.collectAncestors(entry)
+		heap.Push(selector.queue, newPackageItem(entry))
+	}
+	return selector
+}
+
+// collectAncestors computes ancestors of entry from those of its parents
+func (s *packageSelector) collectAncestors(entry *txEntry) {
+	if entry.ancestors != nil {
+		return
+	}
+	entry.ancestors = make(map[*txEntry]struct{})
+	for _, parent := range entry.parents {
+		s.collectAncestors(parent)
+		entry.ancestors[parent] = struct{}{}
+		for ancestor := range parent.ancestors {
+			entry.ancestors[ancestor] = struct{}{}
+		}
+	}
+	entry.depth = len(entry.ancestors)
+	entry.ancestorFee = entry.txWrap.Fee
+	entry.ancestorSize = entry.size
+	for ancestor := range entry.ancestors {
+		entry.ancestorFee += ancestor.txWrap.Fee
+		entry.ancestorSize += ancestor.size
+	}
+}
+
+func newPackageItem(entry *txEntry) *packageItem {
+	var feePerKB uint64
+	if entry.ancestorSize > 0 {
+		feePerKB = entry.ancestorFee * 1000 / uint64(entry.ancestorSize)
+	}
+	return &packageItem{entry: entry, version: entry.version, feePerKB: feePerKB}
+}
+
+// next returns the best package to pack, ordered with ancestors first
+func (s *packageSelector) next() []*txEntry {
+	for s.queue.Len() > 0 {
+		item := heap.Pop(s.queue).(*packageItem)
+		entry := item.entry
+		if entry.packed || entry.failed || item.version != entry.version {
+			continue
+		}
+		pkg := make([]*txEntry, 0, len(entry.ancestors)+1)
+		for ancestor := range entry.ancestors {
+			pkg = append(pkg, ancestor)
+		}
+		pkg = append(pkg, entry)
+		sort.Slice(pkg, func(i, j int) bool {
+			return pkg[i].depth < pkg[j].depth
+		})
+		return pkg
+	}
+	return nil
+}
+
+// markPacked marks entry packed, and removes it from ancestor packages of its descendants
+func (s *packageSelector) markPacked(entry *txEntry) {
+	entry.packed = true
+	for _, descendant := range descendantsOf(entry) {
+		if _, ok := descendant.ancestors[entry]; !ok {
+			continue
+		}
+		delete(descendant.ancestors, entry)
+		descendant.ancestorFee -= entry.txWrap.Fee
+		descendant.ancestorSize -= entry.size
+		descendant.version++
+		if !descendant.failed {
+			heap.Push(s.queue, newPackageItem(descendant))
+		}
+	}
+}
+
+// markFailed marks entry and all its descendants unpackable
+func (s *packageSelector) markFailed(entry *txEntry) {
+	entry.failed = true
+	for _, descendant := range descendantsOf(entry) {
+		descendant.failed = true
+	}
+}
+
+// descendantsOf returns all in-pool descendants of entry
+func descendantsOf(entry *txEntry) []*txEntry {
+	var descendants []*txEntry
+	visited := make(map[*txEntry]bool)
+	queue := append([]*txEntry{}, entry.children...)
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		if visited[e] {
+			continue
+		}
+		visited[e] = true
+		descendants = append(descendants, e)
+		queue = append(queue, e.children...)
+	}
+	return descendants
+}
+
+// selectTxs selects pending txs fitting in maxSize bytes and maxCount txs by package fee rate,
+// parents always before their children, until deadline. Each tx takes txEncodingOverhead bytes
+// more than its own size in a block. tryPack is called on each tx in order and returns false
+// if the tx cannot be packed, e.g., exceeding other block limits, in which case its descendants
+// are skipped.
+func selectTxs(pendingTxs []*chain.TxWrap, maxSize, maxCount int, deadline <-chan time.Time,
+	tryPack func(txWrap *chain.TxWrap) bool) []*chain.TxWrap {
+
+	selector := newPackageSelector(pendingTxs)
+	var selected []*chain.TxWrap
+	var totalSize int
+	for len(selected) < maxCount {
+		select {
+		case <-deadline:
+			return selected
+		default:
+		}
+
+		pkg := selector.next()
+		if pkg == nil {
+			break
+		}
+		entry := pkg[len(pkg)-1]
+		pkgSize := entry.ancestorSize + len(pkg)*txEncodingOverhead
+		if totalSize+pkgSize > maxSize || len(selected)+len(pkg) > maxCount {
+			// a smaller package may still fit
+			selector.markFailed(entry)
+			continue
+		}
+		for _, e := range pkg {
+			if !tryPack(e.txWrap) {
+				selector.markFailed(e)
+				break
+			}
+			selector.markPacked(e)
+			selected = append(selected, e.txWrap)
+			totalSize += e.size + txEncodingOverhead
+		}
+	}
+	return selected
+}
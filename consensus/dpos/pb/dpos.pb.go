@@ -29,7 +29,7 @@ func (m *PeriodContext) Reset()         { *m = PeriodContext{} }
 func (m *PeriodContext) String() string { return proto.CompactTextString(m) }
 func (*PeriodContext) ProtoMessage()    {}
 func (*PeriodContext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dpos_2c0792c77d69a47e, []int{0}
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{0}
 }
 func (m *PeriodContext) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -81,7 +81,7 @@ func (m *Period) Reset()         { *m = Period{} }
 func (m *Period) String() string { return proto.CompactTextString(m) }
 func (*Period) ProtoMessage()    {}
 func (*Period) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dpos_2c0792c77d69a47e, []int{1}
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{1}
 }
 func (m *Period) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -127,13 +127,14 @@ func (m *Period) GetPeerId() string {
 type CandidateContext struct {
 	Height     uint32       `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Candidates []*Candidate `protobuf:"bytes,2,rep,name=candidates" json:"candidates,omitempty"`
+	Slashed    [][]byte     `protobuf:"bytes,3,rep,name=slashed" json:"slashed,omitempty"`
 }
 
 func (m *CandidateContext) Reset()         { *m = CandidateContext{} }
 func (m *CandidateContext) String() string { return proto.CompactTextString(m) }
 func (*CandidateContext) ProtoMessage()    {}
 func (*CandidateContext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dpos_2c0792c77d69a47e, []int{2}
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{2}
 }
 func (m *CandidateContext) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -176,17 +177,27 @@ func (m *CandidateContext) GetCandidates() []*Candidate {
 	return nil
 }
 
+func (m *CandidateContext) GetSlashed() [][]byte {
+	if m != nil {
+		return m.Slashed
+	}
+	return nil
+}
+
 type Candidate struct {
-	Addr  []byte `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	Votes int64  `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
-	Peer  string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Addr        []byte `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Votes       int64  `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	Peer        string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Endpoint    string `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Withdrawn   bool   `protobuf:"varint,6,opt,name=withdrawn,proto3" json:"withdrawn,omitempty"`
 }
 
 func (m *Candidate) Reset()         { *m = Candidate{} }
 func (m *Candidate) String() string { return proto.CompactTextString(m) }
 func (*Candidate) ProtoMessage()    {}
 func (*Candidate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dpos_2c0792c77d69a47e, []int{3}
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{3}
 }
 func (m *Candidate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -236,6 +247,27 @@ func (m *Candidate) GetPeer() string {
 	return ""
 }
 
+func (m *Candidate) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Candidate) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Candidate) GetWithdrawn() bool {
+	if m != nil {
+		return m.Withdrawn
+	}
+	return false
+}
+
 type EternalBlockMsg struct {
 	Hash      []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Timestamp int64  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -246,7 +278,7 @@ func (m *EternalBlockMsg) Reset()         { *m = EternalBlockMsg{} }
 func (m *EternalBlockMsg) String() string { return proto.CompactTextString(m) }
 func (*EternalBlockMsg) ProtoMessage()    {}
 func (*EternalBlockMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dpos_2c0792c77d69a47e, []int{4}
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{4}
 }
 func (m *EternalBlockMsg) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,12 +328,203 @@ func (m *EternalBlockMsg) GetSignature() []byte {
 	return nil
 }
 
+type Evidence struct {
+	HeaderA    []byte `protobuf:"bytes,1,opt,name=header_a,json=headerA,proto3" json:"header_a,omitempty"`
+	SignatureA []byte `protobuf:"bytes,2,opt,name=signature_a,json=signatureA,proto3" json:"signature_a,omitempty"`
+	HeaderB    []byte `protobuf:"bytes,3,opt,name=header_b,json=headerB,proto3" json:"header_b,omitempty"`
+	SignatureB []byte `protobuf:"bytes,4,opt,name=signature_b,json=signatureB,proto3" json:"signature_b,omitempty"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{5}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(dst, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetHeaderA() []byte {
+	if m != nil {
+		return m.HeaderA
+	}
+	return nil
+}
+
+func (m *Evidence) GetSignatureA() []byte {
+	if m != nil {
+		return m.SignatureA
+	}
+	return nil
+}
+
+func (m *Evidence) GetHeaderB() []byte {
+	if m != nil {
+		return m.HeaderB
+	}
+	return nil
+}
+
+func (m *Evidence) GetSignatureB() []byte {
+	if m != nil {
+		return m.SignatureB
+	}
+	return nil
+}
+
+type ValidatorStats struct {
+	Addr      []byte `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Produced  uint64 `protobuf:"varint,2,opt,name=produced,proto3" json:"produced,omitempty"`
+	Missed    uint64 `protobuf:"varint,3,opt,name=missed,proto3" json:"missed,omitempty"`
+	Reverted  uint64 `protobuf:"varint,4,opt,name=reverted,proto3" json:"reverted,omitempty"`
+	Confirmed uint64 `protobuf:"varint,5,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+}
+
+func (m *ValidatorStats) Reset()         { *m = ValidatorStats{} }
+func (m *ValidatorStats) String() string { return proto.CompactTextString(m) }
+func (*ValidatorStats) ProtoMessage()    {}
+func (*ValidatorStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{6}
+}
+func (m *ValidatorStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ValidatorStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorStats.Merge(dst, src)
+}
+func (m *ValidatorStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorStats proto.InternalMessageInfo
+
+func (m *ValidatorStats) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func (m *ValidatorStats) GetProduced() uint64 {
+	if m != nil {
+		return m.Produced
+	}
+	return 0
+}
+
+func (m *ValidatorStats) GetMissed() uint64 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+func (m *ValidatorStats) GetReverted() uint64 {
+	if m != nil {
+		return m.Reverted
+	}
+	return 0
+}
+
+func (m *ValidatorStats) GetConfirmed() uint64 {
+	if m != nil {
+		return m.Confirmed
+	}
+	return 0
+}
+
+type FinalityCertificate struct {
+	Signatures [][]byte `protobuf:"bytes,1,rep,name=signatures" json:"signatures,omitempty"`
+}
+
+func (m *FinalityCertificate) Reset()         { *m = FinalityCertificate{} }
+func (m *FinalityCertificate) String() string { return proto.CompactTextString(m) }
+func (*FinalityCertificate) ProtoMessage()    {}
+func (*FinalityCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dpos_37d66ca8561a5586, []int{7}
+}
+func (m *FinalityCertificate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FinalityCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FinalityCertificate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *FinalityCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalityCertificate.Merge(dst, src)
+}
+func (m *FinalityCertificate) XXX_Size() int {
+	return m.Size()
+}
+func (m *FinalityCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalityCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalityCertificate proto.InternalMessageInfo
+
+func (m *FinalityCertificate) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PeriodContext)(nil), "dpospb.PeriodContext")
 	proto.RegisterType((*Period)(nil), "dpospb.Period")
 	proto.RegisterType((*CandidateContext)(nil), "dpospb.candidateContext")
 	proto.RegisterType((*Candidate)(nil), "dpospb.Candidate")
 	proto.RegisterType((*EternalBlockMsg)(nil), "dpospb.EternalBlockMsg")
+	proto.RegisterType((*Evidence)(nil), "dpospb.Evidence")
+	proto.RegisterType((*ValidatorStats)(nil), "dpospb.ValidatorStats")
+	proto.RegisterType((*FinalityCertificate)(nil), "dpospb.FinalityCertificate")
 }
 func (m *PeriodContext) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -407,6 +630,14 @@ func (m *CandidateContext) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Slashed) > 0 {
+		for _, b := range m.Slashed {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintDpos(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
 	return i, nil
 }
 
@@ -442,6 +673,28 @@ func (m *Candidate) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDpos(dAtA, i, uint64(len(m.Peer)))
 		i += copy(dAtA[i:], m.Peer)
 	}
+	if len(m.Endpoint) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.Endpoint)))
+		i += copy(dAtA[i:], m.Endpoint)
+	}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
+	}
+	if m.Withdrawn {
+		dAtA[i] = 0x30
+		i++
+		if m.Withdrawn {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -480,6 +733,118 @@ func (m *EternalBlockMsg) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *Evidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.HeaderA) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.HeaderA)))
+		i += copy(dAtA[i:], m.HeaderA)
+	}
+	if len(m.SignatureA) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.SignatureA)))
+		i += copy(dAtA[i:], m.SignatureA)
+	}
+	if len(m.HeaderB) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.HeaderB)))
+		i += copy(dAtA[i:], m.HeaderB)
+	}
+	if len(m.SignatureB) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.SignatureB)))
+		i += copy(dAtA[i:], m.SignatureB)
+	}
+	return i, nil
+}
+
+func (m *ValidatorStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addr) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(len(m.Addr)))
+		i += copy(dAtA[i:], m.Addr)
+	}
+	if m.Produced != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(m.Produced))
+	}
+	if m.Missed != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(m.Missed))
+	}
+	if m.Reverted != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(m.Reverted))
+	}
+	if m.Confirmed != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDpos(dAtA, i, uint64(m.Confirmed))
+	}
+	return i, nil
+}
+
+func (m *FinalityCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FinalityCertificate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDpos(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
 func encodeVarintDpos(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -542,6 +907,12 @@ func (m *CandidateContext) Size() (n int) {
 			n += 1 + l + sovDpos(uint64(l))
 		}
 	}
+	if len(m.Slashed) > 0 {
+		for _, b := range m.Slashed {
+			l = len(b)
+			n += 1 + l + sovDpos(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -562,6 +933,17 @@ func (m *Candidate) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDpos(uint64(l))
 	}
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	if m.Withdrawn {
+		n += 2
+	}
 	return n
 }
 
@@ -585,17 +967,82 @@ func (m *EternalBlockMsg) Size() (n int) {
 	return n
 }
 
-func sovDpos(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *Evidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.HeaderA)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	l = len(m.SignatureA)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	l = len(m.HeaderB)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	l = len(m.SignatureB)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
 	}
 	return n
 }
-func sozDpos(x uint64) (n int) {
+
+func (m *ValidatorStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovDpos(uint64(l))
+	}
+	if m.Produced != 0 {
+		n += 1 + sovDpos(uint64(m.Produced))
+	}
+	if m.Missed != 0 {
+		n += 1 + sovDpos(uint64(m.Missed))
+	}
+	if m.Reverted != 0 {
+		n += 1 + sovDpos(uint64(m.Reverted))
+	}
+	if m.Confirmed != 0 {
+		n += 1 + sovDpos(uint64(m.Confirmed))
+	}
+	return n
+}
+
+func (m *FinalityCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			l = len(b)
+			n += 1 + l + sovDpos(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDpos(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozDpos(x uint64) (n int) {
 	return sovDpos(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *PeriodContext) Unmarshal(dAtA []byte) error {
@@ -662,7 +1109,464 @@ func (m *PeriodContext) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field NextPeriod", wireType)
 			}
-			var msglen int
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextPeriod = append(m.NextPeriod, &Period{})
+			if err := m.NextPeriod[len(m.NextPeriod)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDpos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDpos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Period) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDpos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Period: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Period: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDpos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDpos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CandidateContext) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDpos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: candidateContext: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: candidateContext: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates, &Candidate{})
+			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Slashed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Slashed = append(m.Slashed, make([]byte, postIndex-iNdEx))
+			copy(m.Slashed[len(m.Slashed)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDpos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDpos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Candidate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDpos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Candidate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Candidate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			m.Votes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Votes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Withdrawn", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -672,23 +1576,12 @@ func (m *PeriodContext) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthDpos
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.NextPeriod = append(m.NextPeriod, &Period{})
-			if err := m.NextPeriod[len(m.NextPeriod)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Withdrawn = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDpos(dAtA[iNdEx:])
@@ -710,7 +1603,7 @@ func (m *PeriodContext) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Period) Unmarshal(dAtA []byte) error {
+func (m *EternalBlockMsg) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -733,15 +1626,15 @@ func (m *Period) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Period: wiretype end group for non-group")
+			return fmt.Errorf("proto: EternalBlockMsg: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Period: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EternalBlockMsg: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -765,16 +1658,35 @@ func (m *Period) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -784,20 +1696,22 @@ func (m *Period) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthDpos
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerId = string(dAtA[iNdEx:postIndex])
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -820,7 +1734,7 @@ func (m *Period) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CandidateContext) Unmarshal(dAtA []byte) error {
+func (m *Evidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -843,17 +1757,17 @@ func (m *CandidateContext) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: candidateContext: wiretype end group for non-group")
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: candidateContext: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderA", wireType)
 			}
-			m.Height = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -863,16 +1777,28 @@ func (m *CandidateContext) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= (uint32(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderA = append(m.HeaderA[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderA == nil {
+				m.HeaderA = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureA", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -882,21 +1808,83 @@ func (m *CandidateContext) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthDpos
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Candidates = append(m.Candidates, &Candidate{})
-			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.SignatureA = append(m.SignatureA[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignatureA == nil {
+				m.SignatureA = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderB", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderB = append(m.HeaderB[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderB == nil {
+				m.HeaderB = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureB", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDpos
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignatureB = append(m.SignatureB[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignatureB == nil {
+				m.SignatureB = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -920,7 +1908,7 @@ func (m *CandidateContext) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Candidate) Unmarshal(dAtA []byte) error {
+func (m *ValidatorStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -943,10 +1931,10 @@ func (m *Candidate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Candidate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidatorStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Candidate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidatorStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -982,9 +1970,9 @@ func (m *Candidate) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Produced", wireType)
 			}
-			m.Votes = 0
+			m.Produced = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -994,16 +1982,16 @@ func (m *Candidate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Votes |= (int64(b) & 0x7F) << shift
+				m.Produced |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Missed", wireType)
 			}
-			var stringLen uint64
+			m.Missed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDpos
@@ -1013,21 +2001,49 @@ func (m *Candidate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Missed |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDpos
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reverted", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Reverted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reverted |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Confirmed", wireType)
+			}
+			m.Confirmed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDpos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Confirmed |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Peer = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDpos(dAtA[iNdEx:])
@@ -1049,7 +2065,7 @@ func (m *Candidate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EternalBlockMsg) Unmarshal(dAtA []byte) error {
+func (m *FinalityCertificate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1072,65 +2088,15 @@ func (m *EternalBlockMsg) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EternalBlockMsg: wiretype end group for non-group")
+			return fmt.Errorf("proto: FinalityCertificate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EternalBlockMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FinalityCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDpos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDpos
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			m.Timestamp = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDpos
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Timestamp |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1154,10 +2120,8 @@ func (m *EternalBlockMsg) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signature == nil {
-				m.Signature = []byte{}
-			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1285,28 +2249,41 @@ var (
 	ErrIntOverflowDpos   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("dpos.proto", fileDescriptor_dpos_2c0792c77d69a47e) }
-
-var fileDescriptor_dpos_2c0792c77d69a47e = []byte{
-	// 309 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcf, 0x4a, 0x2b, 0x31,
-	0x18, 0xc5, 0x9b, 0xdb, 0xeb, 0x48, 0xbf, 0xb6, 0xfe, 0x09, 0xa2, 0xb3, 0x90, 0x50, 0x66, 0x21,
-	0xb3, 0xaa, 0xa8, 0xf8, 0x02, 0x2d, 0x2e, 0xba, 0x10, 0x24, 0x7b, 0x29, 0x69, 0x13, 0x3a, 0xc1,
-	0x76, 0x12, 0x92, 0x28, 0x7d, 0x0c, 0x1f, 0xcb, 0x65, 0x97, 0x2e, 0xa5, 0xf3, 0x22, 0x92, 0x64,
-	0xa6, 0x75, 0xd1, 0xdd, 0xf9, 0xce, 0xf9, 0x71, 0x72, 0x20, 0x00, 0x5c, 0x2b, 0x3b, 0xd4, 0x46,
-	0x39, 0x85, 0x13, 0xaf, 0xf5, 0x2c, 0x2b, 0xa0, 0xff, 0x22, 0x8c, 0x54, 0x7c, 0xac, 0x4a, 0x27,
-	0xd6, 0x0e, 0xdf, 0x40, 0xa2, 0x83, 0x91, 0xa2, 0x41, 0x3b, 0xef, 0xde, 0x9f, 0x0c, 0x23, 0x39,
-	0x8c, 0x18, 0xad, 0x53, 0x7c, 0x0b, 0xdd, 0x52, 0xac, 0xdd, 0xb4, 0x86, 0xff, 0x1d, 0x84, 0xc1,
-	0x23, 0x51, 0x67, 0x8f, 0x90, 0x44, 0x85, 0x31, 0xfc, 0x67, 0x9c, 0x9b, 0x14, 0x0d, 0x50, 0xde,
-	0xa3, 0x41, 0xe3, 0x2b, 0x38, 0xd6, 0x42, 0x98, 0xa9, 0xf4, 0x55, 0x28, 0xef, 0xf8, 0x77, 0x84,
-	0x99, 0xf0, 0xec, 0x15, 0xce, 0xe6, 0xac, 0xe4, 0x92, 0x33, 0x27, 0x9a, 0x8d, 0x97, 0x90, 0x14,
-	0x42, 0x2e, 0x0a, 0x17, 0x2a, 0xfa, 0xb4, 0xbe, 0xf0, 0x1d, 0xc0, 0x8e, 0xb5, 0xf5, 0xa4, 0xf3,
-	0x66, 0xd2, 0xb8, 0x49, 0xe8, 0x1f, 0x28, 0x9b, 0x40, 0x67, 0x17, 0x1c, 0x1c, 0x76, 0x01, 0x47,
-	0x1f, 0x2a, 0xd6, 0xa1, 0xbc, 0x4d, 0xe3, 0xe1, 0x49, 0xbf, 0x2f, 0x6d, 0x87, 0xad, 0x41, 0x67,
-	0x0c, 0x4e, 0x9f, 0x9c, 0x30, 0x25, 0x5b, 0x8e, 0x96, 0x6a, 0xfe, 0xf6, 0x6c, 0x17, 0x1e, 0x2b,
-	0x98, 0x2d, 0x9a, 0x42, 0xaf, 0xf1, 0x35, 0x74, 0x9c, 0x5c, 0x09, 0xeb, 0xd8, 0x4a, 0xd7, 0xa5,
-	0x7b, 0xc3, 0xa7, 0x56, 0x2e, 0x4a, 0xe6, 0xde, 0x8d, 0x08, 0xed, 0x3d, 0xba, 0x37, 0x46, 0xe9,
-	0xd7, 0x96, 0xa0, 0xcd, 0x96, 0xa0, 0x9f, 0x2d, 0x41, 0x9f, 0x15, 0x69, 0x6d, 0x2a, 0xd2, 0xfa,
-	0xae, 0x48, 0x6b, 0x96, 0x84, 0x6f, 0x7d, 0xf8, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x40, 0xdc, 0x1e,
-	0x63, 0xe4, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("dpos.proto", fileDescriptor_dpos_37d66ca8561a5586) }
+
+var fileDescriptor_dpos_37d66ca8561a5586 = []byte{
+	// 519 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xd1, 0x6e, 0xd3, 0x30,
+	0x14, 0x9d, 0x49, 0x96, 0x75, 0xb7, 0xdd, 0x00, 0x83, 0x20, 0x4c, 0x28, 0x54, 0x79, 0x40, 0x79,
+	0x1a, 0x02, 0xb4, 0x0f, 0x58, 0xa7, 0x21, 0xf1, 0x80, 0x84, 0x8c, 0xc4, 0x6b, 0xe5, 0xc6, 0x77,
+	0x8b, 0x45, 0x6b, 0x47, 0xb6, 0xd7, 0x8e, 0x57, 0xbe, 0x00, 0xf1, 0x0f, 0xfc, 0x0b, 0x8f, 0x7b,
+	0xe4, 0x11, 0xb5, 0x3f, 0x82, 0xec, 0xa4, 0x69, 0x41, 0x7d, 0xbb, 0xe7, 0xdc, 0x73, 0x7c, 0x4f,
+	0x72, 0x6d, 0x00, 0x51, 0x6b, 0x7b, 0x5a, 0x1b, 0xed, 0x34, 0x4d, 0x7c, 0x5d, 0x4f, 0xf2, 0x0a,
+	0x8e, 0x3e, 0xa2, 0x91, 0x5a, 0x5c, 0x68, 0xe5, 0xf0, 0xd6, 0xd1, 0x97, 0x90, 0xd4, 0x81, 0x48,
+	0xc9, 0x30, 0x2a, 0xfa, 0x6f, 0x8e, 0x4f, 0x1b, 0xe5, 0x69, 0x23, 0x63, 0x6d, 0x97, 0xbe, 0x82,
+	0xbe, 0xc2, 0x5b, 0x37, 0x6e, 0xc5, 0xf7, 0x76, 0x8a, 0xc1, 0x4b, 0x9a, 0x3a, 0x3f, 0x83, 0xa4,
+	0xa9, 0x28, 0x85, 0x98, 0x0b, 0x61, 0x52, 0x32, 0x24, 0xc5, 0x80, 0x85, 0x9a, 0x3e, 0x85, 0x83,
+	0x1a, 0xd1, 0x8c, 0xa5, 0x3f, 0x8a, 0x14, 0x87, 0x7e, 0x0e, 0x9a, 0xf7, 0x22, 0x5f, 0xc0, 0x83,
+	0x92, 0x2b, 0x21, 0x05, 0x77, 0xb8, 0xce, 0xf8, 0x04, 0x92, 0x0a, 0xe5, 0x75, 0xe5, 0xc2, 0x11,
+	0x47, 0xac, 0x45, 0xf4, 0x35, 0x40, 0xa7, 0xb5, 0x6d, 0xa4, 0x87, 0xeb, 0x48, 0x17, 0xeb, 0x0e,
+	0xdb, 0x12, 0xd1, 0x14, 0x0e, 0xec, 0x94, 0xdb, 0x0a, 0x45, 0x1a, 0x0d, 0xa3, 0x62, 0xc0, 0xd6,
+	0x30, 0xff, 0x49, 0xe0, 0xb0, 0xf3, 0xec, 0xcc, 0xfc, 0x18, 0xf6, 0xe7, 0xba, 0x99, 0x44, 0x8a,
+	0x88, 0x35, 0xc0, 0x2b, 0x7d, 0xf4, 0x34, 0x0a, 0x9f, 0x11, 0x6a, 0x7a, 0x02, 0x3d, 0x54, 0xa2,
+	0xd6, 0x52, 0xb9, 0x34, 0x0e, 0x7c, 0x87, 0xe9, 0x10, 0xfa, 0x02, 0x6d, 0x69, 0x64, 0xed, 0xa4,
+	0x56, 0xe9, 0x7e, 0x68, 0x6f, 0x53, 0xf4, 0x39, 0x1c, 0x2e, 0xa4, 0xab, 0x84, 0xe1, 0x0b, 0x95,
+	0x26, 0x43, 0x52, 0xf4, 0xd8, 0x86, 0xc8, 0x39, 0xdc, 0xbf, 0x74, 0x68, 0x14, 0x9f, 0x8e, 0xa6,
+	0xba, 0xfc, 0xf2, 0xc1, 0x5e, 0xfb, 0x08, 0x15, 0xb7, 0xd5, 0x3a, 0xac, 0xaf, 0xfd, 0x21, 0x4e,
+	0xce, 0xd0, 0x3a, 0x3e, 0xab, 0xdb, 0xc0, 0x1b, 0xc2, 0x77, 0xad, 0xbc, 0x56, 0xdc, 0xdd, 0x18,
+	0x0c, 0xc9, 0x07, 0x6c, 0x43, 0xe4, 0xdf, 0x08, 0xf4, 0x2e, 0xe7, 0x52, 0xa0, 0x2a, 0x91, 0x3e,
+	0x83, 0x5e, 0x85, 0x5c, 0xa0, 0x19, 0xf3, 0x76, 0xc0, 0x41, 0x83, 0xcf, 0xe9, 0x0b, 0xe8, 0x77,
+	0xa6, 0x31, 0x0f, 0x53, 0x06, 0x0c, 0x3a, 0xea, 0x7c, 0xcb, 0x3b, 0x49, 0xa3, 0x6d, 0xef, 0xe8,
+	0x5f, 0xef, 0x24, 0x8d, 0xff, 0xf3, 0x8e, 0xf2, 0x1f, 0x04, 0x8e, 0x3f, 0xf3, 0xa9, 0x5f, 0x87,
+	0x36, 0x9f, 0x1c, 0x77, 0x76, 0xe7, 0x52, 0x4e, 0xa0, 0x57, 0x1b, 0x2d, 0x6e, 0x4a, 0x6c, 0x6e,
+	0x52, 0xcc, 0x3a, 0xec, 0xef, 0xcd, 0x4c, 0x5a, 0x1b, 0x76, 0xed, 0x3b, 0x2d, 0xf2, 0x1e, 0x83,
+	0x73, 0x34, 0x0e, 0x45, 0x18, 0x1c, 0xb3, 0x0e, 0xfb, 0x3f, 0x53, 0x6a, 0x75, 0x25, 0xcd, 0x0c,
+	0x45, 0x58, 0x4e, 0xcc, 0x36, 0x44, 0x7e, 0x06, 0x8f, 0xde, 0x49, 0xc5, 0xa7, 0xd2, 0x7d, 0xbd,
+	0x40, 0xe3, 0xe4, 0x95, 0x2c, 0xfd, 0x6d, 0xc9, 0x60, 0x93, 0xdc, 0x86, 0x87, 0xb4, 0xfd, 0x2d,
+	0x76, 0x94, 0xfe, 0x5a, 0x66, 0xe4, 0x6e, 0x99, 0x91, 0x3f, 0xcb, 0x8c, 0x7c, 0x5f, 0x65, 0x7b,
+	0x77, 0xab, 0x6c, 0xef, 0xf7, 0x2a, 0xdb, 0x9b, 0x24, 0xe1, 0x79, 0xbe, 0xfd, 0x3b, 0x00, 0x47,
+	0xec, 0x9d, 0xdf, 0xac, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,59 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"github.com/BOXFoundation/boxd/consensus/signer"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/wallet"
+)
+
+// Signer holds the key of the block producer, and signs hashes of blocks it
+// produces or confirms eternal with it.
+type Signer interface {
+	// Addr returns address of the producer
+	Addr() string
+	// PubKeyHash returns public key hash of the producer
+	PubKeyHash() []byte
+	// SignBlock signs hash of the block header produced at height
+	SignBlock(height uint32, header *types.BlockHeader) ([]byte, error)
+	// SignConfirmation signs the confirmation message of the block of hash and
+	// timestamp to confirm it eternal, see signer.ConfirmationHash
+	SignConfirmation(timestamp int64, hash *crypto.HashType) ([]byte, error)
+}
+
+// localSigner signs with the key loaded into the node process.
+type localSigner struct {
+	*wallet.Account
+}
+
+func (s *localSigner) SignBlock(height uint32, header *types.BlockHeader) ([]byte, error) {
+	hash, err := signer.HeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SignCompact(s.PrivateKey(), hash[:])
+}
+
+func (s *localSigner) SignConfirmation(timestamp int64, hash *crypto.HashType) ([]byte, error) {
+	return crypto.SignCompact(s.PrivateKey(), signer.ConfirmationHash(timestamp, hash)[:])
+}
+
+// newSigner returns the remote signer listening on Config.Signer if set, or
+// signs with the key in Config.Keypath unlocked with Config.Passphrase.
+func newSigner(cfg *Config) (Signer, error) {
+	if cfg.Signer != "" {
+		return signer.NewRemoteSigner(cfg.Signer)
+	}
+	account, err := wallet.NewAccountFromFile(cfg.Keypath)
+	if err != nil {
+		return nil, err
+	}
+	if err := account.UnlockWithPassphrase(cfg.Passphrase); err != nil {
+		return nil, err
+	}
+	return &localSigner{account}, nil
+}
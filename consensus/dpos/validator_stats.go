@@ -0,0 +1,294 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"fmt"
+
+	"github.com/BOXFoundation/boxd/consensus/dpos/pb"
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/chain"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/metrics"
+	conv "github.com/BOXFoundation/boxd/p2p/convert"
+	"github.com/BOXFoundation/boxd/storage"
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// ValidatorStats records how a validator performs in its slots.
+type ValidatorStats struct {
+	Addr types.AddressHash
+	// blocks produced in its slots on main chain
+	Produced uint64
+	// its slots without a block on main chain
+	Missed uint64
+	// blocks produced by it and disconnected from main chain in reorgs
+	Reverted uint64
+	// blocks produced by it that have become eternal
+	Confirmed uint64
+	// whether it is a producer of the current period, which is not persisted
+	Active bool
+}
+
+var _ conv.Convertible = (*ValidatorStats)(nil)
+var _ conv.Serializable = (*ValidatorStats)(nil)
+
+// ToProtoMessage converts validator stats to proto message.
+func (stats *ValidatorStats) ToProtoMessage() (proto.Message, error) {
+	return &dpospb.ValidatorStats{
+		Addr:      stats.Addr[:],
+		Produced:  stats.Produced,
+		Missed:    stats.Missed,
+		Reverted:  stats.Reverted,
+		Confirmed: stats.Confirmed,
+	}, nil
+}
+
+// FromProtoMessage converts proto message to validator stats.
+func (stats *ValidatorStats) FromProtoMessage(message proto.Message) error {
+	if message, ok := message.(*dpospb.ValidatorStats); ok {
+		if message != nil {
+			copy(stats.Addr[:], message.Addr)
+			stats.Produced = message.Produced
+			stats.Missed = message.Missed
+			stats.Reverted = message.Reverted
+			stats.Confirmed = message.Confirmed
+			return nil
+		}
+		return core.ErrEmptyProtoMessage
+	}
+
+	return ErrInvalidValidatorStatsProtoMessage
+}
+
+// Marshal method marshal ValidatorStats object to binary
+func (stats *ValidatorStats) Marshal() (data []byte, err error) {
+	return conv.MarshalConvertible(stats)
+}
+
+// Unmarshal method unmarshal binary data to ValidatorStats object
+func (stats *ValidatorStats) Unmarshal(data []byte) error {
+	msg := &dpospb.ValidatorStats{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return stats.FromProtoMessage(msg)
+}
+
+// adjust adds delta to count, which never goes below zero, since blocks connected
+// before stats are tracked may be disconnected.
+func adjust(count uint64, delta int64) uint64 {
+	if delta < 0 && uint64(-delta) > count {
+		return 0
+	}
+	return uint64(int64(count) + delta)
+}
+
+// incValidatorCounter increases the counter of the validator, e.g.,
+// box.dpos.validator.{hex encoded address hash}.missed
+func incValidatorCounter(addr types.AddressHash, name string, count int64) {
+	metrics.NewCounter(fmt.Sprintf("box.dpos.validator.%x.%s", addr[:], name)).Inc(count)
+}
+
+// receiveChainUpdateMsg updates stats of validators when a block is connected to
+// or disconnected from main chain.
+func (dpos *Dpos) receiveChainUpdateMsg(msg *chain.UpdateMsg) {
+	if err := dpos.updateValidatorStats(msg.Block, msg.Connected); err != nil {
+		logger.Warnf("Failed to update validator stats of block %s. Err: %s",
+			msg.Block.BlockHash(), err.Error())
+	}
+}
+
+// updateValidatorStats credits the block to its producer, and the slots between
+// the parent and it as missed to producers scheduled in them. Disconnecting the
+// block undoes both and counts the block as reverted.
+func (dpos *Dpos) updateValidatorStats(block *types.Block, connected bool) error {
+
+	if block.Height == 0 {
+		return nil
+	}
+	producer, err := recoverSigner(block.BlockHash(), block.Signature)
+	if err != nil {
+		return err
+	}
+	parent, err := dpos.chain.LoadBlockByHash(block.Header.PrevBlockHash)
+	if err != nil {
+		return err
+	}
+	missed := make(map[types.AddressHash]int64)
+	// timestamp of genesis is not a slot of any producer
+	if parent.Height > 0 {
+		periodContext, err := dpos.loadPeriodContext(&parent.Header.PeriodHash)
+		if err != nil {
+			return err
+		}
+		for timestamp := parent.Header.TimeStamp + 1; timestamp < block.Header.TimeStamp; timestamp++ {
+			if miner, err := periodContext.FindMinerWithTimeStamp(timestamp); err == nil {
+				missed[*miner]++
+			}
+		}
+	}
+
+	delta := int64(1)
+	if !connected {
+		delta = -1
+	}
+	dpos.statsLock.Lock()
+	defer dpos.statsLock.Unlock()
+
+	batch := dpos.chain.DB().NewBatch()
+	defer batch.Close()
+	stats, err := dpos.loadValidatorStats(producer)
+	if err != nil {
+		return err
+	}
+	stats.Produced = adjust(stats.Produced, delta)
+	if !connected {
+		stats.Reverted++
+	}
+	if count, ok := missed[*producer]; ok {
+		stats.Missed = adjust(stats.Missed, delta*count)
+		delete(missed, *producer)
+	}
+	if err := putValidatorStats(batch, stats); err != nil {
+		return err
+	}
+	for addr, count := range missed {
+		addr := addr
+		stats, err := dpos.loadValidatorStats(&addr)
+		if err != nil {
+			return err
+		}
+		stats.Missed = adjust(stats.Missed, delta*count)
+		if err := putValidatorStats(batch, stats); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	if !connected {
+		MetricsRevertedBlockCounter.Inc(1)
+		incValidatorCounter(*producer, "reverted", 1)
+		return nil
+	}
+	MetricsProducedSlotCounter.Inc(1)
+	incValidatorCounter(*producer, "produced", 1)
+	for addr, count := range missed {
+		MetricsMissedSlotCounter.Inc(count)
+		incValidatorCounter(addr, "missed", count)
+	}
+	return nil
+}
+
+// recordEternal counts blocks after the last eternal one up to the new eternal
+// block as confirmed for their producers.
+func (dpos *Dpos) recordEternal(lastEternalHeight uint32, eternal *types.Block) error {
+
+	dpos.statsLock.Lock()
+	defer dpos.statsLock.Unlock()
+
+	confirmed := make(map[types.AddressHash]uint64)
+	for height := lastEternalHeight + 1; height <= eternal.Height; height++ {
+		block := eternal
+		if height < eternal.Height {
+			var err error
+			if block, err = dpos.chain.LoadBlockByHeight(height); err != nil {
+				return err
+			}
+		}
+		producer, err := recoverSigner(block.BlockHash(), block.Signature)
+		if err != nil {
+			return err
+		}
+		confirmed[*producer]++
+	}
+
+	batch := dpos.chain.DB().NewBatch()
+	defer batch.Close()
+	for addr, count := range confirmed {
+		addr := addr
+		stats, err := dpos.loadValidatorStats(&addr)
+		if err != nil {
+			return err
+		}
+		stats.Confirmed += count
+		if err := putValidatorStats(batch, stats); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for addr, count := range confirmed {
+		MetricsConfirmedBlockCounter.Inc(int64(count))
+		incValidatorCounter(addr, "confirmed", int64(count))
+	}
+	return nil
+}
+
+// loadValidatorStats loads stats of the validator, which are all zero if none
+// is recorded yet.
+func (dpos *Dpos) loadValidatorStats(addr *types.AddressHash) (*ValidatorStats, error) {
+
+	stats := &ValidatorStats{Addr: *addr}
+	value, err := dpos.chain.DB().Get(chain.ValidatorStatsKey(addr))
+	if err != nil || value == nil {
+		return stats, err
+	}
+	if err := stats.Unmarshal(value); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func putValidatorStats(batch storage.Batch, stats *ValidatorStats) error {
+	value, err := stats.Marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(chain.ValidatorStatsKey(&stats.Addr), value)
+	return nil
+}
+
+// Validators returns stats of producers of the current period in slot order,
+// followed by other validators ever recorded, ordered by address.
+func (dpos *Dpos) Validators() ([]*ValidatorStats, error) {
+
+	dpos.statsLock.Lock()
+	defer dpos.statsLock.Unlock()
+
+	var validators []*ValidatorStats
+	active := make(map[types.AddressHash]bool)
+	for _, period := range dpos.activePeriodContext().period {
+		addr := period.addr
+		stats, err := dpos.loadValidatorStats(&addr)
+		if err != nil {
+			return nil, err
+		}
+		stats.Active = true
+		active[addr] = true
+		validators = append(validators, stats)
+	}
+
+	// stats are keyed by address, so iterated in address order
+	var others []*ValidatorStats
+	iter := dpos.chain.DB().NewIterator(storage.BytesPrefix([]byte(chain.ValidatorStatsPrefix + "/")))
+	defer iter.Release()
+	for iter.Next() {
+		stats := new(ValidatorStats)
+		if err := stats.Unmarshal(iter.Value()); err != nil {
+			return nil, err
+		}
+		if !active[stats.Addr] {
+			others = append(others, stats)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return append(validators, others...), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/BOXFoundation/boxd/consensus/signer/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"google.golang.org/grpc"
+)
+
+// Define const
+const (
+	requestTimeout = 5 * time.Second
+)
+
+// RemoteSigner signs with the producer key held by a remote signer listening on
+// a unix socket.
+type RemoteSigner struct {
+	conn   *grpc.ClientConn
+	client signerpb.SignerClient
+	addr   *types.AddressPubKeyHash
+}
+
+// NewRemoteSigner connects to the remote signer listening on socket, and gets
+// the producer address from it.
+func NewRemoteSigner(socket string) (*RemoteSigner, error) {
+	conn, err := grpc.Dial(socket, grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	if err != nil {
+		return nil, err
+	}
+	client := signerpb.NewSignerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := client.PubKey(ctx, &signerpb.PubKeyRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	pubKey, err := crypto.PublicKeyFromBytes(resp.PubKey)
+	if err != nil {
+		conn.Close()
+		return nil, ErrInvalidPubKey
+	}
+	addr, err := types.NewAddressFromPubKey(pubKey)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &RemoteSigner{conn: conn, client: client, addr: addr}, nil
+}
+
+// Addr returns address of the producer
+func (s *RemoteSigner) Addr() string {
+	return s.addr.String()
+}
+
+// PubKeyHash returns public key hash of the producer
+func (s *RemoteSigner) PubKeyHash() []byte {
+	return s.addr.Hash()
+}
+
+// SignBlock requests the remote signer to sign hash of the block header produced
+// at height, which the remote signer computes itself
+func (s *RemoteSigner) SignBlock(height uint32, header *types.BlockHeader) ([]byte, error) {
+	data, err := header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := s.client.Sign(ctx, &signerpb.SignRequest{Height: height, Header: data})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// SignConfirmation requests the remote signer to sign the confirmation message
+// of the block of hash and timestamp to confirm it eternal
+func (s *RemoteSigner) SignConfirmation(timestamp int64, hash *crypto.HashType) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := s.client.Confirm(ctx, &signerpb.ConfirmRequest{Hash: hash[:], Timestamp: timestamp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/BOXFoundation/boxd/consensus/signer/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/wallet"
+	"google.golang.org/grpc"
+)
+
+// Server is the reference remote signer, which holds the producer key outside
+// the node and serves signing requests over a unix socket. It refuses to produce
+// two different blocks at the same height or time slot, or any block below the
+// last one produced. Confirmations of blocks eternal are not limited, since the
+// producer confirms blocks of others, which may be below its own.
+type Server struct {
+	account *wallet.Account
+	mark    *highWaterMark
+	lock    sync.Mutex
+	server  *grpc.Server
+}
+
+var _ signerpb.SignerServer = (*Server)(nil)
+
+// NewServer creates a remote signer with the unlocked account, whose high-water
+// mark is persisted in the file of statePath.
+func NewServer(account *wallet.Account, statePath string) (*Server, error) {
+	mark, err := loadHighWaterMark(statePath)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{account: account, mark: mark}, nil
+}
+
+// Serve listens on the unix socket and serves until Stop is called. The socket
+// is only accessible to the owner.
+func (s *Server) Serve(socket string) error {
+	// remove the socket left by the last run
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		listener.Close()
+		return err
+	}
+	s.server = grpc.NewServer()
+	signerpb.RegisterSignerServer(s.server, s)
+	logger.Infof("Remote signer of %s listening on %s", s.account.Addr(), socket)
+	return s.server.Serve(listener)
+}
+
+// Stop stops serving.
+func (s *Server) Stop() {
+	if s.server != nil {
+		s.server.GracefulStop()
+	}
+}
+
+// PubKey returns public key of the producer.
+func (s *Server) PubKey(ctx context.Context, req *signerpb.PubKeyRequest) (*signerpb.PubKeyResponse, error) {
+	return &signerpb.PubKeyResponse{PubKey: s.account.PublicKey()}, nil
+}
+
+// Sign signs hash of the block header produced at height if it does not
+// conflict with the high-water mark, which is moved to it before the signature
+// is returned. Both the hash and the time slot checked against the mark are
+// derived from the header, so that the signature is never over a block other
+// than the one checked.
+func (s *Server) Sign(ctx context.Context, req *signerpb.SignRequest) (*signerpb.SignResponse, error) {
+	header := new(types.BlockHeader)
+	if err := header.Unmarshal(req.Header); err != nil {
+		return nil, ErrInvalidHeader
+	}
+	hash, err := HeaderHash(header)
+	if err != nil {
+		return nil, ErrInvalidHeader
+	}
+	timestamp := header.TimeStamp
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.mark.check(req.Height, timestamp, hash); err != nil {
+		logger.Warnf("Refuse to sign block %s at height %d time %d, signed %s at height %d time %d",
+			hash, req.Height, timestamp, s.mark.hash, s.mark.height, s.mark.timestamp)
+		return nil, err
+	}
+	if err := s.mark.advance(req.Height, timestamp, hash); err != nil {
+		return nil, err
+	}
+	signature, err := crypto.SignCompact(s.account.PrivateKey(), hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.SignResponse{Signature: signature}, nil
+}
+
+// Confirm signs the confirmation message of the block of hash and timestamp to
+// confirm it eternal, see ConfirmationHash.
+func (s *Server) Confirm(ctx context.Context, req *signerpb.ConfirmRequest) (*signerpb.SignResponse, error) {
+	hash := new(crypto.HashType)
+	if err := hash.SetBytes(req.Hash); err != nil {
+		return nil, ErrInvalidHash
+	}
+	msg := ConfirmationHash(req.Timestamp, hash)
+	signature, err := crypto.SignCompact(s.account.PrivateKey(), msg[:])
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.SignResponse{Signature: signature}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/log"
+)
+
+var logger = log.NewLogger("signer") // logger
+
+// Define err message
+var (
+	ErrDoubleSign    = errors.New("Refuse to sign another block at a signed height or time slot")
+	ErrInvalidHash   = errors.New("Invalid hash to sign")
+	ErrInvalidHeader = errors.New("Invalid block header to sign")
+	ErrInvalidPubKey = errors.New("Invalid public key of remote signer")
+)
+
+// confirmationPrefix separates messages signed to confirm blocks eternal from
+// block hashes signed to produce blocks.
+var confirmationPrefix = []byte("BOX eternal block confirmation")
+
+// ConfirmationHash returns the message signed to confirm the block of hash and
+// timestamp eternal, which differs from the block hash signed by its producer,
+// so that a confirmation never passes as a block signature, or vice versa.
+func ConfirmationHash(timestamp int64, hash *crypto.HashType) *crypto.HashType {
+	var buf bytes.Buffer
+	buf.Write(confirmationPrefix)
+	binary.Write(&buf, binary.LittleEndian, timestamp)
+	buf.Write(hash[:])
+	msg := crypto.DoubleHashH(buf.Bytes())
+	return &msg
+}
+
+// HeaderHash returns hash of the block header, i.e., the block hash signed by
+// its producer.
+func HeaderHash(header *types.BlockHeader) (*crypto.HashType, error) {
+	data, err := header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.DoubleHashH(data)
+	return &hash, nil
+}
+
+// highWaterMark is the height, time slot and hash of the last block produced,
+// persisted before the signature is returned, so that a restarted signer never
+// signs a block below it or another block at it. Two blocks in the same slot
+// are evidence of equivocation even at different heights, so slots are marked
+// as well as heights.
+type highWaterMark struct {
+	height    uint32
+	timestamp int64
+	hash      *crypto.HashType
+	path      string
+}
+
+// highWaterMarkFile is the json format of high-water mark in file.
+type highWaterMarkFile struct {
+	Height    uint32 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Hash      string `json:"hash"`
+}
+
+func loadHighWaterMark(path string) (*highWaterMark, error) {
+	mark := &highWaterMark{path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return mark, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file := new(highWaterMarkFile)
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	mark.height = file.Height
+	mark.timestamp = file.Timestamp
+	mark.hash = new(crypto.HashType)
+	if err := mark.hash.SetString(file.Hash); err != nil {
+		return nil, err
+	}
+	return mark, nil
+}
+
+// check returns ErrDoubleSign if the block of hash at height and timestamp
+// conflicts with the mark.
+func (mark *highWaterMark) check(height uint32, timestamp int64, hash *crypto.HashType) error {
+	if mark.hash == nil {
+		return nil
+	}
+	if height < mark.height || timestamp < mark.timestamp {
+		return ErrDoubleSign
+	}
+	if (height == mark.height || timestamp == mark.timestamp) && *hash != *mark.hash {
+		return ErrDoubleSign
+	}
+	return nil
+}
+
+// advance moves the mark to the block of hash at height and timestamp, and
+// writes it to file atomically.
+func (mark *highWaterMark) advance(height uint32, timestamp int64, hash *crypto.HashType) error {
+	data, err := json.Marshal(&highWaterMarkFile{Height: height, Timestamp: timestamp, Hash: hash.String()})
+	if err != nil {
+		return err
+	}
+	tmp := mark.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, mark.path); err != nil {
+		return err
+	}
+	mark.height, mark.timestamp, mark.hash = height, timestamp, hash
+	return nil
+}
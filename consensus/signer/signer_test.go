@@ -0,0 +1,109 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BOXFoundation/boxd/consensus/signer/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/wallet"
+	"github.com/facebookgo/ensure"
+)
+
+func TestServer_Sign(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "signer")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+	state := filepath.Join(dir, "state.json")
+
+	account, err := wallet.NewAccountFromFile("../../keyfile/key1.keystore")
+	ensure.Nil(t, err)
+	ensure.Nil(t, account.UnlockWithPassphrase("1"))
+	server, err := NewServer(account, state)
+	ensure.Nil(t, err)
+
+	verify := func(hash *crypto.HashType, signature []byte) {
+		pubKey, ok := crypto.RecoverCompact(hash[:], signature)
+		ensure.True(t, ok)
+		ensure.DeepEqual(t, pubKey.Serialize(), account.PublicKey())
+	}
+	// blocks a and b differ in parent, and the time slot is of the header signed
+	sign := func(server *Server, height uint32, timestamp int64, prev crypto.HashType) error {
+		header := &types.BlockHeader{PrevBlockHash: prev, TimeStamp: timestamp}
+		data, err := header.Marshal()
+		ensure.Nil(t, err)
+		resp, err := server.Sign(context.Background(), &signerpb.SignRequest{Height: height, Header: data})
+		if err != nil {
+			return err
+		}
+		hash, err := HeaderHash(header)
+		ensure.Nil(t, err)
+		verify(hash, resp.Signature)
+		return nil
+	}
+	a, b := crypto.HashType{0x01}, crypto.HashType{0x02}
+	_, err = server.Sign(context.Background(), &signerpb.SignRequest{Height: 10, Header: []byte{0xff}})
+	ensure.DeepEqual(t, err, ErrInvalidHeader)
+	ensure.Nil(t, sign(server, 10, 100, a))
+	// the same block may be signed again
+	ensure.Nil(t, sign(server, 10, 100, a))
+	ensure.DeepEqual(t, sign(server, 10, 101, b), ErrDoubleSign)
+	ensure.DeepEqual(t, sign(server, 9, 101, b), ErrDoubleSign)
+	// another block in the same slot on another fork
+	ensure.DeepEqual(t, sign(server, 11, 100, b), ErrDoubleSign)
+	ensure.DeepEqual(t, sign(server, 11, 99, b), ErrDoubleSign)
+	ensure.Nil(t, sign(server, 11, 101, b))
+
+	// the high-water mark survives restart
+	restarted, err := NewServer(account, state)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, sign(restarted, 11, 101, a), ErrDoubleSign)
+	ensure.DeepEqual(t, sign(restarted, 12, 101, a), ErrDoubleSign)
+	ensure.Nil(t, sign(restarted, 11, 101, b))
+}
+
+func TestServer_Confirm(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "signer")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	account, err := wallet.NewAccountFromFile("../../keyfile/key1.keystore")
+	ensure.Nil(t, err)
+	ensure.Nil(t, account.UnlockWithPassphrase("1"))
+	server, err := NewServer(account, filepath.Join(dir, "state.json"))
+	ensure.Nil(t, err)
+
+	sign := func(height uint32, timestamp int64) error {
+		data, err := (&types.BlockHeader{TimeStamp: timestamp}).Marshal()
+		ensure.Nil(t, err)
+		_, err = server.Sign(context.Background(), &signerpb.SignRequest{Height: height, Header: data})
+		return err
+	}
+	// produce a block, and then confirm one below it, which is not limited by
+	// the high-water mark
+	a := crypto.HashType{0x01}
+	ensure.Nil(t, sign(11, 101))
+	resp, err := server.Confirm(context.Background(), &signerpb.ConfirmRequest{Hash: a[:], Timestamp: 90})
+	ensure.Nil(t, err)
+	pubKey, ok := crypto.RecoverCompact(ConfirmationHash(90, &a)[:], resp.Signature)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, pubKey.Serialize(), account.PublicKey())
+
+	// over a message apart from block hashes, so it is no block signature
+	pubKey, ok = crypto.RecoverCompact(a[:], resp.Signature)
+	ensure.True(t, !ok || !bytes.Equal(pubKey.Serialize(), account.PublicKey()))
+
+	// confirmations do not move the mark either
+	ensure.Nil(t, sign(12, 102))
+}
@@ -35,8 +35,10 @@ const (
 
 	MaxTimeOffsetSeconds = 2 * 60 * 60
 	MaxBlockSize         = 32000000
+	MaxTxsPerBlock       = 100000
 	CoinbaseLib          = 100
-	maxBlockSigOpCnt     = 80000
+	MaxBlockSigOpCnt     = 80000
+	MaxTxSigOpCnt        = MaxBlockSigOpCnt / 5
 	LockTimeThreshold    = 5e8 // Tue Nov 5 00:53:20 1985 UTC
 	PeriodDuration       = 3600 * 24 * 100 / 5
 
@@ -73,6 +75,11 @@ type BlockChain struct {
 	orphanBlockHashToChildren map[crypto.HashType][]*types.Block
 	syncManager               types.SyncManager
 	filterHolder              BloomFilterHolder
+	snapshotBlock             *types.Block
+	snapshot                  *UtxoSnapshot
+	snapshotMtx               sync.Mutex
+	// commitment to utxo set as of tail
+	utxoCommitment *UtxoCommitment
 }
 
 // UpdateMsg sent from blockchain to, e.g., mempool
@@ -109,6 +116,11 @@ func NewBlockChain(parent goprocess.Process, notifiee p2p.Net, db storage.Storag
 		return nil, err
 	}
 
+	if b.snapshotBlock, err = b.loadSnapshotBlock(); err != nil {
+		logger.Error("Failed to load snapshot block ", err)
+		return nil, err
+	}
+
 	if b.eternal, err = b.loadEternalBlock(); err != nil {
 		logger.Error("Failed to load eternal block ", err)
 		return nil, err
@@ -120,6 +132,16 @@ func NewBlockChain(parent goprocess.Process, notifiee p2p.Net, db storage.Storag
 	}
 	b.LongestChainHeight = b.tail.Height
 
+	if err = b.recoverTail(); err != nil {
+		logger.Error("Failed to recover tail block ", err)
+		return nil, err
+	}
+
+	if b.utxoCommitment, err = b.loadTailUtxoCommitment(); err != nil {
+		logger.Error("Failed to load utxo commitment ", err)
+		return nil, err
+	}
+
 	if err = b.loadFilters(); err != nil {
 		logger.Error("Fail to load filters", err)
 		return nil, err
@@ -134,6 +156,11 @@ func (chain *BlockChain) Setup(consensus types.Consensus, syncManager types.Sync
 	chain.syncManager = syncManager
 }
 
+// Consensus returns the consensus the chain is set up with.
+func (chain *BlockChain) Consensus() types.Consensus {
+	return chain.consensus
+}
+
 // implement interface service.Server
 var _ service.Server = (*BlockChain)(nil)
 
@@ -347,17 +374,9 @@ func (chain *BlockChain) tryAcceptBlock(block *types.Block) error {
 	}
 
 	// Case 3): Extended side chain is longer than the main chain and becomes the new main chain.
+	// This block is the end of the best chain once reorganized.
 	logger.Infof("REORGANIZE: Block %v is causing a reorganization.", blockHash.String())
-	if err := chain.reorganize(block); err != nil {
-		return err
-	}
-
-	// This block is now the end of the best chain.
-	if err := chain.SetTailBlock(block); err != nil {
-		logger.Errorf("Failed to set tail block. Hash: %s, Height: %d, Err: %s", block.BlockHash().String(), block.Height, err.Error())
-		return err
-	}
-	return nil
+	return chain.reorganize(block)
 }
 
 func (chain *BlockChain) addOrphanBlock(orphan *types.Block, orphanHash crypto.HashType, parentHash crypto.HashType) {
@@ -404,68 +423,92 @@ func (chain *BlockChain) getParentBlock(block *types.Block) *types.Block {
 	if block.BlockHash().IsEqual(chain.genesis.BlockHash()) {
 		return chain.genesis
 	}
-	if target, ok := chain.cache.Get(block.Header.PrevBlockHash); ok {
-		return target.(*types.Block)
-	}
-	target, err := chain.LoadBlockByHash(block.Header.PrevBlockHash)
+	target, err := chain.FindBlock(block.Header.PrevBlockHash)
 	if err != nil {
 		return nil
 	}
 	return target
 }
 
+// FindBlock finds block by hash on main chain or side chains, whose blocks are
+// only cached.
+func (chain *BlockChain) FindBlock(hash crypto.HashType) (*types.Block, error) {
+	if target, ok := chain.cache.Get(hash); ok {
+		return target.(*types.Block), nil
+	}
+	return chain.LoadBlockByHash(hash)
+}
+
 // tryConnectBlockToMainChain tries to append the passed block to the main chain.
-// It enforces multiple rules such as double spends and script verification.
 func (chain *BlockChain) tryConnectBlockToMainChain(block *types.Block) error {
 	utxoSet := NewUtxoSet()
 	if err := utxoSet.LoadBlockUtxos(block, chain.db); err != nil {
 		return err
 	}
 
+	utxoCommitment, err := chain.checkConnectBlock(block, utxoSet)
+	if err != nil {
+		return err
+	}
+
+	return chain.applyBlock(block, utxoSet, utxoCommitment)
+}
+
+// checkConnectBlock checks if the passed block can be connected to tail with utxos
+// it spends in utxoSet, and returns the utxo commitment after it is connected.
+// It enforces multiple rules such as double spends, script verification and coinbase value.
+func (chain *BlockChain) checkConnectBlock(block *types.Block, utxoSet *UtxoSet) (*UtxoCommitment, error) {
 	// Validate scripts here before utxoSet is updated; otherwise it may fail mistakenly
 	if err := validateBlockScripts(utxoSet, block); err != nil {
-		return err
+		return nil, err
 	}
 
 	transactions := block.Txs
 	// Perform several checks on the inputs for each transaction.
 	// Also accumulate the total fees.
 	var totalFees uint64
+	totalSigOpCnt := 0
 	for _, tx := range transactions {
 		txFee, err := ValidateTxInputs(utxoSet, tx, block.Height)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		// Signature operations in p2sh redeem scripts are only known with utxos spent,
+		// so the block sigop budget checked in validateBlock is enforced again here.
+		totalSigOpCnt += CountSigOps(tx) + CountP2SHSigOps(utxoSet, tx)
+		if totalSigOpCnt > MaxBlockSigOpCnt {
+			logger.Errorf("block contains too many signature operations - got %v, max %v",
+				totalSigOpCnt, MaxBlockSigOpCnt)
+			return nil, core.ErrTooManySigOps
 		}
 
 		// Check for overflow.
 		lastTotalFees := totalFees
 		totalFees += txFee
 		if totalFees < lastTotalFees {
-			return core.ErrBadFees
+			return nil, core.ErrBadFees
 		}
 	}
 
 	// Ensure coinbase does not output more than block reward.
-	var totalCoinbaseOutput uint64
-	for _, txOut := range transactions[0].Vout {
-		totalCoinbaseOutput += txOut.Value
-	}
-	expectedCoinbaseOutput := CalcBlockSubsidy(block.Height) + totalFees
-	if totalCoinbaseOutput > expectedCoinbaseOutput {
-		logger.Errorf("coinbase transaction for block pays %v which is more than expected value of %v",
-			totalCoinbaseOutput, expectedCoinbaseOutput)
-		return core.ErrBadCoinbaseValue
+	if err := checkCoinbaseValue(block, totalFees); err != nil {
+		return nil, err
 	}
 
-	if err := chain.applyBlock(block, utxoSet); err != nil {
-		return err
+	// Ensure the utxo set after the block is connected matches the commitment in header.
+	utxoCommitment, err := chain.calcUtxoCommitment(block, utxoSet)
+	if err != nil {
+		return nil, err
 	}
-	if err := chain.SetTailBlock(block); err != nil {
-		logger.Errorf("Failed to set tail block. Hash: %s, Height: %d, Err: %s", block.BlockHash().String(), block.Height, err.Error())
-		return err
+	if block.Height < UtxoCommitmentHeight {
+		if block.Header.UtxoRoot != zeroHash {
+			return nil, core.ErrBadUtxoRoot
+		}
+	} else if err := checkUtxoRoot(block, utxoCommitment); err != nil {
+		return nil, err
 	}
-
-	return nil
+	return utxoCommitment, nil
 }
 
 // findFork returns final common block between the passed block and the main chain (i.e., fork point)
@@ -513,33 +556,106 @@ func (chain *BlockChain) findFork(block *types.Block) (*types.Block, []*types.Bl
 	return mainChainBlock, detachBlocks, attachBlocks
 }
 
+// revertBlock disconnects tail block from main chain, and its parent becomes tail.
+// All state changes are committed at once, so that db is never left with the block
+// half disconnected.
 func (chain *BlockChain) revertBlock(block *types.Block) error {
 
+	parent, err := chain.LoadBlockByHash(block.Header.PrevBlockHash)
+	if err != nil {
+		return err
+	}
+	// utxo commitment is rolled back to the one as of parent
+	utxoCommitment, err := chain.loadUtxoCommitment(&block.Header.PrevBlockHash)
+	if err != nil {
+		return err
+	}
+	if utxoCommitment == nil {
+		// parent is connected before utxo commitment and has none stored
+		if utxoCommitment, err = chain.revertUtxoCommitment(block); err != nil {
+			return err
+		}
+	}
+
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+	if err := chain.unstoreBlock(batch, block); err != nil {
+		return err
+	}
+	switchPeriod, err := chain.consensus.RevertPeriodContext(block, batch)
+	if err != nil {
+		return err
+	}
+	if err := chain.storeTailBlock(batch, parent); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	if switchPeriod != nil {
+		switchPeriod()
+	}
+	chain.utxoCommitment = utxoCommitment
+	chain.filterHolder.ResetFilters(block.Height)
+	chain.heightToBlock.Remove(block.Height)
+	chain.setTailBlock(parent)
+
+	return chain.notifyBlockConnectionUpdate(block, false)
+}
+
+// unstoreBlock adds to batch removal of the block on top of main chain, i.e., its
+// utxo changes, body, utxo commitment and tx index.
+func (chain *BlockChain) unstoreBlock(batch storage.Batch, block *types.Block) error {
+
 	utxoSet := NewUtxoSet()
 	if err := utxoSet.LoadBlockUtxos(block, chain.db); err != nil {
 		return err
 	}
+	if err := chain.loadSpentUtxos(utxoSet); err != nil {
+		return err
+	}
 	if err := utxoSet.RevertBlock(block); err != nil {
 		return err
 	}
-	// save utxoset to database
-	if err := utxoSet.WriteUtxoSetToDB(chain.db); err != nil {
+	if err := utxoSet.WriteUtxoSetToDB(batch); err != nil {
 		return err
 	}
 
-	chain.db.Del(BlockKey(block.BlockHash()))
+	hash := block.BlockHash()
+	batch.Del(UtxoCommitmentKey(hash))
+	batch.Del(BlockKey(hash))
+	batch.Del(BlockHashKey(block.Height))
+	return chain.delTxIndex(batch, block)
+}
 
-	chain.filterHolder.ResetFilters(block.Height)
+// loadSpentUtxos loads utxos missing in utxoSet, which are spent and removed from
+// db, from txs creating them on main chain.
+func (chain *BlockChain) loadSpentUtxos(utxoSet *UtxoSet) error {
 
-	// save tx index
-	if err := chain.DelTxIndex(block); err != nil {
-		return err
+	for outPoint, utxoWrap := range utxoSet.utxoMap {
+		if utxoWrap != nil {
+			continue
+		}
+		tx, height, err := chain.loadTxAndHeight(outPoint.Hash)
+		if err != nil {
+			return err
+		}
+		if int(outPoint.Index) >= len(tx.Vout) {
+			return core.ErrTxOutIndexOob
+		}
+		utxoSet.utxoMap[outPoint] = &types.UtxoWrap{
+			Output:      tx.Vout[outPoint.Index],
+			BlockHeight: height,
+			IsCoinBase:  IsCoinBase(tx),
+			IsSpent:     true,
+		}
 	}
-
-	return chain.notifyBlockConnectionUpdate(block, false)
+	return nil
 }
 
-func (chain *BlockChain) applyBlock(block *types.Block, utxoSet *UtxoSet) error {
+func (chain *BlockChain) applyBlock(block *types.Block, utxoSet *UtxoSet,
+	utxoCommitment *UtxoCommitment) error {
 
 	if utxoSet == nil {
 		utxoSet = NewUtxoSet()
@@ -547,31 +663,58 @@ func (chain *BlockChain) applyBlock(block *types.Block, utxoSet *UtxoSet) error
 			return err
 		}
 	}
+	// blocks attached in reorganization are not checked yet
+	if utxoCommitment == nil {
+		var err error
+		if utxoCommitment, err = chain.checkConnectBlock(block, utxoSet); err != nil {
+			return err
+		}
+	}
+	// all state changes are committed at once, so that db is never left with the
+	// block half connected
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+	// candidate context and period context are checked and updated before anything else is written
+	if err := chain.consensus.StoreCandidateContext(block, utxoSet.GetUtxos(), batch); err != nil {
+		return err
+	}
+	switchPeriod, err := chain.consensus.UpdatePeriodContext(block, batch)
+	if err != nil {
+		return err
+	}
 	if err := utxoSet.ApplyBlock(block); err != nil {
 		return err
 	}
-	// save utxoset to database
-	if err := utxoSet.WriteUtxoSetToDB(chain.db); err != nil {
+	// utxos spent are kept for filter, since utxo set frees them once written
+	utxoMap := utxoSet.utxoMap
+	if err := utxoSet.WriteUtxoSetToDB(batch); err != nil {
 		return err
 	}
-
-	if err := chain.StoreBlockToDb(block); err != nil {
+	if err := chain.storeBlock(batch, block); err != nil {
 		return err
 	}
-
-	if err := chain.filterHolder.AddFilter(block.Height, *block.BlockHash(), chain.DB(), func() bloom.Filter {
-		return GetFilterForTransactionScript(block, utxoSet.utxoMap)
-	}); err != nil {
+	if err := chain.storeUtxoCommitment(batch, block.BlockHash(), utxoCommitment); err != nil {
 		return err
 	}
-
-	// save candidate context
-	if err := chain.consensus.StoreCandidateContext(block.BlockHash()); err != nil {
+	if err := chain.writeTxIndex(batch, block); err != nil {
+		return err
+	}
+	if err := chain.storeTailBlock(batch, block); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
 		return err
 	}
 
-	// save tx index
-	if err := chain.WriteTxIndex(block); err != nil {
+	if switchPeriod != nil {
+		switchPeriod()
+	}
+	chain.utxoCommitment = utxoCommitment
+	chain.setTailBlock(block)
+	// filter is derived from blocks, and rebuilt on startup if missing in db
+	if err := chain.filterHolder.AddFilter(block.Height, *block.BlockHash(), chain.DB(), func() bloom.Filter {
+		return GetFilterForTransactionScript(block, utxoMap)
+	}); err != nil {
 		return err
 	}
 
@@ -603,7 +746,7 @@ func (chain *BlockChain) reorganize(block *types.Block) error {
 	// From fork to tip, not including fork
 	for blockIdx := len(attachBlocks) - 1; blockIdx >= 0; blockIdx-- {
 		attachBlock := attachBlocks[blockIdx]
-		if err := chain.applyBlock(attachBlock, nil); err != nil {
+		if err := chain.applyBlock(attachBlock, nil, nil); err != nil {
 			return err
 		}
 	}
@@ -614,11 +757,21 @@ func (chain *BlockChain) reorganize(block *types.Block) error {
 
 // StoreTailBlock store tail block to db.
 func (chain *BlockChain) StoreTailBlock(block *types.Block) error {
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+	if err := chain.storeTailBlock(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (chain *BlockChain) storeTailBlock(batch storage.Batch, block *types.Block) error {
 	data, err := block.Marshal()
 	if err != nil {
 		return err
 	}
-	return chain.db.Put(TailKey, data)
+	batch.Put(TailKey, data)
+	return nil
 }
 
 // TailBlock return chain tail block.
@@ -660,21 +813,26 @@ func (chain *BlockChain) ListAllUtxos() (map[types.OutPoint]*types.UtxoWrap, err
 
 // LoadUtxoByAddress list all the available utxos owned by an address, including token utxos
 func (chain *BlockChain) LoadUtxoByAddress(addr types.Address) (map[types.OutPoint]*types.UtxoWrap, error) {
-	payToPubKeyHashScript := *script.PayToPubKeyHashScript(addr.Hash())
-	blockHashes := chain.filterHolder.ListMatchedBlockHashes(payToPubKeyHashScript)
+	return loadUtxoByAddress(chain.db, chain.filterHolder, addr)
+}
+
+// loadUtxoByAddress replays blocks in db whose filters match the address
+func loadUtxoByAddress(db storage.Reader, filters BloomFilterHolder, addr types.Address) (map[types.OutPoint]*types.UtxoWrap, error) {
+	addrScript := *script.PayToAddressScript(addr)
+	blockHashes := filters.ListMatchedBlockHashes(addrScript)
 	utxos := make(map[types.OutPoint]*types.UtxoWrap)
 	utxoSet := NewUtxoSet()
 	for _, hash := range blockHashes {
-		block, err := chain.LoadBlockByHash(hash)
+		block, err := loadBlock(db, hash)
 		if err != nil {
 			return nil, err
 		}
-		if err = utxoSet.ApplyBlockWithScriptFilter(block, payToPubKeyHashScript); err != nil {
+		if err = utxoSet.ApplyBlockWithScriptFilter(block, addrScript); err != nil {
 			return nil, err
 		}
 	}
 	for key, value := range utxoSet.utxoMap {
-		if util.IsPrefixed(value.Output.ScriptPubKey, payToPubKeyHashScript) && !value.IsSpent {
+		if util.IsPrefixed(value.Output.ScriptPubKey, addrScript) && !value.IsSpent {
 			utxos[key] = value
 		}
 	}
@@ -702,7 +860,12 @@ func (chain *BlockChain) SetTailBlock(tail *types.Block) error {
 	if err := chain.StoreTailBlock(tail); err != nil {
 		return err
 	}
+	chain.setTailBlock(tail)
+	return nil
+}
 
+// setTailBlock sets chain tail block in memory, which is already stored.
+func (chain *BlockChain) setTailBlock(tail *types.Block) {
 	chain.repeatedMintCache.Add(tail.Header.TimeStamp, tail)
 	chain.heightToBlock.Add(tail.Height, tail)
 	chain.LongestChainHeight = tail.Height
@@ -711,7 +874,6 @@ func (chain *BlockChain) SetTailBlock(tail *types.Block) error {
 
 	metrics.MetricsBlockHeightGauge.Update(int64(tail.Height))
 	metrics.MetricsBlockTailHashGauge.Update(int64(util.HashBytes(tail.BlockHash().GetBytes())))
-	return nil
 }
 
 func (chain *BlockChain) loadGenesis() (*types.Block, error) {
@@ -775,10 +937,58 @@ func (chain *BlockChain) loadTailBlock() (*types.Block, error) {
 	return &GenesisBlock, nil
 }
 
+// recoverTail detects a block half connected on top of tail, which is left in db by
+// a crash amid connecting it before state changes were committed at once, and rolls
+// it back. Such a block is stored in db along with its utxo changes, and the tail
+// is written last.
+func (chain *BlockChain) recoverTail() error {
+
+	data, err := chain.db.Get(BlockHashKey(chain.tail.Height + 1))
+	if err != nil || data == nil {
+		return err
+	}
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+
+	hash := new(crypto.HashType)
+	copy(hash[:], data)
+	block, err := chain.LoadBlockByHash(*hash)
+	if err == core.ErrBlockIsNil || (err == nil && block.Header.PrevBlockHash != *chain.tail.BlockHash()) {
+		// stale entry of a block not extending tail, which is simply dropped
+		batch.Del(BlockHashKey(chain.tail.Height + 1))
+		return batch.Write()
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Warnf("Roll back block %s at height %d half connected on top of tail", hash, block.Height)
+	if err := chain.unstoreBlock(batch, block); err != nil {
+		return err
+	}
+	// the current period context is the one tail commits to
+	if chain.tail.Header.PeriodHash == zeroHash {
+		batch.Del(PeriodKey)
+	} else {
+		period, err := chain.db.Get(PeriodContextKey(&chain.tail.Header.PeriodHash))
+		if err != nil {
+			return err
+		}
+		if period != nil {
+			batch.Put(PeriodKey, period)
+		}
+	}
+	return batch.Write()
+}
+
 // LoadBlockByHash load block by hash from db.
 func (chain *BlockChain) LoadBlockByHash(hash crypto.HashType) (*types.Block, error) {
+	return loadBlock(chain.db, hash)
+}
+
+func loadBlock(db storage.Reader, hash crypto.HashType) (*types.Block, error) {
 
-	blockBin, err := chain.db.Get(BlockKey(&hash))
+	blockBin, err := db.Get(BlockKey(&hash))
 	if err != nil {
 		return nil, err
 	}
@@ -823,7 +1033,13 @@ func (chain *BlockChain) LoadBlockByHeight(height uint32) (*types.Block, error)
 func (chain *BlockChain) StoreBlockToDb(block *types.Block) error {
 	batch := chain.db.NewBatch()
 	defer batch.Close()
+	if err := chain.storeBlock(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
 
+func (chain *BlockChain) storeBlock(batch storage.Batch, block *types.Block) error {
 	hash := block.BlockHash()
 	batch.Put(BlockHashKey(block.Height), hash[:])
 
@@ -832,43 +1048,54 @@ func (chain *BlockChain) StoreBlockToDb(block *types.Block) error {
 		return err
 	}
 	batch.Put(BlockKey(hash), data)
-
-	return batch.Write()
+	return nil
 }
 
 // LoadTxByHash load transaction with hash.
 func (chain *BlockChain) LoadTxByHash(hash crypto.HashType) (*types.Transaction, error) {
+	tx, _, err := chain.loadTxAndHeight(hash)
+	return tx, err
+}
+
+// loadTxAndHeight load transaction with hash and height of the block containing it.
+func (chain *BlockChain) loadTxAndHeight(hash crypto.HashType) (*types.Transaction, uint32, error) {
 	txIndex, err := chain.db.Get(TxIndexKey(&hash))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	height, idx, err := UnmarshalTxIndex(txIndex)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	block, err := chain.LoadBlockByHeight(height)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	tx := block.Txs[idx]
 	target, err := tx.TxHash()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if *target == hash {
-		return tx, nil
+		return tx, height, nil
 	}
 	logger.Errorf("Error reading tx hash, expect: %s got: %s", hash.String(), target.String())
-	return nil, errors.New("Failed to load tx with hash")
+	return nil, 0, errors.New("Failed to load tx with hash")
 }
 
 // WriteTxIndex builds tx index in block
 func (chain *BlockChain) WriteTxIndex(block *types.Block) error {
 	batch := chain.db.NewBatch()
 	defer batch.Close()
+	if err := chain.writeTxIndex(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
 
+func (chain *BlockChain) writeTxIndex(batch storage.Batch, block *types.Block) error {
 	for idx, tx := range block.Txs {
 		tiBuf, err := MarshalTxIndex(block.Height, uint32(idx))
 		if err != nil {
@@ -880,15 +1107,20 @@ func (chain *BlockChain) WriteTxIndex(block *types.Block) error {
 		}
 		batch.Put(TxIndexKey(txHash), tiBuf)
 	}
-
-	return batch.Write()
+	return nil
 }
 
 // DelTxIndex deletes tx index in block
 func (chain *BlockChain) DelTxIndex(block *types.Block) error {
 	batch := chain.db.NewBatch()
 	defer batch.Close()
+	if err := chain.delTxIndex(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
 
+func (chain *BlockChain) delTxIndex(batch storage.Batch, block *types.Block) error {
 	for _, tx := range block.Txs {
 		txHash, err := tx.TxHash()
 		if err != nil {
@@ -896,8 +1128,7 @@ func (chain *BlockChain) DelTxIndex(block *types.Block) error {
 		}
 		batch.Del(TxIndexKey(txHash))
 	}
-
-	return batch.Write()
+	return nil
 }
 
 // LocateForkPointAndFetchHeaders return block headers when get locate fork point request for sync service.
@@ -1010,8 +1241,9 @@ func GetFilterForTransactionScript(block *types.Block, utxoUsed map[types.OutPoi
 	}
 	for _, scriptBytes := range vout {
 		scriptPubKey := script.NewScriptFromBytes(scriptBytes)
-		if scriptPubKey.IsTokenIssue() || scriptPubKey.IsTokenTransfer() {
-			// token output: only store the p2pkh prefix part so we can retrieve it later
+		if scriptPubKey.IsTokenIssue() || scriptPubKey.IsTokenTransfer() ||
+			scriptPubKey.IsVote() || scriptPubKey.IsUnbond() {
+			// token or vote output: only store the p2pkh prefix part so we can retrieve it later
 			scriptBytes = *scriptPubKey.P2PKHScriptPrefix()
 		}
 		filter.Add(scriptBytes)
@@ -1025,6 +1257,14 @@ func (chain *BlockChain) loadFilters() error {
 	var utxoSet *UtxoSet
 	for ; i <= chain.LongestChainHeight; i++ {
 		block, err := chain.LoadBlockByHeight(i)
+		if err == core.ErrBlockIsNil && i < chain.snapshotBlock.Height {
+			// blocks before the snapshot block are not stored
+			if err := chain.filterHolder.AddFilter(i, crypto.HashType{}, chain.DB(), emptyFilter); err != nil {
+				logger.Error("Failed to addFilter", err)
+				return err
+			}
+			continue
+		}
 		if err != nil {
 			logger.Error("Error try to load block at height", i, err)
 			return core.ErrWrongBlockHeight
@@ -1047,8 +1287,8 @@ func (chain *BlockChain) loadFilters() error {
 
 // GetTransactionsByAddr search the main chain about transaction relate to give address
 func (chain *BlockChain) GetTransactionsByAddr(addr types.Address) ([]*types.Transaction, error) {
-	payToPubKeyHashScript := *script.PayToPubKeyHashScript(addr.Hash())
-	hashes := chain.filterHolder.ListMatchedBlockHashes(payToPubKeyHashScript)
+	addrScript := *script.PayToAddressScript(addr)
+	hashes := chain.filterHolder.ListMatchedBlockHashes(addrScript)
 	utxoSet := NewUtxoSet()
 	var txs []*types.Transaction
 	for _, hash := range hashes {
@@ -1059,7 +1299,7 @@ func (chain *BlockChain) GetTransactionsByAddr(addr types.Address) ([]*types.Tra
 		for _, tx := range block.Txs {
 			isRelated := false
 			for index, vout := range tx.Vout {
-				if bytes.Equal(vout.ScriptPubKey, payToPubKeyHashScript) {
+				if bytes.Equal(vout.ScriptPubKey, addrScript) {
 					utxoSet.AddUtxo(tx, uint32(index), block.Height)
 					isRelated = true
 				}
@@ -5,11 +5,16 @@
 package chain
 
 import (
+	"bytes"
+	"math"
 	"testing"
 
 	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/script"
+	"github.com/BOXFoundation/boxd/storage"
 	_ "github.com/BOXFoundation/boxd/storage/memdb"
 	"github.com/facebookgo/ensure"
 )
@@ -19,8 +24,15 @@ var (
 	_, publicKey, _ = crypto.NewKeyPair()
 	minerAddr, _    = types.NewAddressFromPubKey(publicKey)
 	blockChain      = NewTestBlockChain()
+	// utxo commitments as of test blocks, to set utxo roots of their children
+	utxoCommitments = map[crypto.HashType]*UtxoCommitment{GenesisHash: NewUtxoCommitment()}
 )
 
+// test blocks commit to utxos from genesis
+func init() {
+	UtxoCommitmentHeight = 0
+}
+
 // Test if appending a slice while looping over it using index works.
 // Just to make sure compiler is not optimizing len() condition away.
 func TestAppendInLoop(t *testing.T) {
@@ -44,12 +56,25 @@ func TestAppendInLoop(t *testing.T) {
 func nextBlock(parentBlock *types.Block) *types.Block {
 	newBlock := types.NewBlock(parentBlock)
 
-	coinbaseTx, _ := CreateCoinbaseTx(minerAddr.Hash(), parentBlock.Height+1)
+	coinbaseTx, _ := CreateCoinbaseTx(minerAddr.Hash(), parentBlock.Height+1, 0)
 	newBlock.Txs = []*types.Transaction{coinbaseTx}
 	newBlock.Header.TxsRoot = *CalcTxsHash(newBlock.Txs)
+	setUtxoRoot(newBlock)
 	return newBlock
 }
 
+// set utxo root of a block spending no utxos following a test block
+func setUtxoRoot(block *types.Block) {
+	parent, ok := utxoCommitments[block.Header.PrevBlockHash]
+	if !ok {
+		return
+	}
+	c := parent.Copy()
+	c.ApplyBlock(block, NewUtxoSet())
+	block.Header.UtxoRoot = *c.Hash()
+	utxoCommitments[*block.BlockHash()] = c
+}
+
 func getTailBlock() *types.Block {
 	tailBlock, _ := blockChain.loadTailBlock()
 	return tailBlock
@@ -148,6 +173,13 @@ func TestBlockProcessing(t *testing.T) {
 	b11 := nextBlock(b10)
 	verifyProcessBlock(t, b11, nil, 11, b11)
 
+	// Create block with wrong utxo root
+	b12 := nextBlock(b11)
+	b12.Header.UtxoRoot = crypto.HashType{}
+	b12.Hash = nil
+	verifyProcessBlock(t, b12, core.ErrBadUtxoRoot, 11, b11)
+	ensure.Nil(t, blockChain.VerifyUtxoSet())
+
 	// Double spend
 
 	// Create a fork that ends with block that generates too much coinbase
@@ -185,3 +217,255 @@ func TestBlockChain_WriteDelTxIndex(t *testing.T) {
 	_, err = blockChain.LoadTxByHash(*txhash)
 	ensure.NotNil(t, err)
 }
+
+// generate a child block whose coinbase has an extra output locked by scriptPubKey
+func TestRecoverTail(t *testing.T) {
+	b0 := getTailBlock()
+	b1 := nextBlock(b0)
+
+	// crash amid connecting b1 with its utxos and body written, but not tail
+	utxoSet := NewUtxoSet()
+	ensure.Nil(t, utxoSet.ApplyBlock(b1))
+	batch := blockChain.db.NewBatch()
+	ensure.Nil(t, utxoSet.WriteUtxoSetToDB(batch))
+	ensure.Nil(t, batch.Write())
+	batch.Close()
+	ensure.Nil(t, blockChain.StoreBlockToDb(b1))
+	ensure.Nil(t, blockChain.WriteTxIndex(b1))
+
+	// b1 is rolled back
+	ensure.Nil(t, blockChain.recoverTail())
+	_, err := blockChain.LoadBlockByHash(*b1.BlockHash())
+	ensure.DeepEqual(t, err, core.ErrBlockIsNil)
+	coinbaseHash, _ := b1.Txs[0].TxHash()
+	utxo, err := blockChain.db.Get(UtxoKey(&types.OutPoint{Hash: *coinbaseHash, Index: 0}))
+	ensure.Nil(t, err)
+	ensure.True(t, utxo == nil)
+	ensure.Nil(t, blockChain.VerifyUtxoSet())
+
+	// and connected again
+	verifyProcessBlock(t, b1, nil, b1.Height, b1)
+	ensure.Nil(t, blockChain.recoverTail())
+	ensure.DeepEqual(t, getTailBlock(), b1)
+}
+
+func TestRevertBlockBeforeUtxoCommitment(t *testing.T) {
+	b0 := getTailBlock()
+	b1 := nextBlock(b0)
+	verifyProcessBlock(t, b1, nil, b1.Height, b1)
+
+	// b0 is connected before utxo commitment, which is not stored
+	expected, err := blockChain.loadUtxoCommitment(b0.BlockHash())
+	ensure.Nil(t, err)
+	ensure.Nil(t, blockChain.db.Del(UtxoCommitmentKey(b0.BlockHash())))
+	ensure.Nil(t, blockChain.revertBlock(b1))
+	ensure.DeepEqual(t, getTailBlock(), b0)
+	ensure.DeepEqual(t, blockChain.utxoCommitment.Hash(), expected.Hash())
+	ensure.Nil(t, blockChain.VerifyUtxoSet())
+
+	// blocks below activation height leave utxo root zero
+	UtxoCommitmentHeight = b0.Height + 2
+	defer func() { UtxoCommitmentHeight = 0 }()
+	b1A := nextBlockWithCoinbaseOutput(b0, []byte{0x01})
+	verifyProcessBlock(t, b1A, nil, b1A.Height, b1A)
+	utxoCommitments[*b1A.BlockHash()] = blockChain.utxoCommitment.Copy()
+	ensure.Nil(t, blockChain.VerifyUtxoSet())
+}
+
+func TestStateSnapshot(t *testing.T) {
+	b0 := getTailBlock()
+	snapshot, err := blockChain.StateSnapshot()
+	ensure.Nil(t, err)
+	defer snapshot.Release()
+
+	b1 := nextBlock(b0)
+	verifyProcessBlock(t, b1, nil, b1.Height, b1)
+
+	// utxos of b1 are not visible in the snapshot taken before it
+	hash, height := snapshot.Tail()
+	ensure.DeepEqual(t, *hash, *b0.BlockHash())
+	ensure.DeepEqual(t, height, b0.Height)
+	coinbaseHash, _ := b1.Txs[0].TxHash()
+	op := types.OutPoint{Hash: *coinbaseHash, Index: 0}
+	utxos, err := snapshot.ListAllUtxos()
+	ensure.Nil(t, err)
+	_, ok := utxos[op]
+	ensure.False(t, ok)
+	utxoWrap, err := snapshot.LoadUtxo(op)
+	ensure.Nil(t, err)
+	ensure.True(t, utxoWrap == nil)
+	utxo, err := blockChain.db.Get(UtxoKey(&op))
+	ensure.Nil(t, err)
+	ensure.NotNil(t, utxo)
+}
+
+func TestInstallUtxoSnapshot(t *testing.T) {
+	period, candidates := []byte("period context"), []byte("candidate context")
+	b := types.NewBlock(getTailBlock())
+	coinbaseTx, _ := CreateCoinbaseTx(minerAddr.Hash(), b.Height, 0)
+	b.Txs = []*types.Transaction{coinbaseTx}
+	b.Header.TxsRoot = *CalcTxsHash(b.Txs)
+	b.Header.PeriodHash = crypto.DoubleHashH(period)
+	b.Header.CandidatesHash = crypto.DoubleHashH(candidates)
+	setUtxoRoot(b)
+	verifyProcessBlock(t, b, nil, b.Height, b)
+
+	// the utxo set as of b in a single chunk
+	chunk := &SnapshotChunk{}
+	iter := blockChain.db.NewIterator(storage.BytesPrefix([]byte(UtxoPrefix + "/")))
+	for iter.Next() {
+		chunk.Keys = append(chunk.Keys, append([]byte(nil), iter.Key()...))
+		chunk.Values = append(chunk.Values, append([]byte(nil), iter.Value()...))
+	}
+	iter.Release()
+	ensure.Nil(t, iter.Error())
+	newSnapshot := func(period, candidates []byte) *UtxoSnapshot {
+		return &UtxoSnapshot{
+			Blocks:      []*types.Block{b},
+			Candidates:  candidates,
+			Period:      period,
+			ChunkHashes: []*crypto.HashType{chunk.Hash()},
+			Chunks:      []*SnapshotChunk{chunk},
+		}
+	}
+
+	// consensus context not committed to by the snapshot block is rejected
+	chain := NewTestBlockChain()
+	err := chain.InstallUtxoSnapshot(newSnapshot([]byte("another period context"), candidates))
+	ensure.DeepEqual(t, err, core.ErrInvalidSnapshotContext)
+	err = chain.InstallUtxoSnapshot(newSnapshot(nil, candidates))
+	ensure.DeepEqual(t, err, core.ErrInvalidSnapshotContext)
+	err = chain.InstallUtxoSnapshot(newSnapshot(period, []byte("another candidate context")))
+	ensure.DeepEqual(t, err, core.ErrInvalidSnapshotContext)
+
+	ensure.Nil(t, chain.InstallUtxoSnapshot(newSnapshot(period, candidates)))
+	ensure.DeepEqual(t, chain.TailBlock().BlockHash(), b.BlockHash())
+	data, err := chain.db.Get(PeriodContextKey(&b.Header.PeriodHash))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, data, period)
+	data, err = chain.db.Get(CandidatesKey(b.BlockHash()))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, data, candidates)
+}
+
+func nextBlockWithCoinbaseOutput(parentBlock *types.Block, scriptPubKey []byte) *types.Block {
+	newBlock := types.NewBlock(parentBlock)
+
+	coinbaseTx, _ := CreateCoinbaseTx(minerAddr.Hash(), parentBlock.Height+1, 0)
+	coinbaseTx.Vout = append(coinbaseTx.Vout, &corepb.TxOut{ScriptPubKey: scriptPubKey})
+	newBlock.Txs = []*types.Transaction{coinbaseTx}
+	newBlock.Header.TxsRoot = *CalcTxsHash(newBlock.Txs)
+	return newBlock
+}
+
+func TestValidateBlockLimits(t *testing.T) {
+	b0 := getTailBlock()
+
+	b1 := nextBlock(b0)
+	ensure.Nil(t, validateBlock(b1))
+
+	// too many txs
+	b1 = nextBlock(b0)
+	tx := types.NewTransaction(*types.NewOutPoint(crypto.HashType{0x01}), 1, 0)
+	for len(b1.Txs) <= MaxTxsPerBlock {
+		b1.Txs = append(b1.Txs, tx)
+	}
+	ensure.DeepEqual(t, validateBlock(b1), core.ErrTooManyTxs)
+
+	// too big
+	b1 = nextBlockWithCoinbaseOutput(b0, make([]byte, MaxBlockSize))
+	ensure.DeepEqual(t, validateBlock(b1), core.ErrBlockTooBig)
+
+	// too many sigops
+	b1 = nextBlockWithCoinbaseOutput(b0, bytes.Repeat([]byte{byte(script.OPCHECKSIG)}, MaxBlockSigOpCnt))
+	ensure.DeepEqual(t, validateBlock(b1), core.ErrTooManySigOps)
+	b1 = nextBlockWithCoinbaseOutput(b0, bytes.Repeat([]byte{byte(script.OPCHECKSIG)}, MaxBlockSigOpCnt-1))
+	ensure.Nil(t, validateBlock(b1))
+}
+
+func TestValidateTxInputsSigOps(t *testing.T) {
+	// each OP_16 OP_CHECKMULTISIG in the redeem script counts as 16 sigops
+	spendP2SH := func(numMultisig int) (*UtxoSet, *types.Transaction) {
+		redeemScript := script.NewScript()
+		for i := 0; i < numMultisig; i++ {
+			redeemScript.AddOpCode(script.OP16).AddOpCode(script.OPCHECKMULTISIG)
+		}
+		addr, _ := types.NewAddressScriptHash(*redeemScript)
+		prevTx := types.NewTransaction(*types.NewOutPoint(crypto.HashType{0x01}), 1, 0)
+		prevTx.Vout[0].ScriptPubKey = *script.PayToAddressScript(addr)
+		utxoSet := NewUtxoSet()
+		ensure.Nil(t, utxoSet.AddUtxo(prevTx, 0, 0))
+
+		prevTxHash, _ := prevTx.TxHash()
+		tx := types.NewTransaction(types.OutPoint{Hash: *prevTxHash, Index: 0}, 1, 0)
+		tx.Vin[0].ScriptSig = *script.ScriptHashSignatureScript(nil, *redeemScript)
+		return utxoSet, tx
+	}
+
+	utxoSet, tx := spendP2SH(MaxTxSigOpCnt / 16)
+	ensure.DeepEqual(t, CountP2SHSigOps(utxoSet, tx), MaxTxSigOpCnt/16*16)
+	_, err := ValidateTxInputs(utxoSet, tx, 0)
+	ensure.Nil(t, err)
+
+	utxoSet, tx = spendP2SH(MaxTxSigOpCnt/16 + 1)
+	_, err = ValidateTxInputs(utxoSet, tx, 0)
+	ensure.DeepEqual(t, err, core.ErrTooManyTxSigOps)
+}
+
+func TestCheckCoinbaseValue(t *testing.T) {
+	b0 := getTailBlock()
+	b1 := nextBlock(b0)
+	ensure.Nil(t, checkCoinbaseValue(b1, 0))
+
+	// coinbase claims fees
+	coinbaseTx, _ := CreateCoinbaseTx(minerAddr.Hash(), b1.Height, 10)
+	b1.Txs[0] = coinbaseTx
+	ensure.Nil(t, checkCoinbaseValue(b1, 10))
+	ensure.DeepEqual(t, checkCoinbaseValue(b1, 9), core.ErrBadCoinbaseValue)
+
+	// coinbase outputs overflow
+	coinbaseTx.Vout = append(coinbaseTx.Vout, &corepb.TxOut{Value: math.MaxUint64})
+	ensure.DeepEqual(t, checkCoinbaseValue(b1, 10), core.ErrBadCoinbaseValue)
+
+	// coinbase pays to vote or unbond outputs
+	voteScript := *script.VoteScript(minerAddr.Hash(), *minerAddr.Hash160())
+	b2 := nextBlockWithCoinbaseOutput(b0, voteScript)
+	ensure.DeepEqual(t, checkCoinbaseValue(b2, 0), core.ErrInvalidCoinbaseOutput)
+	b2 = nextBlockWithCoinbaseOutput(b0, *script.UnbondScript(minerAddr.Hash()))
+	ensure.DeepEqual(t, checkCoinbaseValue(b2, 0), core.ErrInvalidCoinbaseOutput)
+}
+
+func TestCheckCandidateTx(t *testing.T) {
+	candidate := *minerAddr.Hash160()
+	prevTx := types.NewTransaction(*types.NewOutPoint(crypto.HashType{0x02}), 1, 0)
+	prevTx.Vout[0].ScriptPubKey = *script.PayToPubKeyHashScript(minerAddr.Hash())
+	utxoSet := NewUtxoSet()
+	ensure.Nil(t, utxoSet.AddUtxo(prevTx, 0, 0))
+	prevTxHash, _ := prevTx.TxHash()
+
+	newCandidateTx := func(txType int, content interface{ Marshal() ([]byte, error) }) *types.Transaction {
+		data, err := content.Marshal()
+		ensure.Nil(t, err)
+		tx := types.NewTransaction(types.OutPoint{Hash: *prevTxHash, Index: 0}, 1, 0)
+		tx.Data = &corepb.Data{Type: int32(txType), Content: data}
+		return tx
+	}
+
+	// candidate txs must spend coins of the candidate
+	tx := newCandidateTx(types.RegisterCandidateTx, types.NewSignUpContent(candidate, "peer"))
+	ensure.Nil(t, checkCandidateTx(utxoSet, tx))
+	tx = newCandidateTx(types.UnregisterCandidateTx, types.NewUnregisterContent(candidate))
+	ensure.Nil(t, checkCandidateTx(utxoSet, tx))
+	tx = newCandidateTx(types.UnregisterCandidateTx, types.NewUnregisterContent(types.AddressHash{0x01}))
+	ensure.DeepEqual(t, checkCandidateTx(utxoSet, tx), core.ErrInvalidCandidateTx)
+
+	// and publish info within size limits
+	tx = newCandidateTx(types.UpdateCandidateTx,
+		types.NewCandidateInfoContent(candidate, "peer", "127.0.0.1:19199", "candidate"))
+	ensure.Nil(t, checkCandidateTx(utxoSet, tx))
+	tx = newCandidateTx(types.UpdateCandidateTx, types.NewCandidateInfoContent(candidate, "", "", ""))
+	ensure.DeepEqual(t, checkCandidateTx(utxoSet, tx), core.ErrInvalidCandidateTx)
+	tx = newCandidateTx(types.UpdateCandidateTx, types.NewCandidateInfoContent(candidate, "peer", "",
+		string(bytes.Repeat([]byte{'a'}, MaxCandidateDescriptionLen+1))))
+	ensure.DeepEqual(t, checkCandidateTx(utxoSet, tx), core.ErrInvalidCandidateTx)
+}
@@ -26,9 +26,27 @@ var (
 	// CoinbaseMaturity coinbase only spendable after this many blocks
 	CoinbaseMaturity = (uint32)(0)
 
+	// UnbondingPeriod coins unbonded from votes only spendable after this many blocks
+	UnbondingPeriod = (uint32)(3600 * 24 * 7 / 5)
+
+	// MaxCandidatePeerIDLen is the max length of peer id a candidate publishes
+	MaxCandidatePeerIDLen = 128
+
+	// MaxCandidateEndpointLen is the max length of endpoint a candidate publishes
+	MaxCandidateEndpointLen = 256
+
+	// MaxCandidateDescriptionLen is the max length of description a candidate publishes
+	MaxCandidateDescriptionLen = 1024
+
 	// BaseSubsidy is the starting subsidy amount for mined blocks.
 	// This value is halved every SubsidyReductionInterval blocks.
 	BaseSubsidy = (uint64)(50 * math.Pow10(core.Decimals))
+
+	// UtxoCommitmentHeight is the height from which the utxo root in block header
+	// is set and checked. Blocks below it must leave the root zero, so that they
+	// hash as before utxo commitment. Networks started from a genesis spec commit
+	// to utxos from genesis, unless the spec sets a later height.
+	UtxoCommitmentHeight = uint32(1000000)
 )
 
 // isNullOutPoint determines whether or not a previous transaction output point is set.
@@ -47,6 +65,12 @@ func IsCoinBase(tx *types.Transaction) bool {
 	return isNullOutPoint(&tx.Vin[0].PrevOutPoint)
 }
 
+// IsEvidenceTx determines whether or not a transaction carries equivocation evidence,
+// which has neither inputs nor outputs.
+func IsEvidenceTx(tx *types.Transaction) bool {
+	return tx.Data != nil && tx.Data.Type == types.EvidenceTx
+}
+
 // CalcTxsHash calculate txsHash in block.
 func CalcTxsHash(txs []*types.Transaction) *crypto.HashType {
 
@@ -64,10 +88,11 @@ func CalcBlockSubsidy(height uint32) uint64 {
 	return BaseSubsidy >> uint(height/core.SubsidyReductionInterval)
 }
 
-// CreateCoinbaseTx creates a coinbase give miner address and block height
-func CreateCoinbaseTx(addr []byte, blockHeight uint32) (*types.Transaction, error) {
+// CreateCoinbaseTx creates a coinbase give miner address and block height, which
+// pays block subsidy plus fees of transactions in the block to the miner
+func CreateCoinbaseTx(addr []byte, blockHeight uint32, fees uint64) (*types.Transaction, error) {
 	var pkScript []byte
-	blockReward := CalcBlockSubsidy(blockHeight)
+	blockReward := CalcBlockSubsidy(blockHeight) + fees
 	coinbaseScriptSig := script.StandardCoinbaseSignatureScript(blockHeight)
 	pkScript = *script.PayToPubKeyHashScript(addr)
 
@@ -93,9 +118,9 @@ func CreateCoinbaseTx(addr []byte, blockHeight uint32) (*types.Transaction, erro
 	return tx, nil
 }
 
-// return the number of signature operations for all transaction
+// CountSigOps returns the number of signature operations for all transaction
 // input and output scripts in the provided transaction.
-func countSigOps(tx *types.Transaction) int {
+func CountSigOps(tx *types.Transaction) int {
 	// Accumulate the number of signature operations in all transaction inputs.
 	totalSigOps := 0
 	for _, txIn := range tx.Vin {
@@ -112,6 +137,30 @@ func countSigOps(tx *types.Transaction) int {
 	return totalSigOps
 }
 
+// CountP2SHSigOps returns the number of signature operations in redeem scripts of
+// the inputs spending p2sh outputs in utxoSet, which are invisible to CountSigOps.
+func CountP2SHSigOps(utxoSet *UtxoSet, tx *types.Transaction) int {
+	if IsCoinBase(tx) {
+		return 0
+	}
+	totalSigOps := 0
+	for _, txIn := range tx.Vin {
+		utxo := utxoSet.FindUtxo(txIn.PrevOutPoint)
+		if utxo == nil {
+			continue
+		}
+		if !script.NewScriptFromBytes(utxo.Output.GetScriptPubKey()).IsPayToScriptHash() {
+			continue
+		}
+		_, redeemScript, err := script.NewScriptFromBytes(txIn.ScriptSig).GetScriptHashSigParams()
+		if err != nil {
+			continue
+		}
+		totalSigOps += redeemScript.GetPreciseSigOpCount()
+	}
+	return totalSigOps
+}
+
 // MarshalTxIndex writes Tx height and index to bytes
 func MarshalTxIndex(height, index uint32) (data []byte, err error) {
 	var buf bytes.Buffer
@@ -29,6 +29,20 @@ const (
 	// Period is the db key name of current period
 	Period = "/period/current"
 
+	// Snapshot is the db key name of the block the chain is synced from with utxo snapshot
+	Snapshot = "/snapshot"
+
+	// GenesisSpec is the db key name of the genesis spec the chain is initialized with
+	GenesisSpec = "/genesis/spec"
+
+	// SchemaVersion is the db key name of the schema version of db keys and value encodings
+	// value: 4 bytes version
+	SchemaVersion = "/schema/version"
+
+	// MigrationProgress is the db key name of the progress of the migration interrupted
+	// value: 4 bytes version migrating to + the last key migrated
+	MigrationProgress = "/schema/progress"
+
 	// BlockPrefix is the key prefix of database key to store block content
 	// /bk/{hex encoded block hash}
 	// e.g.
@@ -57,6 +71,22 @@ const (
 	// value: utxo wrapper
 	UtxoPrefix = "/ut"
 
+	// UtxoCommitmentPrefix is the key prefix of database key to store utxo commitment
+	// as of a block on main chain
+	// /uc/{hex encoded block hash}
+	// e.g.
+	// key: /uc/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
+	// value: utxo commitment binary
+	UtxoCommitmentPrefix = "/uc"
+
+	// PeriodContextPrefix is the key prefix of database key to store period context
+	// committed in block header
+	// /pc/{hex encoded period context hash}
+	// e.g.
+	// key: /pc/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
+	// value: period context binary
+	PeriodContextPrefix = "/pc"
+
 	// CandidatesPrefix is the key prefix of database key to store candidates
 	CandidatesPrefix = "/candidates"
 	// FilterPrefix is the key prefix of block bloom filter to store a filter bytes
@@ -65,14 +95,49 @@ const (
 	// key: /bf/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
 	// value: crypto hash
 	FilterPrefix = "/bf"
+
+	// TxPoolPrefix is the key prefix of database key to store pending txs in tx pool
+	// /tp/{hex encoded tx hash}
+	// e.g.
+	// key: /tp/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
+	// value: tx binary
+	TxPoolPrefix = "/tp"
+
+	// OrphanTxPrefix is the key prefix of database key to store orphan txs in tx pool
+	// /otp/{hex encoded tx hash}
+	// e.g.
+	// key: /otp/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
+	// value: tx binary
+	OrphanTxPrefix = "/otp"
+
+	// ValidatorStatsPrefix is the key prefix of database key to store performance of validators
+	// /vs/{hex encoded address hash}
+	// e.g.
+	// key: /vs/ce86056786e3415530f8cc739fb414a87435b4b6
+	// value: validator stats binary
+	ValidatorStatsPrefix = "/vs"
+
+	// FinalityCertificatePrefix is the key prefix of database key to store signatures
+	// of producers justifying an eternal block
+	// /fc/{hex encoded block hash}
+	// e.g.
+	// key: /fc/1113b8bdad74cdc045e64e09b3e2f0502d1b7f9bd8123b28239a3360bd3a8757
+	// value: finality certificate binary
+	FinalityCertificatePrefix = "/fc"
 )
 
 var blkBase = key.NewKey(BlockPrefix)
 var blkHashBase = key.NewKey(BlockHashPrefix)
 var txixBase = key.NewKey(TxIndexPrefix)
 var utxoBase = key.NewKey(UtxoPrefix)
+var utxoCommitmentBase = key.NewKey(UtxoCommitmentPrefix)
+var periodContextBase = key.NewKey(PeriodContextPrefix)
 var candidatesBase = key.NewKey(CandidatesPrefix)
 var filterBase = key.NewKey(FilterPrefix)
+var txPoolBase = key.NewKey(TxPoolPrefix)
+var orphanTxBase = key.NewKey(OrphanTxPrefix)
+var validatorStatsBase = key.NewKey(ValidatorStatsPrefix)
+var finalityCertificateBase = key.NewKey(FinalityCertificatePrefix)
 var genesisBlockKey = BlockKey(GenesisBlock.BlockHash())
 
 // TailKey is the db key to stoare tail block content
@@ -84,6 +149,18 @@ var EternalKey = []byte(Eternal)
 // PeriodKey is the db key to stoare current period contex content
 var PeriodKey = []byte(Period)
 
+// SnapshotKey is the db key to store the block the chain is synced from with utxo snapshot
+var SnapshotKey = []byte(Snapshot)
+
+// GenesisSpecKey is the db key to store the genesis spec the chain is initialized with
+var GenesisSpecKey = []byte(GenesisSpec)
+
+// SchemaVersionKey is the db key to store the schema version of db
+var SchemaVersionKey = []byte(SchemaVersion)
+
+// MigrationProgressKey is the db key to store the progress of the migration interrupted
+var MigrationProgressKey = []byte(MigrationProgress)
+
 // BlockKey returns the db key to stoare block content of the hash
 func BlockKey(h *crypto.HashType) []byte {
 	return blkBase.ChildString(h.String()).Bytes()
@@ -104,6 +181,32 @@ func UtxoKey(op *types.OutPoint) []byte {
 	return utxoBase.ChildString(op.Hash.String()).ChildString(fmt.Sprintf("%x", op.Index)).Bytes()
 }
 
+// parseUtxoKey returns the Outpoint of the utxo db key
+func parseUtxoKey(k []byte) (*types.OutPoint, error) {
+	list := key.NewKeyFromBytes(k).List()
+	if len(list) != 3 || "/"+list[0] != UtxoPrefix {
+		return nil, fmt.Errorf("invalid utxo key %s", k)
+	}
+	op := &types.OutPoint{}
+	if err := op.Hash.SetString(list[1]); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Sscanf(list[2], "%x", &op.Index); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// UtxoCommitmentKey returns the db key to store utxo commitment as of the block hash
+func UtxoCommitmentKey(h *crypto.HashType) []byte {
+	return utxoCommitmentBase.ChildString(h.String()).Bytes()
+}
+
+// PeriodContextKey returns the db key to store period context of the hash
+func PeriodContextKey(h *crypto.HashType) []byte {
+	return periodContextBase.ChildString(h.String()).Bytes()
+}
+
 // CandidatesKey returns the db key to stoare candidates.
 func CandidatesKey(h *crypto.HashType) []byte {
 	return candidatesBase.ChildString(h.String()).Bytes()
@@ -118,3 +221,23 @@ func FilterKey(hash crypto.HashType) []byte {
 	buf = append(buf[:], hash.GetBytes()...)
 	return buf
 }
+
+// TxPoolKey returns the db key to store pending tx of the hash in tx pool
+func TxPoolKey(h *crypto.HashType) []byte {
+	return txPoolBase.ChildString(h.String()).Bytes()
+}
+
+// OrphanTxKey returns the db key to store orphan tx of the hash in tx pool
+func OrphanTxKey(h *crypto.HashType) []byte {
+	return orphanTxBase.ChildString(h.String()).Bytes()
+}
+
+// ValidatorStatsKey returns the db key to store performance of the validator
+func ValidatorStatsKey(addr *types.AddressHash) []byte {
+	return validatorStatsBase.ChildString(fmt.Sprintf("%x", addr[:])).Bytes()
+}
+
+// FinalityCertificateKey returns the db key to store finality certificate of the block hash
+func FinalityCertificateKey(h *crypto.HashType) []byte {
+	return finalityCertificateBase.ChildString(h.String()).Bytes()
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/BOXFoundation/boxd/boxd/eventbus"
 	"github.com/BOXFoundation/boxd/core/types"
-	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/p2p"
 	"github.com/BOXFoundation/boxd/storage"
 	"github.com/jbenet/goprocess"
@@ -58,7 +57,19 @@ func (dpos *DummyDpos) Run() error { return nil }
 func (dpos *DummyDpos) Stop() {}
 
 // StoreCandidateContext store candidate context
-func (dpos *DummyDpos) StoreCandidateContext(*crypto.HashType) error { return nil }
+func (dpos *DummyDpos) StoreCandidateContext(*types.Block, map[types.OutPoint]*types.UtxoWrap, storage.Batch) error {
+	return nil
+}
+
+// UpdatePeriodContext update period context
+func (dpos *DummyDpos) UpdatePeriodContext(*types.Block, storage.Batch) (func(), error) {
+	return nil, nil
+}
+
+// RevertPeriodContext revert period context
+func (dpos *DummyDpos) RevertPeriodContext(*types.Block, storage.Batch) (func(), error) {
+	return nil, nil
+}
 
 // VerifySign verify sign
 func (dpos *DummyDpos) VerifySign(*types.Block) (bool, error) { return true, nil }
@@ -66,6 +77,11 @@ func (dpos *DummyDpos) VerifySign(*types.Block) (bool, error) { return true, nil
 // VerifyMinerEpoch verify miner epoch
 func (dpos *DummyDpos) VerifyMinerEpoch(*types.Block) error { return nil }
 
+// VerifyCandidateTx verify candidate tx
+func (dpos *DummyDpos) VerifyCandidateTx(*types.Transaction, map[types.OutPoint]*types.UtxoWrap) error {
+	return nil
+}
+
 // RecoverMint revover mint
 func (dpos *DummyDpos) RecoverMint() {}
 
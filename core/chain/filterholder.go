@@ -26,6 +26,7 @@ type BloomFilterHolder interface {
 	ResetFilters(uint32) error
 	ListMatchedBlockHashes([]byte) []crypto.HashType
 	AddFilter(uint32, crypto.HashType, storage.Table, func() bloom.Filter) error
+	Snapshot() BloomFilterHolder
 }
 
 // NewFilterHolder creates an holder instance
@@ -114,11 +115,26 @@ func (holder *MemoryBloomFilterHolder) ResetFilters(height uint32) error {
 	if height == 0 {
 		holder.entries = []*FilterEntry{}
 	} else {
-		holder.entries = holder.entries[:height-1]
+		// copied rather than resliced, since filters added later would overwrite
+		// entries seen by snapshots otherwise
+		holder.entries = append([]*FilterEntry(nil), holder.entries[:height-1]...)
 	}
 	return nil
 }
 
+// Snapshot returns a read-only view of filters for now, which shares entries
+// with the holder since entries are only appended until reset
+func (holder *MemoryBloomFilterHolder) Snapshot() BloomFilterHolder {
+	holder.mux.Lock()
+	defer holder.mux.Unlock()
+
+	n := len(holder.entries)
+	return &MemoryBloomFilterHolder{
+		entries: holder.entries[:n:n],
+		mux:     &sync.Mutex{},
+	}
+}
+
 // ListMatchedBlockHashes search all blocks' bloom filter, and returns block hashes
 // that might contain a certain word
 func (holder *MemoryBloomFilterHolder) ListMatchedBlockHashes(word []byte) []crypto.HashType {
@@ -446,6 +446,24 @@ func TestMemoryBloomFilterHolder_ResetFilters(t *testing.T) {
 	}
 }
 
+func TestMemoryBloomFilterHolder_Snapshot(t *testing.T) {
+	holder := &MemoryBloomFilterHolder{
+		entries: prepareEntries(10),
+		mux:     &sync.Mutex{},
+	}
+	snapshot := holder.Snapshot().(*MemoryBloomFilterHolder)
+	word := wordWithInt(5)
+	matched := holder.ListMatchedBlockHashes(word)
+	ensure.DeepEqual(t, snapshot.ListMatchedBlockHashes(word), matched)
+
+	// filters reset and added afterwards do not affect the snapshot
+	ensure.Nil(t, holder.ResetFilters(5))
+	ensure.Nil(t, holder.addFilterInternal(filterForHeight(11), 5, hashForHeight(11)))
+	ensure.DeepEqual(t, len(snapshot.entries), 10)
+	ensure.DeepEqual(t, snapshot.entries[4].BlockHash, hashForHeight(5))
+	ensure.DeepEqual(t, snapshot.ListMatchedBlockHashes(word), matched)
+}
+
 func TestMemoryBloomFilterHolder_ListMatchedBlockHashes(t *testing.T) {
 	type args struct {
 		word []byte
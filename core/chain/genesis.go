@@ -5,11 +5,20 @@
 package chain
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/BOXFoundation/boxd/core"
 	"github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/script"
+	"github.com/BOXFoundation/boxd/storage"
+	"github.com/spf13/viper"
 )
 
 var genesisCoinbaseTx = types.Transaction{
@@ -77,3 +86,241 @@ var GenesisPeriod = []map[string]string{
 		"peerID": "12D3KooWNcJQzHaNpW5vZDQbTcoLXVCyGS755hTpendGzb5Hqtcu",
 	},
 }
+
+// Genesis is the spec of a network, i.e., its genesis block and consensus
+// parameters, which is read from a json or yaml file.
+type Genesis struct {
+	Network     string             `json:"network" mapstructure:"network"`
+	Magic       uint32             `json:"magic" mapstructure:"magic"`
+	Timestamp   int64              `json:"timestamp" mapstructure:"timestamp"`
+	Allocations []GenesisAlloc     `json:"allocations" mapstructure:"allocations"`
+	Validators  []GenesisValidator `json:"validators" mapstructure:"validators"`
+	Consensus   ConsensusParams    `json:"consensus" mapstructure:"consensus"`
+}
+
+// GenesisAlloc is coins allocated to an address in genesis block.
+type GenesisAlloc struct {
+	Addr  string `json:"addr" mapstructure:"addr"`
+	Value uint64 `json:"value" mapstructure:"value"`
+}
+
+// GenesisValidator is a block producer of the genesis period.
+type GenesisValidator struct {
+	Addr   string `json:"addr" mapstructure:"addr"`
+	PeerID string `json:"peer_id" mapstructure:"peer_id"`
+}
+
+// ConsensusParams are consensus parameters of a network. Zero values keep the defaults.
+type ConsensusParams struct {
+	// block interval in ms, which must be whole seconds
+	BlockInterval int64 `json:"block_interval" mapstructure:"block_interval"`
+	// max time in ms spent in packing txs into a block
+	MaxPackedTxTime int64  `json:"max_packed_tx_time" mapstructure:"max_packed_tx_time"`
+	BaseSubsidy     uint64 `json:"base_subsidy" mapstructure:"base_subsidy"`
+	// height from which blocks commit to the utxo set, zero from genesis
+	UtxoCommitmentHeight uint32 `json:"utxo_commitment_height" mapstructure:"utxo_commitment_height"`
+}
+
+// ReadGenesisFile reads genesis spec from a json or yaml file.
+func ReadGenesisFile(path string) (*Genesis, error) {
+	genesis := new(Genesis)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		// amounts may exceed the precision of float, so viper is not used here
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, genesis); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		v := viper.New()
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+		if err := v.Unmarshal(genesis); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, core.ErrGenesisFileFormat
+	}
+	if err := genesis.Validate(); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// Validate checks addresses and allocations in the spec.
+func (genesis *Genesis) Validate() error {
+	if len(genesis.Validators) == 0 {
+		return fmt.Errorf("%v: no validators", core.ErrInvalidGenesis)
+	}
+	for _, v := range genesis.Validators {
+		if _, err := types.NewAddress(v.Addr); err != nil {
+			return fmt.Errorf("%v: validator %s: %v", core.ErrInvalidGenesis, v.Addr, err)
+		}
+		if len(v.PeerID) == 0 {
+			return fmt.Errorf("%v: validator %s has no peer id", core.ErrInvalidGenesis, v.Addr)
+		}
+	}
+	var total uint64
+	for _, alloc := range genesis.Allocations {
+		if _, err := types.NewAddress(alloc.Addr); err != nil {
+			return fmt.Errorf("%v: allocation %s: %v", core.ErrInvalidGenesis, alloc.Addr, err)
+		}
+		if alloc.Value == 0 || alloc.Value > TotalSupply-total {
+			return fmt.Errorf("%v: invalid allocation to %s", core.ErrInvalidGenesis, alloc.Addr)
+		}
+		total += alloc.Value
+	}
+	return nil
+}
+
+// Block builds the genesis block of the spec, whose coinbase pays allocations.
+func (genesis *Genesis) Block() (*types.Block, error) {
+	coinbaseTx := &types.Transaction{
+		Version: 1,
+		Vin: []*types.TxIn{
+			{
+				PrevOutPoint: types.OutPoint{
+					Hash:  crypto.HashType{},
+					Index: 0xffffffff,
+				},
+				ScriptSig: []byte{},
+				Sequence:  0xffffffff,
+			},
+		},
+	}
+	for _, alloc := range genesis.Allocations {
+		addr, err := types.NewAddress(alloc.Addr)
+		if err != nil {
+			return nil, err
+		}
+		coinbaseTx.Vout = append(coinbaseTx.Vout, &corepb.TxOut{
+			Value:        alloc.Value,
+			ScriptPubKey: *script.PayToPubKeyHashScript(addr.Hash()),
+		})
+	}
+	timestamp := genesis.Timestamp
+	if timestamp == 0 {
+		timestamp = GenesisBlock.Header.TimeStamp
+	}
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			Version:   1,
+			TimeStamp: timestamp,
+			Magic:     genesis.Magic,
+		},
+		Txs: []*types.Transaction{coinbaseTx},
+	}
+	block.Header.TxsRoot = *CalcTxsHash(block.Txs)
+	if genesis.Consensus.UtxoCommitmentHeight > 0 {
+		return block, nil
+	}
+
+	utxoSet := NewUtxoSet()
+	if err := utxoSet.ApplyBlock(block); err != nil {
+		return nil, err
+	}
+	block.Header.UtxoRoot = *NewUtxoCommitmentFromUtxos(utxoSet.GetUtxos()).Hash()
+	return block, nil
+}
+
+// Apply makes the network of the spec the one the node runs on. It must be called
+// before the chain is loaded.
+func (genesis *Genesis) Apply() error {
+	block, err := genesis.Block()
+	if err != nil {
+		return err
+	}
+	period := make([]map[string]string, len(genesis.Validators))
+	for k, v := range genesis.Validators {
+		period[k] = map[string]string{
+			"addr":   v.Addr,
+			"peerID": v.PeerID,
+		}
+	}
+
+	GenesisBlock = *block
+	GenesisHash = *block.BlockHash()
+	genesisBlockKey = BlockKey(&GenesisHash)
+	GenesisPeriod = period
+	if genesis.Consensus.BaseSubsidy > 0 {
+		BaseSubsidy = genesis.Consensus.BaseSubsidy
+	}
+	// unlike other params, the default network predates utxo commitment, which
+	// spec networks have from genesis unless set otherwise
+	UtxoCommitmentHeight = genesis.Consensus.UtxoCommitmentHeight
+	return nil
+}
+
+// InitGenesis initializes an empty chain db with the genesis spec, which writes
+// genesis block, utxos allocated in it and the spec itself to db. Initializing
+// a chain with the same spec again is a no-op.
+func InitGenesis(db storage.Table, genesis *Genesis) (*types.Block, error) {
+	block, err := genesis.Block()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := LoadGenesis(db)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		existingBlock, err := existing.Block()
+		if err != nil {
+			return nil, err
+		}
+		if *existingBlock.BlockHash() != *block.BlockHash() {
+			return nil, core.ErrChainInitialized
+		}
+		return block, nil
+	}
+	// a chain started without spec runs on the default genesis
+	if ok, _ := db.Has(TailKey); ok {
+		return nil, core.ErrChainInitialized
+	}
+	if ok, _ := db.Has(genesisBlockKey); ok {
+		return nil, core.ErrChainInitialized
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	utxoSet := NewUtxoSet()
+	if err := utxoSet.ApplyBlock(block); err != nil {
+		return nil, err
+	}
+	if err := utxoSet.WriteUtxoSetToDB(batch); err != nil {
+		return nil, err
+	}
+	data, err := block.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	batch.Put(BlockKey(block.BlockHash()), data)
+	spec, err := json.Marshal(genesis)
+	if err != nil {
+		return nil, err
+	}
+	batch.Put(GenesisSpecKey, spec)
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// LoadGenesis loads the genesis spec the chain db is initialized with. It returns
+// nil if the chain runs on the default genesis.
+func LoadGenesis(db storage.Table) (*Genesis, error) {
+	spec, err := db.Get(GenesisSpecKey)
+	if err != nil || spec == nil {
+		return nil, err
+	}
+	genesis := new(Genesis)
+	if err := json.Unmarshal(spec, genesis); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
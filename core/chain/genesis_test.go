@@ -0,0 +1,90 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/storage"
+	"github.com/facebookgo/ensure"
+	"github.com/jbenet/goprocess"
+)
+
+const testGenesisSpec = `{
+	"network": "privnet",
+	"magic": 1234,
+	"timestamp": 1541824620,
+	"allocations": [
+		{"addr": "b1ndoQmEd83y4Fza5PzbUQDYpT3mV772J5o", "value": 123456789012345678}
+	],
+	"validators": [
+		{"addr": "b1ndoQmEd83y4Fza5PzbUQDYpT3mV772J5o", "peer_id": "12D3KooWFQ2naj8XZUVyGhFzBTEMrMc6emiCEDKLjaJMsK7p8Cza"},
+		{"addr": "b1b8bzyci5VYUJVKRU2HRMMQiUXnoULkKAJ", "peer_id": "12D3KooWKPRAK7vBBrVv9szEin55kBnJEEuHG4gDTQEM72ByZDpA"}
+	],
+	"consensus": {"block_interval": 3000, "base_subsidy": 100}
+}`
+
+func readTestGenesis(t *testing.T, spec string) (*Genesis, error) {
+	dir, err := ioutil.TempDir("", "genesis")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "genesis.json")
+	ensure.Nil(t, ioutil.WriteFile(path, []byte(spec), 0600))
+	return ReadGenesisFile(path)
+}
+
+func TestReadGenesisFile(t *testing.T) {
+	genesis, err := readTestGenesis(t, testGenesisSpec)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, genesis.Magic, uint32(1234))
+	ensure.DeepEqual(t, genesis.Allocations[0].Value, uint64(123456789012345678))
+	ensure.DeepEqual(t, len(genesis.Validators), 2)
+	ensure.DeepEqual(t, genesis.Consensus.BlockInterval, int64(3000))
+
+	_, err = readTestGenesis(t, `{"network": "privnet"}`)
+	ensure.NotNil(t, err)
+	_, err = ReadGenesisFile("genesis.toml")
+	ensure.DeepEqual(t, err, core.ErrGenesisFileFormat)
+}
+
+func TestInitGenesis(t *testing.T) {
+	genesis, err := readTestGenesis(t, testGenesisSpec)
+	ensure.Nil(t, err)
+	block, err := genesis.Block()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, block.Header.Magic, genesis.Magic)
+	ensure.DeepEqual(t, len(block.Txs[0].Vout), 1)
+	ensure.True(t, IsCoinBase(block.Txs[0]))
+
+	database, err := storage.NewDatabase(goprocess.Background(), &storage.Config{Name: "memdb"})
+	ensure.Nil(t, err)
+	db, err := database.Table(BlockTableName)
+	ensure.Nil(t, err)
+
+	initialized, err := InitGenesis(db, genesis)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, initialized.BlockHash(), block.BlockHash())
+	loaded, err := LoadGenesis(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, loaded, genesis)
+
+	// allocations are spendable utxos
+	txHash, _ := block.Txs[0].TxHash()
+	utxo, err := db.Get(UtxoKey(&types.OutPoint{Hash: *txHash, Index: 0}))
+	ensure.Nil(t, err)
+	ensure.NotNil(t, utxo)
+
+	// initializing again with the same spec is a no-op, but not with another
+	_, err = InitGenesis(db, genesis)
+	ensure.Nil(t, err)
+	genesis.Magic++
+	_, err = InitGenesis(db, genesis)
+	ensure.DeepEqual(t, err, core.ErrChainInitialized)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/storage"
+)
+
+// baseSchemaVersion is the schema version of chain dbs created before schema
+// versioning, whose version is not stored
+const baseSchemaVersion = 1
+
+// MigrationBatchSize is the max number of entries a migration visits before its
+// changes are written along with its progress
+const MigrationBatchSize = 4096
+
+// Migration upgrades chain db from schema version Version-1 to Version, e.g., by
+// rewriting entries whose keys or value encodings are changed
+type Migration struct {
+	Version     uint32
+	Description string
+	Migrate     func(m *Migrator) error
+}
+
+// migrations upgrade chain db to the schema version of the binary in order. A
+// migration is appended here whenever db keys or value encodings change.
+var migrations = []*Migration{
+	{
+		Version: 2,
+		// headers committing to the utxo set, votes, candidates and period
+		// contexts are encoded differently since, and cannot be rebuilt
+		// without replaying the chain
+		Description: "refuse dbs created before schema versioning",
+		Migrate: func(*Migrator) error {
+			return core.ErrReinitRequired
+		},
+	},
+}
+
+// CurrentSchemaVersion returns the schema version of chain db the binary works with
+func CurrentSchemaVersion() uint32 {
+	return schemaVersionOf(migrations)
+}
+
+func schemaVersionOf(migrations []*Migration) uint32 {
+	if len(migrations) == 0 {
+		return baseSchemaVersion
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func encodeSchemaVersion(version uint32) []byte {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, version)
+	return data
+}
+
+// LoadSchemaVersion returns the schema version of chain db, which is 0 if db is
+// empty, i.e., not created yet
+func LoadSchemaVersion(db storage.Table) (uint32, error) {
+	data, err := db.Get(SchemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if data != nil {
+		if len(data) != 4 {
+			return 0, core.ErrInvalidSchemaValue
+		}
+		return binary.LittleEndian.Uint32(data), nil
+	}
+
+	iter := db.NewIterator(nil)
+	defer iter.Release()
+	if iter.First() {
+		return baseSchemaVersion, nil
+	}
+	return 0, iter.Error()
+}
+
+// PendingMigrations returns the schema version of chain db and migrations to
+// upgrade it to the schema version of the binary. It fails with ErrSchemaTooNew
+// if db is of a newer version, which the binary must not open.
+func PendingMigrations(db storage.Table) (uint32, []*Migration, error) {
+	return pendingMigrations(db, migrations)
+}
+
+func pendingMigrations(db storage.Table, migrations []*Migration) (uint32, []*Migration, error) {
+	for i, migration := range migrations {
+		if migration.Version != baseSchemaVersion+uint32(i)+1 {
+			return 0, nil, core.ErrInvalidMigrations
+		}
+	}
+	version, err := LoadSchemaVersion(db)
+	if err != nil {
+		return 0, nil, err
+	}
+	if current := schemaVersionOf(migrations); version > current {
+		logger.Errorf("Chain db is of schema version %d, while the binary supports up to %d", version, current)
+		return version, nil, core.ErrSchemaTooNew
+	}
+	if version == 0 {
+		return 0, nil, nil
+	}
+	return version, migrations[version-baseSchemaVersion:], nil
+}
+
+// Migrate upgrades chain db to the schema version of the binary by running
+// pending migrations in order, and an empty db is stamped with the version
+// directly. Migrations commit their progress along the way and the version once
+// done, so that an interrupted upgrade resumes from where it stopped next time.
+func Migrate(db storage.Table) error {
+	return migrate(db, migrations)
+}
+
+func migrate(db storage.Table, migrations []*Migration) error {
+	version, pending, err := pendingMigrations(db, migrations)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return db.Put(SchemaVersionKey, encodeSchemaVersion(schemaVersionOf(migrations)))
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	logger.Infof("Migrating chain db from schema version %d to %d", version, schemaVersionOf(migrations))
+	for i, migration := range pending {
+		logger.Infof("Running migration %d/%d to schema version %d: %s",
+			i+1, len(pending), migration.Version, migration.Description)
+		m, err := newMigrator(db, migration)
+		if err != nil {
+			return err
+		}
+		if err := migration.Migrate(m); err != nil {
+			return fmt.Errorf("migration to schema version %d failed: %v", migration.Version, err)
+		}
+
+		batch := db.NewBatch()
+		batch.Put(SchemaVersionKey, encodeSchemaVersion(migration.Version))
+		batch.Del(MigrationProgressKey)
+		err = batch.Write()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+		logger.Infof("Chain db migrated to schema version %d, %d entries visited", migration.Version, m.visited)
+	}
+	return nil
+}
+
+// Migrator runs a migration over chain db, keeping its progress in db
+type Migrator struct {
+	db        storage.Table
+	migration *Migration
+	// key of the last entry migrated, after which the migration resumes
+	cursor  []byte
+	visited int
+}
+
+func newMigrator(db storage.Table, migration *Migration) (*Migrator, error) {
+	m := &Migrator{db: db, migration: migration}
+	progress, err := db.Get(MigrationProgressKey)
+	if err != nil {
+		return nil, err
+	}
+	// progress left by another migration is ignored, which is not resumable
+	if len(progress) >= 4 && binary.LittleEndian.Uint32(progress) == migration.Version {
+		m.cursor = progress[4:]
+		logger.Infof("Resuming migration to schema version %d after key %s", migration.Version, m.cursor)
+	}
+	return m, nil
+}
+
+// DB returns the chain db to migrate
+func (m *Migrator) DB() storage.Table {
+	return m.db
+}
+
+// ForEach calls fn with entries whose keys are prefixed with prefix in key order,
+// and writes changes fn makes to batch every MigrationBatchSize entries along
+// with the progress. Entries migrated before the migration was interrupted are
+// skipped, so a migration calling ForEach more than once must visit prefixes in
+// key order. Key and value are only valid during the call.
+func (m *Migrator) ForEach(prefix []byte, fn func(key, value []byte, batch storage.Batch) error) error {
+	iter := m.db.NewIterator(storage.BytesPrefix(prefix))
+	defer iter.Release()
+
+	ok := iter.First()
+	if m.cursor != nil {
+		if ok = iter.Seek(m.cursor); ok && bytes.Equal(iter.Key(), m.cursor) {
+			ok = iter.Next()
+		}
+	}
+
+	batch := m.db.NewBatch()
+	defer batch.Close()
+	var last []byte
+	for n := 1; ok; n++ {
+		if err := fn(iter.Key(), iter.Value(), batch); err != nil {
+			return err
+		}
+		m.visited++
+		last = append(last[:0], iter.Key()...)
+		if n%MigrationBatchSize == 0 {
+			if err := m.commit(batch, last); err != nil {
+				return err
+			}
+			logger.Infof("Migrating to schema version %d, %d entries visited", m.migration.Version, m.visited)
+		}
+		ok = iter.Next()
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if last == nil {
+		return nil
+	}
+	return m.commit(batch, last)
+}
+
+// commit writes the batch along with the progress up to key
+func (m *Migrator) commit(batch storage.Batch, key []byte) error {
+	progress := append(encodeSchemaVersion(m.migration.Version), key...)
+	batch.Put(MigrationProgressKey, progress)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Clear()
+	m.cursor = progress[4:]
+	return nil
+}
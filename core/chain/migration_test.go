@@ -0,0 +1,138 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/storage"
+	"github.com/BOXFoundation/boxd/storage/memdb"
+	"github.com/facebookgo/ensure"
+)
+
+func newMigrationTestDB(t *testing.T) storage.Table {
+	db, err := memdb.NewMemoryDB("migration test", nil)
+	ensure.Nil(t, err)
+	table, err := db.Table(BlockTableName)
+	ensure.Nil(t, err)
+	return table
+}
+
+// migration appending version to values of keys with the prefix, and failing at
+// the entry failAt once if it is not 0
+func rewriteMigration(version uint32, prefix string, failAt int) *Migration {
+	return &Migration{
+		Version:     version,
+		Description: fmt.Sprintf("rewrite %s", prefix),
+		Migrate: func(m *Migrator) error {
+			n := 0
+			return m.ForEach([]byte(prefix), func(key, value []byte, batch storage.Batch) error {
+				if n++; n == failAt {
+					failAt = 0
+					return errors.New("interrupted")
+				}
+				batch.Put(key, append(append([]byte(nil), value...), byte(version)))
+				return nil
+			})
+		},
+	}
+}
+
+func TestMigrateEmptyDB(t *testing.T) {
+	db := newMigrationTestDB(t)
+	version, err := LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(0))
+
+	migrations := []*Migration{rewriteMigration(2, "/a", 0)}
+	ensure.Nil(t, migrate(db, migrations))
+	version, err = LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(2))
+}
+
+func TestMigrate(t *testing.T) {
+	db := newMigrationTestDB(t)
+	for i := 0; i < MigrationBatchSize+10; i++ {
+		ensure.Nil(t, db.Put([]byte(fmt.Sprintf("/a/%06d", i)), []byte{0}))
+		ensure.Nil(t, db.Put([]byte(fmt.Sprintf("/b/%06d", i)), []byte{0}))
+	}
+	// db created before schema versioning
+	version, err := LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(baseSchemaVersion))
+
+	migrations := []*Migration{
+		rewriteMigration(2, "/a", 0),
+		rewriteMigration(3, "/b", MigrationBatchSize+5),
+	}
+	version, pending, err := pendingMigrations(db, migrations)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(1))
+	ensure.DeepEqual(t, len(pending), 2)
+
+	// interrupted in the middle of migration to version 3
+	ensure.NotNil(t, migrate(db, migrations))
+	version, pending, err = pendingMigrations(db, migrations)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(2))
+	ensure.DeepEqual(t, len(pending), 1)
+
+	// and resumed after the entries migrated, so every entry is migrated once
+	ensure.Nil(t, migrate(db, migrations))
+	version, err = LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(3))
+	ok, err := db.Has(MigrationProgressKey)
+	ensure.Nil(t, err)
+	ensure.False(t, ok)
+
+	// nothing to run any more
+	ensure.Nil(t, migrate(db, migrations))
+	for i := 0; i < MigrationBatchSize+10; i++ {
+		value, err := db.Get([]byte(fmt.Sprintf("/a/%06d", i)))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, value, []byte{0, 2})
+		value, err = db.Get([]byte(fmt.Sprintf("/b/%06d", i)))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, value, []byte{0, 3})
+	}
+}
+
+func TestMigrateNewerDB(t *testing.T) {
+	db := newMigrationTestDB(t)
+	ensure.Nil(t, db.Put(SchemaVersionKey, encodeSchemaVersion(3)))
+
+	migrations := []*Migration{rewriteMigration(2, "/a", 0)}
+	ensure.DeepEqual(t, migrate(db, migrations), core.ErrSchemaTooNew)
+}
+
+func TestMigrateInvalidMigrations(t *testing.T) {
+	db := newMigrationTestDB(t)
+	migrations := []*Migration{rewriteMigration(3, "/a", 0)}
+	ensure.DeepEqual(t, migrate(db, migrations), core.ErrInvalidMigrations)
+}
+
+func TestMigrateUnversionedDB(t *testing.T) {
+	// dbs before schema versioning are refused rather than opened silently
+	db := newMigrationTestDB(t)
+	ensure.Nil(t, db.Put([]byte("/a/1"), []byte{1}))
+	err := Migrate(db)
+	ensure.NotNil(t, err)
+	ensure.StringContains(t, err.Error(), core.ErrReinitRequired.Error())
+	version, err := LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, uint32(baseSchemaVersion))
+
+	// new dbs are of the current version
+	db = newMigrationTestDB(t)
+	ensure.Nil(t, Migrate(db))
+	version, err = LoadSchemaVersion(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, version, CurrentSchemaVersion())
+}
@@ -0,0 +1,367 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/storage"
+	"github.com/BOXFoundation/boxd/util"
+	"github.com/BOXFoundation/boxd/util/bloom"
+)
+
+// SnapshotChunkSize is the max number of utxos in a chunk of utxo snapshot
+const SnapshotChunkSize = 4096
+
+// UtxoSnapshot is the utxo set as of an eternal block, with which a new node
+// skips processing blocks before it. Utxos are sorted by db key and split into
+// chunks, and the root commits to the hashes of all chunks along with the blocks
+// and consensus context, so that a snapshot can be verified chunk by chunk.
+type UtxoSnapshot struct {
+	// the snapshot block followed by its ancestors, latest first
+	Blocks []*types.Block
+	// marshaled candidate context as of the snapshot block, which its header
+	// commits to
+	Candidates []byte
+	// marshaled period context the snapshot block header commits to, nil for
+	// the genesis period
+	Period      []byte
+	ChunkHashes []*crypto.HashType
+	// nil for chunks not downloaded yet in sync
+	Chunks []*SnapshotChunk
+}
+
+// SnapshotChunk is a run of utxos in a snapshot, i.e., their db keys and
+// marshaled UtxoWraps in db key order
+type SnapshotChunk struct {
+	Keys   [][]byte
+	Values [][]byte
+}
+
+// Hash returns the hash of the chunk
+func (c *SnapshotChunk) Hash() *crypto.HashType {
+	var buf bytes.Buffer
+	for i := range c.Keys {
+		util.WriteVarBytes(&buf, c.Keys[i])
+		util.WriteVarBytes(&buf, c.Values[i])
+	}
+	hash := crypto.DoubleHashH(buf.Bytes())
+	return &hash
+}
+
+// Block returns the snapshot block
+func (s *UtxoSnapshot) Block() *types.Block {
+	return s.Blocks[0]
+}
+
+// Root returns the merkle root of the snapshot context and chunk hashes
+func (s *UtxoSnapshot) Root() *crypto.HashType {
+	var buf bytes.Buffer
+	for _, b := range s.Blocks {
+		buf.Write(b.BlockHash()[:])
+	}
+	util.WriteVarBytes(&buf, s.Candidates)
+	util.WriteVarBytes(&buf, s.Period)
+	contextHash := crypto.DoubleHashH(buf.Bytes())
+
+	hashes := append([]*crypto.HashType{&contextHash}, s.ChunkHashes...)
+	merkleRoot := util.BuildMerkleRoot(hashes)
+	return merkleRoot[len(merkleRoot)-1]
+}
+
+// UtxoSnapshot returns the utxo snapshot as of a block on main chain not after
+// the eternal block, carrying ancestors more blocks before it. The latest eternal
+// block is used if hash is nil. The latest snapshot is cached since computing
+// it requires undoing all blocks after the snapshot block.
+func (chain *BlockChain) UtxoSnapshot(hash *crypto.HashType, ancestors int) (*UtxoSnapshot, error) {
+	chain.chainLock.RLock()
+	defer chain.chainLock.RUnlock()
+
+	block := chain.eternal
+	if hash != nil {
+		var err error
+		if block, err = chain.LoadBlockByHash(*hash); err != nil {
+			return nil, err
+		}
+	}
+	if block.Height == 0 || block.Height > chain.eternal.Height {
+		return nil, core.ErrSnapshotBlockNotEternal
+	}
+	if mainBlock, err := chain.LoadBlockByHeight(block.Height); err != nil ||
+		*mainBlock.BlockHash() != *block.BlockHash() {
+		return nil, core.ErrSnapshotBlockNotEternal
+	}
+	chain.snapshotMtx.Lock()
+	defer chain.snapshotMtx.Unlock()
+	if s := chain.snapshot; s != nil && *s.Block().BlockHash() == *block.BlockHash() &&
+		len(s.Blocks) == ancestors+1 {
+		return s, nil
+	}
+
+	snapshot := &UtxoSnapshot{Blocks: []*types.Block{block}}
+	for b := block; len(snapshot.Blocks) <= ancestors && b.Height > 1; {
+		var err error
+		if b, err = chain.LoadBlockByHash(b.Header.PrevBlockHash); err != nil {
+			return nil, err
+		}
+		snapshot.Blocks = append(snapshot.Blocks, b)
+	}
+	var err error
+	if snapshot.Candidates, err = chain.db.Get(CandidatesKey(block.BlockHash())); err != nil {
+		return nil, err
+	}
+	if snapshot.Period, err = chain.db.Get(PeriodContextKey(&block.Header.PeriodHash)); err != nil {
+		return nil, err
+	}
+	utxos, err := chain.utxosAsOf(block.Height)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(utxos))
+	for k := range utxos {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i := 0; i < len(keys); i += SnapshotChunkSize {
+		end := i + SnapshotChunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		chunk := &SnapshotChunk{}
+		for _, k := range keys[i:end] {
+			chunk.Keys = append(chunk.Keys, []byte(k))
+			chunk.Values = append(chunk.Values, utxos[k])
+		}
+		snapshot.Chunks = append(snapshot.Chunks, chunk)
+		snapshot.ChunkHashes = append(snapshot.ChunkHashes, chunk.Hash())
+	}
+	logger.Infof("Create utxo snapshot with %d utxos in %d chunks at block %s height %d",
+		len(keys), len(snapshot.Chunks), block.BlockHash(), block.Height)
+	chain.snapshot = snapshot
+	return snapshot, nil
+}
+
+// CachedUtxoSnapshot returns the cached utxo snapshot with root, or nil
+func (chain *BlockChain) CachedUtxoSnapshot(root *crypto.HashType) *UtxoSnapshot {
+	chain.snapshotMtx.Lock()
+	defer chain.snapshotMtx.Unlock()
+	if chain.snapshot == nil || *chain.snapshot.Root() != *root {
+		return nil
+	}
+	return chain.snapshot
+}
+
+// utxosAsOf returns marshaled utxos keyed by db key as of height on main chain,
+// by undoing blocks from tail down to height in reverse order
+func (chain *BlockChain) utxosAsOf(height uint32) (map[string][]byte, error) {
+	utxos := make(map[string][]byte)
+	iter := chain.db.NewIterator(storage.BytesPrefix([]byte(UtxoPrefix + "/")))
+	defer iter.Release()
+	for iter.Next() {
+		utxos[string(iter.Key())] = append([]byte(nil), iter.Value()...)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	for h := chain.tail.Height; h > height; h-- {
+		block, err := chain.LoadBlockByHeight(h)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(block.Txs) - 1; i >= 0; i-- {
+			tx := block.Txs[i]
+			txHash, _ := tx.TxHash()
+			for idx := range tx.Vout {
+				delete(utxos, string(UtxoKey(&types.OutPoint{Hash: *txHash, Index: uint32(idx)})))
+			}
+			if IsCoinBase(tx) {
+				continue
+			}
+			// restore spent utxos, which are removed from db
+			for _, txIn := range tx.Vin {
+				prevTx, prevHeight, err := chain.loadTxAndHeight(txIn.PrevOutPoint.Hash)
+				if err != nil {
+					return nil, err
+				}
+				if txIn.PrevOutPoint.Index >= uint32(len(prevTx.Vout)) {
+					return nil, core.ErrTxOutIndexOob
+				}
+				utxoWrap := &types.UtxoWrap{
+					Output:      prevTx.Vout[txIn.PrevOutPoint.Index],
+					BlockHeight: prevHeight,
+					IsCoinBase:  IsCoinBase(prevTx),
+				}
+				data, err := utxoWrap.Marshal()
+				if err != nil {
+					return nil, err
+				}
+				utxos[string(UtxoKey(&txIn.PrevOutPoint))] = data
+			}
+		}
+	}
+	return utxos, nil
+}
+
+// InstallUtxoSnapshot installs a downloaded utxo snapshot to a chain having no
+// blocks but genesis, and sets the snapshot block as both tail and eternal.
+// The caller verifies chunks against the snapshot root, and the utxo set and
+// consensus context are checked against the snapshot block header.
+func (chain *BlockChain) InstallUtxoSnapshot(snapshot *UtxoSnapshot) error {
+	chain.chainLock.Lock()
+	defer chain.chainLock.Unlock()
+
+	if chain.tail.Height != 0 {
+		return core.ErrSnapshotChainNotEmpty
+	}
+	block := snapshot.Block()
+	if block.Height == 0 {
+		return core.ErrInvalidSnapshot
+	}
+	for i, b := range snapshot.Blocks {
+		if err := validateBlock(b); err != nil {
+			return err
+		}
+		if i > 0 && (*b.BlockHash() != snapshot.Blocks[i-1].Header.PrevBlockHash ||
+			b.Height+1 != snapshot.Blocks[i-1].Height) {
+			return core.ErrInvalidSnapshot
+		}
+	}
+	if len(snapshot.Chunks) != len(snapshot.ChunkHashes) {
+		return core.ErrInvalidSnapshot
+	}
+	// blocks before utxo commitment have no utxo root to check the snapshot against
+	if block.Height < UtxoCommitmentHeight {
+		return core.ErrInvalidSnapshot
+	}
+	if !matchContextHash(snapshot.Period, &block.Header.PeriodHash) ||
+		!matchContextHash(snapshot.Candidates, &block.Header.CandidatesHash) {
+		return core.ErrInvalidSnapshotContext
+	}
+
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+	var lastKey []byte
+	utxoPrefix := []byte(UtxoPrefix + "/")
+	utxoCommitment := NewUtxoCommitment()
+	for _, chunk := range snapshot.Chunks {
+		if chunk == nil || len(chunk.Keys) != len(chunk.Values) {
+			return core.ErrInvalidSnapshot
+		}
+		for i, k := range chunk.Keys {
+			// keys are utxo keys in strictly increasing order
+			if !bytes.HasPrefix(k, utxoPrefix) || bytes.Compare(lastKey, k) >= 0 {
+				return core.ErrInvalidSnapshot
+			}
+			outPoint, err := parseUtxoKey(k)
+			if err != nil {
+				return core.ErrInvalidSnapshot
+			}
+			utxoWrap := new(types.UtxoWrap)
+			if err := utxoWrap.Unmarshal(chunk.Values[i]); err != nil {
+				return err
+			}
+			utxoCommitment.Add(outPoint, utxoWrap)
+			batch.Put(k, chunk.Values[i])
+			lastKey = k
+		}
+	}
+	// the utxo set must match the commitment in the snapshot block
+	if err := checkUtxoRoot(block, utxoCommitment); err != nil {
+		return err
+	}
+	for _, b := range snapshot.Blocks {
+		data, err := b.Marshal()
+		if err != nil {
+			return err
+		}
+		batch.Put(BlockHashKey(b.Height), b.BlockHash()[:])
+		batch.Put(BlockKey(b.BlockHash()), data)
+		for idx, tx := range b.Txs {
+			tiBuf, err := MarshalTxIndex(b.Height, uint32(idx))
+			if err != nil {
+				return err
+			}
+			txHash, err := tx.TxHash()
+			if err != nil {
+				return err
+			}
+			batch.Put(TxIndexKey(txHash), tiBuf)
+		}
+	}
+	if snapshot.Candidates != nil {
+		batch.Put(CandidatesKey(block.BlockHash()), snapshot.Candidates)
+	}
+	if snapshot.Period != nil {
+		batch.Put(PeriodKey, snapshot.Period)
+		batch.Put(PeriodContextKey(&block.Header.PeriodHash), snapshot.Period)
+	}
+	data, err := block.Marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(EternalKey, data)
+	batch.Put(SnapshotKey, data)
+	commitmentData, err := utxoCommitment.Marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(UtxoCommitmentKey(block.BlockHash()), commitmentData)
+	if err := chain.storeTailBlock(batch, block); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	chain.eternal = block
+	chain.snapshotBlock = block
+	chain.utxoCommitment = utxoCommitment
+	chain.setTailBlock(block)
+	if err := chain.loadFilters(); err != nil {
+		return err
+	}
+	logger.Infof("Install utxo snapshot at block %s height %d", block.BlockHash(), block.Height)
+	return nil
+}
+
+// matchContextHash checks the marshaled consensus context is the one hash in block
+// header commits to, and zero hash commits to none, e.g., the genesis period
+func matchContextHash(context []byte, hash *crypto.HashType) bool {
+	if *hash == zeroHash {
+		return context == nil
+	}
+	return context != nil && crypto.DoubleHashH(context) == *hash
+}
+
+// SnapshotBlock returns the block the chain is synced from with utxo snapshot,
+// i.e., blocks before it except a few ancestors are not stored. It is genesis
+// if the chain is not synced from a snapshot.
+func (chain *BlockChain) SnapshotBlock() *types.Block {
+	return chain.snapshotBlock
+}
+
+func (chain *BlockChain) loadSnapshotBlock() (*types.Block, error) {
+	data, err := chain.db.Get(SnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return chain.genesis, nil
+	}
+	block := new(types.Block)
+	if err := block.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// emptyFilter is held for blocks before the snapshot block, which are not stored
+func emptyFilter() bloom.Filter {
+	return bloom.NewFilter(1, 0.0001)
+}
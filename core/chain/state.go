@@ -0,0 +1,93 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"github.com/BOXFoundation/boxd/boxd/service"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/storage"
+)
+
+// StateSnapshot is a read-only view of chain state as of the tail block when it
+// is taken. Blocks connected or disconnected afterwards do not affect it, so
+// queries answered from one snapshot are consistent with each other.
+type StateSnapshot struct {
+	db      storage.Snapshot
+	filters BloomFilterHolder
+	tail    *types.Block
+}
+
+var _ service.ChainSnapshot = (*StateSnapshot)(nil)
+
+// StateSnapshot takes a snapshot of chain state as of the current tail block,
+// which must be released after use
+func (chain *BlockChain) StateSnapshot() (service.ChainSnapshot, error) {
+	// blocks are connected and disconnected with chainLock held
+	chain.chainLock.RLock()
+	defer chain.chainLock.RUnlock()
+
+	db, err := chain.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &StateSnapshot{
+		db:      db,
+		filters: chain.filterHolder.Snapshot(),
+		tail:    chain.tail,
+	}, nil
+}
+
+// Tail returns hash and height of the tail block the snapshot is taken as of
+func (s *StateSnapshot) Tail() (*crypto.HashType, uint32) {
+	return s.tail.BlockHash(), s.tail.Height
+}
+
+// ListAllUtxos returns all the utxos in the snapshot
+func (s *StateSnapshot) ListAllUtxos() (map[types.OutPoint]*types.UtxoWrap, error) {
+	utxos := make(map[types.OutPoint]*types.UtxoWrap)
+	iter := s.db.NewIterator(storage.BytesPrefix([]byte(UtxoPrefix + "/")))
+	defer iter.Release()
+	for iter.Next() {
+		op, err := parseUtxoKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		utxoWrap := new(types.UtxoWrap)
+		if err := utxoWrap.Unmarshal(iter.Value()); err != nil {
+			return nil, err
+		}
+		utxos[*op] = utxoWrap
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+// LoadUtxoByAddress list all the available utxos owned by an address in the
+// snapshot, including token utxos
+func (s *StateSnapshot) LoadUtxoByAddress(addr types.Address) (map[types.OutPoint]*types.UtxoWrap, error) {
+	return loadUtxoByAddress(s.db, s.filters, addr)
+}
+
+// LoadUtxo returns the utxo of the outpoint in the snapshot, or nil if there is
+// none, e.g., it is spent as of the snapshot or created afterwards
+func (s *StateSnapshot) LoadUtxo(op types.OutPoint) (*types.UtxoWrap, error) {
+	data, err := s.db.Get(UtxoKey(&op))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	utxoWrap := new(types.UtxoWrap)
+	if err := utxoWrap.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return utxoWrap, nil
+}
+
+// Release releases the snapshot
+func (s *StateSnapshot) Release() {
+	s.db.Release()
+}
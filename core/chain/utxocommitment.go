@@ -0,0 +1,343 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/BOXFoundation/boxd/core"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/storage"
+	"github.com/BOXFoundation/boxd/util"
+)
+
+// utxoCommitmentSize is the size in bytes of an element of utxo commitment
+const utxoCommitmentSize = 384
+
+// utxoCommitmentPrime is the modulus of utxo commitment, i.e., 2^3072 - 1103717,
+// the largest 3072-bit safe prime
+var utxoCommitmentPrime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 8*utxoCommitmentSize),
+	big.NewInt(1103717))
+
+// UtxoCommitment is a rolling commitment to the utxo set. Each utxo is hashed to
+// an element of the multiplicative group modulo a prime, and the set is the
+// product of its elements, so that it is independent of the order utxos are
+// added in, and a utxo is removed by dividing its element. Divisions are
+// accumulated in the denominator and done once when the commitment is hashed.
+type UtxoCommitment struct {
+	numerator   *big.Int
+	denominator *big.Int
+}
+
+// NewUtxoCommitment returns the commitment to an empty utxo set
+func NewUtxoCommitment() *UtxoCommitment {
+	return &UtxoCommitment{
+		numerator:   big.NewInt(1),
+		denominator: big.NewInt(1),
+	}
+}
+
+// NewUtxoCommitmentFromUtxos returns the commitment to the utxo set
+func NewUtxoCommitmentFromUtxos(utxos map[types.OutPoint]*types.UtxoWrap) *UtxoCommitment {
+	c := NewUtxoCommitment()
+	for outPoint, utxoWrap := range utxos {
+		if utxoWrap != nil && !utxoWrap.IsSpent {
+			c.Add(&outPoint, utxoWrap)
+		}
+	}
+	return c
+}
+
+// Add adds a utxo to the commitment
+func (c *UtxoCommitment) Add(outPoint *types.OutPoint, utxoWrap *types.UtxoWrap) {
+	c.numerator.Mul(c.numerator, utxoElement(outPoint, utxoWrap))
+	c.numerator.Mod(c.numerator, utxoCommitmentPrime)
+}
+
+// Remove removes a utxo from the commitment
+func (c *UtxoCommitment) Remove(outPoint *types.OutPoint, utxoWrap *types.UtxoWrap) {
+	c.denominator.Mul(c.denominator, utxoElement(outPoint, utxoWrap))
+	c.denominator.Mod(c.denominator, utxoCommitmentPrime)
+}
+
+// ApplyBlock updates the commitment with all transactions in the passed block,
+// with utxoSet containing utxos the block spends
+func (c *UtxoCommitment) ApplyBlock(block *types.Block, utxoSet *UtxoSet) error {
+	for _, tx := range block.Txs {
+		txHash, err := tx.TxHash()
+		if err != nil {
+			return err
+		}
+		isCoinBase := IsCoinBase(tx)
+		// outputs spent later in the same block are added here and removed below
+		for idx, txOut := range tx.Vout {
+			c.Add(&types.OutPoint{Hash: *txHash, Index: uint32(idx)}, &types.UtxoWrap{
+				Output:      txOut,
+				BlockHeight: block.Height,
+				IsCoinBase:  isCoinBase,
+			})
+		}
+		if isCoinBase {
+			continue
+		}
+		for _, txIn := range tx.Vin {
+			utxoWrap := utxoSet.FindUtxo(txIn.PrevOutPoint)
+			if utxoWrap == nil {
+				return core.ErrMissingTxOut
+			}
+			c.Remove(&txIn.PrevOutPoint, utxoWrap)
+		}
+	}
+	return nil
+}
+
+// RevertBlock undoes ApplyBlock with the same utxoSet, i.e., utxos the block spends
+func (c *UtxoCommitment) RevertBlock(block *types.Block, utxoSet *UtxoSet) error {
+	delta := NewUtxoCommitment()
+	if err := delta.ApplyBlock(block, utxoSet); err != nil {
+		return err
+	}
+	// utxos added by the block are removed, and ones removed are added back
+	c.numerator.Mul(c.numerator, delta.denominator)
+	c.numerator.Mod(c.numerator, utxoCommitmentPrime)
+	c.denominator.Mul(c.denominator, delta.numerator)
+	c.denominator.Mod(c.denominator, utxoCommitmentPrime)
+	return nil
+}
+
+// Copy returns a copy of the commitment
+func (c *UtxoCommitment) Copy() *UtxoCommitment {
+	return &UtxoCommitment{
+		numerator:   new(big.Int).Set(c.numerator),
+		denominator: new(big.Int).Set(c.denominator),
+	}
+}
+
+// normalize does the accumulated divisions
+func (c *UtxoCommitment) normalize() {
+	if c.denominator.Cmp(big.NewInt(1)) == 0 {
+		return
+	}
+	inverse := new(big.Int).ModInverse(c.denominator, utxoCommitmentPrime)
+	c.numerator.Mul(c.numerator, inverse)
+	c.numerator.Mod(c.numerator, utxoCommitmentPrime)
+	c.denominator.SetInt64(1)
+}
+
+// Hash returns the hash of the commitment, which is committed in block header
+func (c *UtxoCommitment) Hash() *crypto.HashType {
+	data, _ := c.Marshal()
+	hash := crypto.DoubleHashH(data)
+	return &hash
+}
+
+// Marshal method marshal UtxoCommitment object to binary
+func (c *UtxoCommitment) Marshal() (data []byte, err error) {
+	c.normalize()
+	data = make([]byte, utxoCommitmentSize)
+	numerator := c.numerator.Bytes()
+	copy(data[utxoCommitmentSize-len(numerator):], numerator)
+	return data, nil
+}
+
+// Unmarshal method unmarshal binary data to UtxoCommitment object
+func (c *UtxoCommitment) Unmarshal(data []byte) error {
+	if len(data) != utxoCommitmentSize {
+		return core.ErrInvalidUtxoCommitment
+	}
+	numerator := new(big.Int).SetBytes(data)
+	if numerator.Sign() == 0 || numerator.Cmp(utxoCommitmentPrime) >= 0 {
+		return core.ErrInvalidUtxoCommitment
+	}
+	c.numerator = numerator
+	c.denominator = big.NewInt(1)
+	return nil
+}
+
+// utxoElement hashes a utxo to a non-zero element modulo utxoCommitmentPrime
+func utxoElement(outPoint *types.OutPoint, utxoWrap *types.UtxoWrap) *big.Int {
+	var buf bytes.Buffer
+	buf.Write(outPoint.Hash[:])
+	util.WriteUint32(&buf, outPoint.Index)
+	util.WriteUint32(&buf, utxoWrap.BlockHeight)
+	if utxoWrap.IsCoinBase {
+		util.WriteUint8(&buf, 1)
+	} else {
+		util.WriteUint8(&buf, 0)
+	}
+	util.WriteUint64(&buf, utxoWrap.Output.Value)
+	util.WriteVarBytes(&buf, utxoWrap.Output.ScriptPubKey)
+
+	// expand the digest to the size of an element
+	digest := crypto.Sha256(buf.Bytes())
+	data := make([]byte, 0, utxoCommitmentSize)
+	for i := uint8(0); len(data) < utxoCommitmentSize; i++ {
+		data = append(data, crypto.Sha256Multi(digest, []byte{i})...)
+	}
+	element := new(big.Int).SetBytes(data)
+	element.Mod(element, utxoCommitmentPrime)
+	if element.Sign() == 0 {
+		// practically impossible
+		element.SetInt64(1)
+	}
+	return element
+}
+
+// UtxoRoot returns the tail block and the hash of commitment to the utxo set as of it
+func (chain *BlockChain) UtxoRoot() (*types.Block, *crypto.HashType) {
+	chain.chainLock.RLock()
+	defer chain.chainLock.RUnlock()
+	return chain.tail, chain.utxoCommitment.Copy().Hash()
+}
+
+// VerifyUtxoSet recomputes the commitment from all utxos in db and checks it
+// against the one as of tail. Utxos are scanned in a snapshot of db, so that
+// blocks are still connected meanwhile.
+func (chain *BlockChain) VerifyUtxoSet() error {
+	chain.chainLock.RLock()
+	db, err := chain.db.Snapshot()
+	if err != nil {
+		chain.chainLock.RUnlock()
+		return err
+	}
+	expected := chain.utxoCommitment.Copy()
+	chain.chainLock.RUnlock()
+	defer db.Release()
+
+	c, err := utxoCommitmentFromDB(db)
+	if err != nil {
+		return err
+	}
+	if *c.Hash() != *expected.Hash() {
+		return core.ErrBadUtxoRoot
+	}
+	return nil
+}
+
+// CalcUtxoRoot returns the hash of commitment to the utxo set after block
+// following tail is connected, which is to be set in its header. It is zero
+// for blocks below UtxoCommitmentHeight.
+func (chain *BlockChain) CalcUtxoRoot(block *types.Block) (*crypto.HashType, error) {
+	chain.chainLock.RLock()
+	defer chain.chainLock.RUnlock()
+	if block.Header.PrevBlockHash != *chain.tail.BlockHash() {
+		return nil, core.ErrParentBlockNotExist
+	}
+	if block.Height < UtxoCommitmentHeight {
+		return &crypto.HashType{}, nil
+	}
+	utxoSet := NewUtxoSet()
+	if err := utxoSet.LoadBlockUtxos(block, chain.db); err != nil {
+		return nil, err
+	}
+	c, err := chain.calcUtxoCommitment(block, utxoSet)
+	if err != nil {
+		return nil, err
+	}
+	return c.Hash(), nil
+}
+
+// calcUtxoCommitment returns the commitment after block is applied to tail,
+// with utxoSet containing utxos the block spends
+func (chain *BlockChain) calcUtxoCommitment(block *types.Block, utxoSet *UtxoSet) (*UtxoCommitment, error) {
+	c := chain.utxoCommitment.Copy()
+	if err := c.ApplyBlock(block, utxoSet); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkUtxoRoot checks the utxo commitment in block header
+func checkUtxoRoot(block *types.Block, c *UtxoCommitment) error {
+	if utxoRoot := c.Hash(); *utxoRoot != block.Header.UtxoRoot {
+		logger.Errorf("block utxo root is invalid: %s, calculated %s",
+			block.Header.UtxoRoot, utxoRoot)
+		return core.ErrBadUtxoRoot
+	}
+	return nil
+}
+
+func (chain *BlockChain) storeUtxoCommitment(batch storage.Batch, hash *crypto.HashType, c *UtxoCommitment) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(UtxoCommitmentKey(hash), data)
+	return nil
+}
+
+func (chain *BlockChain) loadUtxoCommitment(hash *crypto.HashType) (*UtxoCommitment, error) {
+	data, err := chain.db.Get(UtxoCommitmentKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	c := new(UtxoCommitment)
+	if err := c.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// loadTailUtxoCommitment loads the commitment as of tail, which is computed from
+// all utxos in db if absent, e.g., in db created before utxo commitment
+func (chain *BlockChain) loadTailUtxoCommitment() (*UtxoCommitment, error) {
+	c, err := chain.loadUtxoCommitment(chain.tail.BlockHash())
+	if err != nil || c != nil {
+		return c, err
+	}
+	if c, err = utxoCommitmentFromDB(chain.db); err != nil {
+		return nil, err
+	}
+	batch := chain.db.NewBatch()
+	defer batch.Close()
+	if err := chain.storeUtxoCommitment(batch, chain.tail.BlockHash(), c); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// revertUtxoCommitment returns the commitment as of parent of tail block by
+// undoing the block, for parents connected before utxo commitment which have
+// none stored
+func (chain *BlockChain) revertUtxoCommitment(block *types.Block) (*UtxoCommitment, error) {
+	utxoSet := NewUtxoSet()
+	if err := utxoSet.LoadBlockUtxos(block, chain.db); err != nil {
+		return nil, err
+	}
+	if err := chain.loadSpentUtxos(utxoSet); err != nil {
+		return nil, err
+	}
+	c := chain.utxoCommitment.Copy()
+	if err := c.RevertBlock(block, utxoSet); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func utxoCommitmentFromDB(db storage.Reader) (*UtxoCommitment, error) {
+	c := NewUtxoCommitment()
+	iter := db.NewIterator(storage.BytesPrefix([]byte(UtxoPrefix + "/")))
+	defer iter.Release()
+	for iter.Next() {
+		outPoint, err := parseUtxoKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		utxoWrap := new(types.UtxoWrap)
+		if err := utxoWrap.Unmarshal(iter.Value()); err != nil {
+			return nil, err
+		}
+		c.Add(outPoint, utxoWrap)
+	}
+	return c, iter.Error()
+}
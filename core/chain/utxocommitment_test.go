@@ -0,0 +1,81 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/facebookgo/ensure"
+)
+
+func TestUtxoCommitment(t *testing.T) {
+	utxos := make(map[types.OutPoint]*types.UtxoWrap)
+	for i := 0; i < 4; i++ {
+		outPoint := types.OutPoint{Hash: crypto.HashType{byte(i)}, Index: uint32(i)}
+		utxos[outPoint] = &types.UtxoWrap{
+			Output:      &corepb.TxOut{Value: uint64(i), ScriptPubKey: []byte{byte(i)}},
+			BlockHeight: uint32(i),
+		}
+	}
+	empty := NewUtxoCommitment().Hash()
+
+	// independent of the order utxos are added in
+	c := NewUtxoCommitment()
+	for i := 3; i >= 0; i-- {
+		outPoint := types.OutPoint{Hash: crypto.HashType{byte(i)}, Index: uint32(i)}
+		c.Add(&outPoint, utxos[outPoint])
+	}
+	ensure.DeepEqual(t, c.Hash(), NewUtxoCommitmentFromUtxos(utxos).Hash())
+	ensure.NotDeepEqual(t, c.Hash(), empty)
+
+	// removing utxos undoes adding them
+	c1 := c.Copy()
+	for outPoint, utxoWrap := range utxos {
+		c1.Remove(&outPoint, utxoWrap)
+	}
+	ensure.DeepEqual(t, c1.Hash(), empty)
+
+	// any change of a utxo changes the commitment
+	outPoint := types.OutPoint{Hash: crypto.HashType{0}, Index: 0}
+	c1 = c.Copy()
+	c1.Remove(&outPoint, utxos[outPoint])
+	c1.Add(&outPoint, &types.UtxoWrap{Output: utxos[outPoint].Output, IsCoinBase: true})
+	ensure.NotDeepEqual(t, c1.Hash(), c.Hash())
+
+	// reverting a block undoes applying it
+	tx := &types.Transaction{
+		Vin:  []*types.TxIn{{PrevOutPoint: outPoint}},
+		Vout: []*corepb.TxOut{{Value: 1, ScriptPubKey: []byte{1}}},
+	}
+	block := &types.Block{Header: &types.BlockHeader{}, Height: 5, Txs: []*types.Transaction{tx}}
+	utxoSet := NewUtxoSet()
+	utxoSet.utxoMap[outPoint] = utxos[outPoint]
+	c1 = c.Copy()
+	ensure.Nil(t, c1.ApplyBlock(block, utxoSet))
+	ensure.NotDeepEqual(t, c1.Hash(), c.Hash())
+	ensure.Nil(t, c1.RevertBlock(block, utxoSet))
+	ensure.DeepEqual(t, c1.Hash(), c.Hash())
+
+	// marshal and unmarshal
+	data, err := c.Marshal()
+	ensure.Nil(t, err)
+	c1 = new(UtxoCommitment)
+	ensure.Nil(t, c1.Unmarshal(data))
+	ensure.DeepEqual(t, c1.Hash(), c.Hash())
+	ensure.NotNil(t, c1.Unmarshal(data[1:]))
+}
+
+func TestParseUtxoKey(t *testing.T) {
+	outPoint := &types.OutPoint{Hash: crypto.HashType{0x01, 0x02}, Index: 300}
+	parsed, err := parseUtxoKey(UtxoKey(outPoint))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, parsed, outPoint)
+
+	_, err = parseUtxoKey(BlockKey(&outPoint.Hash))
+	ensure.NotNil(t, err)
+}
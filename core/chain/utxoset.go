@@ -99,6 +99,7 @@ type TxWrap struct {
 	Tx             *types.Transaction
 	AddedTimestamp int64
 	Height         uint32
+	Fee            uint64
 	FeePerKB       uint64
 }
 
@@ -240,8 +241,8 @@ func (u *UtxoSet) ApplyTxWithScriptFilter(tx *types.Transaction, blockHeight uin
 	return nil
 }
 
-// WriteUtxoSetToDB store utxo set to database.
-func (u *UtxoSet) WriteUtxoSetToDB(db storage.Table) error {
+// WriteUtxoSetToDB adds utxo set changes to the batch writing database.
+func (u *UtxoSet) WriteUtxoSetToDB(batch storage.Batch) error {
 
 	for outpoint, utxoWrap := range u.utxoMap {
 		if utxoWrap == nil || !utxoWrap.IsModified {
@@ -250,10 +251,7 @@ func (u *UtxoSet) WriteUtxoSetToDB(db storage.Table) error {
 		utxoKey := UtxoKey(&outpoint)
 		// Remove the utxo entry if it is spent.
 		if utxoWrap.IsSpent {
-			err := db.Del(utxoKey)
-			if err != nil {
-				return err
-			}
+			batch.Del(utxoKey)
 			continue
 		} else if utxoWrap.IsModified {
 			// Serialize and store the utxo entry.
@@ -261,10 +259,7 @@ func (u *UtxoSet) WriteUtxoSetToDB(db storage.Table) error {
 			if err != nil {
 				return err
 			}
-			err = db.Put(utxoKey, serialized)
-			if err != nil {
-				return err
-			}
+			batch.Put(utxoKey, serialized)
 		}
 	}
 	// free memory
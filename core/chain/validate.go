@@ -42,14 +42,21 @@ func validateBlock(block *types.Block) error {
 		return core.ErrNoTransactions
 	}
 
-	// TODO: check before deserialization
-	// // A block must not exceed the maximum allowed block payload when serialized.
-	// serializedSize := msgBlock.SerializeSizeStripped()
-	// if serializedSize > MaxBlockSize {
-	// 	logger.Errorf("serialized block is too big - got %d, "+
-	// 		"max %d", serializedSize, MaxBlockSize)
-	// 	return ErrBlockTooBig
-	// }
+	// A block must not have more transactions than the max allowed.
+	if numTx > MaxTxsPerBlock {
+		logger.Errorf("block contains too many transactions - got %d, max %d", numTx, MaxTxsPerBlock)
+		return core.ErrTooManyTxs
+	}
+
+	// A block must not exceed the maximum allowed block payload when serialized.
+	serializedSize, err := block.SerializeSize()
+	if err != nil {
+		return err
+	}
+	if serializedSize > MaxBlockSize {
+		logger.Errorf("serialized block is too big - got %d, max %d", serializedSize, MaxBlockSize)
+		return core.ErrBlockTooBig
+	}
 
 	// First tx must be coinbase.
 	transactions := block.Txs
@@ -103,10 +110,10 @@ func validateBlock(block *types.Block) error {
 	// Enforce number of signature operations.
 	totalSigOpCnt := 0
 	for _, tx := range transactions {
-		totalSigOpCnt += countSigOps(tx)
-		if totalSigOpCnt > maxBlockSigOpCnt {
+		totalSigOpCnt += CountSigOps(tx)
+		if totalSigOpCnt > MaxBlockSigOpCnt {
 			logger.Errorf("block contains too many signature "+
-				"operations - got %v, max %v", totalSigOpCnt, maxBlockSigOpCnt)
+				"operations - got %v, max %v", totalSigOpCnt, MaxBlockSigOpCnt)
 			return core.ErrTooManySigOps
 		}
 	}
@@ -143,6 +150,32 @@ func IsTxFinalized(tx *types.Transaction, blockHeight uint32, blockTime int64) b
 	return true
 }
 
+// checkCoinbaseValue ensures the coinbase does not pay more than block subsidy
+// plus fees of all transactions in the block. It must not pay to vote or unbond
+// outputs either, which are only created by vote and unvote txs, or votes never
+// counted for a candidate would be deducted when they are unvoted.
+func checkCoinbaseValue(block *types.Block, totalFees uint64) error {
+	var totalCoinbaseOutput uint64
+	for _, txOut := range block.Txs[0].Vout {
+		scriptPubKey := script.NewScriptFromBytes(txOut.ScriptPubKey)
+		if scriptPubKey.IsVote() || scriptPubKey.IsUnbond() {
+			return core.ErrInvalidCoinbaseOutput
+		}
+		lastTotalCoinbaseOutput := totalCoinbaseOutput
+		totalCoinbaseOutput += txOut.Value
+		if totalCoinbaseOutput < lastTotalCoinbaseOutput {
+			return core.ErrBadCoinbaseValue
+		}
+	}
+	expectedCoinbaseOutput := CalcBlockSubsidy(block.Height) + totalFees
+	if totalCoinbaseOutput > expectedCoinbaseOutput {
+		logger.Errorf("coinbase transaction for block pays %v which is more than expected value of %v",
+			totalCoinbaseOutput, expectedCoinbaseOutput)
+		return core.ErrBadCoinbaseValue
+	}
+	return nil
+}
+
 func validateBlockScripts(utxoSet *UtxoSet, block *types.Block) error {
 	// Skip coinbases.
 	for _, tx := range block.Txs[1:] {
@@ -244,6 +277,13 @@ func ValidateTxInputs(utxoSet *UtxoSet, tx *types.Transaction, txHeight uint32)
 		}
 	}
 
+	if err := checkVoteTx(utxoSet, tx, txHeight); err != nil {
+		return 0, err
+	}
+	if err := checkCandidateTx(utxoSet, tx); err != nil {
+		return 0, err
+	}
+
 	// Sum the total output amount.
 	var totalOutputAmount uint64
 	tokenOutputAmounts := make(map[script.TokenID]uint64)
@@ -275,32 +315,156 @@ func ValidateTxInputs(utxoSet *UtxoSet, tx *types.Transaction, txHeight uint32)
 		return 0, core.ErrTokenInputsOutputNotEqual
 	}
 
+	// A tx must not use more than its share of the block signature operation budget,
+	// including those in redeem scripts of p2sh inputs.
+	sigOpCnt := CountSigOps(tx) + CountP2SHSigOps(utxoSet, tx)
+	if sigOpCnt > MaxTxSigOpCnt {
+		logger.Errorf("transaction %v contains too many signature operations - got %v, max %v",
+			txHash, sigOpCnt, MaxTxSigOpCnt)
+		return 0, core.ErrTooManyTxSigOps
+	}
+
 	txFee := totalInputAmount - totalOutputAmount
 	return txFee, nil
 }
 
+// checkVoteTx checks coins locked as votes and unbonded from votes in tx, whose inputs
+// are known to exist in utxoSet. Vote outputs are only created by vote txs for the
+// candidate in their content, and only spent by unvote txs paying to unbond outputs,
+// which are spendable after unbonding period.
+func checkVoteTx(utxoSet *UtxoSet, tx *types.Transaction, txHeight uint32) error {
+	txType := types.GeneralTx
+	if tx.Data != nil {
+		txType = int(tx.Data.Type)
+	}
+
+	var candidate *types.AddressHash
+	if txType == types.VoteTx {
+		voteContent := new(types.VoteContent)
+		if err := voteContent.Unmarshal(tx.Data.Content); err != nil {
+			return core.ErrInvalidVoteTx
+		}
+		addr := voteContent.Addr()
+		candidate = &addr
+	}
+	voteOutputCnt := 0
+	for _, txOut := range tx.Vout {
+		scriptPubKey := script.NewScriptFromBytes(txOut.ScriptPubKey)
+		if scriptPubKey.IsVote() {
+			target, err := scriptPubKey.GetVoteCandidate()
+			if err != nil || candidate == nil || *target != *candidate {
+				return core.ErrInvalidVoteTx
+			}
+			voteOutputCnt++
+		} else if txType == types.UnvoteTx && !scriptPubKey.IsUnbond() {
+			return core.ErrInvalidUnvoteTx
+		}
+	}
+	if txType == types.VoteTx && voteOutputCnt == 0 {
+		return core.ErrInvalidVoteTx
+	}
+
+	voteInputCnt := 0
+	for _, txIn := range tx.Vin {
+		utxo := utxoSet.FindUtxo(txIn.PrevOutPoint)
+		scriptPubKey := script.NewScriptFromBytes(utxo.Output.ScriptPubKey)
+		if scriptPubKey.IsVote() {
+			if txType != types.UnvoteTx {
+				return core.ErrInvalidUnvoteTx
+			}
+			voteInputCnt++
+		} else if scriptPubKey.IsUnbond() && txHeight-utxo.BlockHeight < UnbondingPeriod {
+			logger.Errorf("tried to spend unbonding output %v from height %v at height %v "+
+				"before unbonding period of %v blocks", txIn.PrevOutPoint, utxo.BlockHeight,
+				txHeight, UnbondingPeriod)
+			return core.ErrImmatureUnbond
+		}
+	}
+	if txType == types.UnvoteTx && voteInputCnt == 0 {
+		return core.ErrInvalidUnvoteTx
+	}
+	return nil
+}
+
+// checkCandidateTx checks register, unregister and update candidate txs, whose inputs
+// are known to exist in utxoSet. Such a tx is only valid if it spends coins of the
+// candidate in its content, so that it is authorized by the candidate, and the info
+// published by the candidate is within size limits.
+func checkCandidateTx(utxoSet *UtxoSet, tx *types.Transaction) error {
+	if tx.Data == nil {
+		return nil
+	}
+
+	var candidate types.AddressHash
+	switch int(tx.Data.Type) {
+	case types.RegisterCandidateTx:
+		signUpContent := new(types.SignUpContent)
+		if err := signUpContent.Unmarshal(tx.Data.Content); err != nil ||
+			len(signUpContent.PeerID()) > MaxCandidatePeerIDLen {
+			return core.ErrInvalidCandidateTx
+		}
+		candidate = signUpContent.Addr()
+	case types.UnregisterCandidateTx:
+		unregisterContent := new(types.UnregisterContent)
+		if err := unregisterContent.Unmarshal(tx.Data.Content); err != nil {
+			return core.ErrInvalidCandidateTx
+		}
+		candidate = unregisterContent.Addr()
+	case types.UpdateCandidateTx:
+		infoContent := new(types.CandidateInfoContent)
+		if err := infoContent.Unmarshal(tx.Data.Content); err != nil ||
+			infoContent.PeerID() == "" ||
+			len(infoContent.PeerID()) > MaxCandidatePeerIDLen ||
+			len(infoContent.Endpoint()) > MaxCandidateEndpointLen ||
+			len(infoContent.Description()) > MaxCandidateDescriptionLen {
+			return core.ErrInvalidCandidateTx
+		}
+		candidate = infoContent.Addr()
+	default:
+		return nil
+	}
+
+	for _, txIn := range tx.Vin {
+		utxo := utxoSet.FindUtxo(txIn.PrevOutPoint)
+		scriptPubKey := script.NewScriptFromBytes(utxo.Output.ScriptPubKey)
+		if !scriptPubKey.IsPayToPubKeyHash() {
+			continue
+		}
+		if addr, err := scriptPubKey.ExtractAddress(); err == nil && *addr.Hash160() == candidate {
+			return nil
+		}
+	}
+	return core.ErrInvalidCandidateTx
+}
+
 // ValidateTransactionPreliminary performs some preliminary checks on a transaction to
 // ensure it is sane. These checks are context free.
 func ValidateTransactionPreliminary(tx *types.Transaction) error {
+	// An evidence tx only carries evidence, and moves no coins.
+	isEvidence := IsEvidenceTx(tx)
+	if isEvidence && (len(tx.Vin) != 0 || len(tx.Vout) != 0) {
+		return core.ErrInvalidEvidenceTx
+	}
+
 	// A transaction must have at least one input.
-	if len(tx.Vin) == 0 {
+	if len(tx.Vin) == 0 && !isEvidence {
 		return core.ErrNoTxInputs
 	}
 
 	// A transaction must have at least one output.
-	if len(tx.Vout) == 0 {
+	if len(tx.Vout) == 0 && !isEvidence {
 		return core.ErrNoTxOutputs
 	}
 
-	// TOOD: check before deserialization
-	// // A transaction must not exceed the maximum allowed block payload when
-	// // serialized.
-	// serializedTxSize := tx.MsgTx().SerializeSizeStripped()
-	// if serializedTxSize > MaxBlockBaseSize {
-	// 	str := fmt.Sprintf("serialized transaction is too big - got "+
-	// 		"%d, max %d", serializedTxSize, MaxBlockBaseSize)
-	// 	return ruleError(ErrTxTooBig, str)
-	// }
+	// A transaction must not exceed the maximum allowed block payload when serialized.
+	serializedTxSize, err := tx.SerializeSize()
+	if err != nil {
+		return err
+	}
+	if serializedTxSize > MaxBlockSize {
+		logger.Errorf("serialized transaction is too big - got %d, max %d", serializedTxSize, MaxBlockSize)
+		return core.ErrTxTooBig
+	}
 
 	// Ensure the transaction amounts are in range. Each transaction
 	// output must not be negative or more than the max allowed per
@@ -19,8 +19,11 @@ var (
 	ErrFirstTxNotCoinbase          = errors.New("First transaction in block is not a coinbase")
 	ErrMultipleCoinbases           = errors.New("Block contains multiple coinbase transactions")
 	ErrBadMerkleRoot               = errors.New("Merkel root mismatch")
+	ErrBadUtxoRoot                 = errors.New("Utxo root mismatch")
 	ErrDuplicateTx                 = errors.New("Duplicate transactions in a block")
 	ErrTooManySigOps               = errors.New("Too many signature operations in a block")
+	ErrTooManyTxSigOps             = errors.New("Too many signature operations in a transaction")
+	ErrTooManyTxs                  = errors.New("Too many transactions in a block")
 	ErrBadFees                     = errors.New("total fees for block overflows accumulator")
 	ErrBadCoinbaseValue            = errors.New("Coinbase pays more than expected value")
 	ErrUnfinalizedTx               = errors.New("Transaction has not been finalized")
@@ -45,6 +48,11 @@ var (
 	ErrNonLocalMessage            = errors.New("Received non-local message")
 	ErrLocalMessageNotChainUpdate = errors.New("Received local message is not a chain update")
 	ErrDoubleSpendTx              = errors.New("transaction must not use any of the same outputs as other transactions already in the pool")
+	ErrTxFeeTooLow                = errors.New("Transaction fee is less than the minimum relay fee")
+	ErrTxPoolFull                 = errors.New("Transaction pool is full and the transaction fee is too low to evict others")
+	ErrReplacementFeeTooLow       = errors.New("Replacement transaction does not pay enough fee to replace conflicting transactions")
+	ErrReplacementSpendsConflict  = errors.New("Replacement transaction spends outputs of transactions it replaces")
+	ErrTooManyReplacements        = errors.New("Replacement transaction evicts too many transactions")
 
 	//block.go
 	ErrSerializeHeader                = errors.New("Serialize block header error")
@@ -61,11 +69,13 @@ var (
 
 	//address.go
 	ErrInvalidPKHash        = errors.New("pkHash must be 20 bytes")
+	ErrInvalidScriptHash    = errors.New("scriptHash must be 20 bytes")
 	ErrInvalidAddressString = errors.New("invalid box address format")
 
 	//utils.go
 	ErrNoTxInputs           = errors.New("Transaction has no inputs")
 	ErrNoTxOutputs          = errors.New("Transaction has no outputs")
+	ErrTxTooBig             = errors.New("Transaction too big")
 	ErrBadTxOutValue        = errors.New("Invalid output value")
 	ErrDuplicateTxInputs    = errors.New("Transaction contains duplicate inputs")
 	ErrBadCoinbaseScriptLen = errors.New("Coinbase scriptSig out of range")
@@ -73,15 +83,43 @@ var (
 	ErrMissingTxOut         = errors.New("Referenced utxo does not exist")
 	ErrImmatureSpend        = errors.New("Attempting to spend an immature coinbase")
 	ErrSpendTooHigh         = errors.New("Transaction is attempting to spend more value than the sum of all of its inputs")
+	ErrInvalidVoteTx        = errors.New("Vote outputs must be created by vote transaction for its candidate")
+	ErrInvalidUnvoteTx      = errors.New("Vote outputs must be spent by unvote transaction paying to unbond outputs")
+	ErrImmatureUnbond       = errors.New("Attempting to spend unbonding coins before unbonding period")
+	ErrInvalidEvidenceTx    = errors.New("Evidence transaction must have no inputs or outputs")
+	ErrInvalidCandidateTx   = errors.New("Candidate transaction must spend coins of the candidate and carry valid content")
+
+	//validate.go
+	ErrInvalidCoinbaseOutput = errors.New("Coinbase must not pay to vote or unbond outputs")
 
 	//utxoset.go
 	ErrTxOutIndexOob               = errors.New("Transaction output index out of bound")
 	ErrAddExistingUtxo             = errors.New("Trying to add utxo already existed")
 	ErrInvalidUtxoWrapProtoMessage = errors.New("Invalid utxo wrap proto message")
 
+	//utxocommitment.go
+	ErrInvalidUtxoCommitment = errors.New("Invalid utxo commitment")
+
+	//snapshot.go
+	ErrSnapshotBlockNotEternal = errors.New("Snapshot block is not an eternal block on main chain")
+	ErrSnapshotChainNotEmpty   = errors.New("Utxo snapshot can only be installed to an empty chain")
+	ErrInvalidSnapshot         = errors.New("Invalid utxo snapshot")
+	ErrInvalidSnapshotContext  = errors.New("Utxo snapshot consensus context does not match snapshot block")
+
+	//genesis.go
+	ErrInvalidGenesis    = errors.New("Invalid genesis spec")
+	ErrChainInitialized  = errors.New("Chain is already initialized with another genesis")
+	ErrGenesisFileFormat = errors.New("Genesis file must be in json or yaml format")
+
 	//filterholder.go
 	ErrInvalidFilterHeight = errors.New("Filter can only be added in chain sequence")
 	ErrLoadBlockFilters    = errors.New("Fail to load block filters")
 
-	EvilBehavior = []interface{}{ErrInvalidTime, ErrNoTransactions, ErrBlockTooBig, ErrFirstTxNotCoinbase, ErrMultipleCoinbases, ErrBadMerkleRoot, ErrDuplicateTx, ErrTooManySigOps, ErrBadFees, ErrBadCoinbaseValue, ErrUnfinalizedTx, ErrWrongBlockHeight, ErrDuplicateTxInPool, ErrDuplicateTxInOrphanPool, ErrCoinbaseTx, ErrNonStandardTransaction, ErrOutPutAlreadySpent, ErrOrphanTransaction, ErrDoubleSpendTx}
+	//migration.go
+	ErrSchemaTooNew       = errors.New("Database is of a newer schema version than the binary supports")
+	ErrInvalidMigrations  = errors.New("Migrations must upgrade schema version one by one")
+	ErrInvalidSchemaValue = errors.New("Invalid schema version in database")
+	ErrReinitRequired     = errors.New("Database cannot be migrated, remove it and resync the chain")
+
+	EvilBehavior = []interface{}{ErrInvalidTime, ErrNoTransactions, ErrBlockTooBig, ErrFirstTxNotCoinbase, ErrMultipleCoinbases, ErrBadMerkleRoot, ErrBadUtxoRoot, ErrDuplicateTx, ErrTooManySigOps, ErrTooManyTxSigOps, ErrTooManyTxs, ErrBadFees, ErrBadCoinbaseValue, ErrUnfinalizedTx, ErrWrongBlockHeight, ErrDuplicateTxInPool, ErrDuplicateTxInOrphanPool, ErrCoinbaseTx, ErrNonStandardTransaction, ErrOutPutAlreadySpent, ErrOrphanTransaction, ErrDoubleSpendTx}
 )
@@ -28,13 +28,14 @@ type BlockHeader struct {
 	Magic          uint32 `protobuf:"varint,5,opt,name=magic,proto3" json:"magic,omitempty"`
 	PeriodHash     []byte `protobuf:"bytes,6,opt,name=period_hash,json=periodHash,proto3" json:"period_hash,omitempty"`
 	CandidatesHash []byte `protobuf:"bytes,7,opt,name=candidates_hash,json=candidatesHash,proto3" json:"candidates_hash,omitempty"`
+	UtxoRoot       []byte `protobuf:"bytes,8,opt,name=utxo_root,json=utxoRoot,proto3" json:"utxo_root,omitempty"`
 }
 
 func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{0}
+	return fileDescriptor_block_77df8435b6e254a7, []int{0}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -112,6 +113,13 @@ func (m *BlockHeader) GetCandidatesHash() []byte {
 	return nil
 }
 
+func (m *BlockHeader) GetUtxoRoot() []byte {
+	if m != nil {
+		return m.UtxoRoot
+	}
+	return nil
+}
+
 type Block struct {
 	Header    *BlockHeader   `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Txs       []*Transaction `protobuf:"bytes,2,rep,name=txs" json:"txs,omitempty"`
@@ -123,7 +131,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{1}
+	return fileDescriptor_block_77df8435b6e254a7, []int{1}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -193,7 +201,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{2}
+	return fileDescriptor_block_77df8435b6e254a7, []int{2}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -274,7 +282,7 @@ func (m *TxIn) Reset()         { *m = TxIn{} }
 func (m *TxIn) String() string { return proto.CompactTextString(m) }
 func (*TxIn) ProtoMessage()    {}
 func (*TxIn) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{3}
+	return fileDescriptor_block_77df8435b6e254a7, []int{3}
 }
 func (m *TxIn) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -333,7 +341,7 @@ func (m *TxOut) Reset()         { *m = TxOut{} }
 func (m *TxOut) String() string { return proto.CompactTextString(m) }
 func (*TxOut) ProtoMessage()    {}
 func (*TxOut) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{4}
+	return fileDescriptor_block_77df8435b6e254a7, []int{4}
 }
 func (m *TxOut) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -385,7 +393,7 @@ func (m *OutPoint) Reset()         { *m = OutPoint{} }
 func (m *OutPoint) String() string { return proto.CompactTextString(m) }
 func (*OutPoint) ProtoMessage()    {}
 func (*OutPoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{5}
+	return fileDescriptor_block_77df8435b6e254a7, []int{5}
 }
 func (m *OutPoint) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -437,7 +445,7 @@ func (m *Data) Reset()         { *m = Data{} }
 func (m *Data) String() string { return proto.CompactTextString(m) }
 func (*Data) ProtoMessage()    {}
 func (*Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{6}
+	return fileDescriptor_block_77df8435b6e254a7, []int{6}
 }
 func (m *Data) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -492,7 +500,7 @@ func (m *UtxoWrap) Reset()         { *m = UtxoWrap{} }
 func (m *UtxoWrap) String() string { return proto.CompactTextString(m) }
 func (*UtxoWrap) ProtoMessage()    {}
 func (*UtxoWrap) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_58645f32f7e44a85, []int{7}
+	return fileDescriptor_block_77df8435b6e254a7, []int{7}
 }
 func (m *UtxoWrap) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -620,6 +628,12 @@ func (m *BlockHeader) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintBlock(dAtA, i, uint64(len(m.CandidatesHash)))
 		i += copy(dAtA[i:], m.CandidatesHash)
 	}
+	if len(m.UtxoRoot) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.UtxoRoot)))
+		i += copy(dAtA[i:], m.UtxoRoot)
+	}
 	return i, nil
 }
 
@@ -970,6 +984,10 @@ func (m *BlockHeader) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovBlock(uint64(l))
 	}
+	l = len(m.UtxoRoot)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
 	return n
 }
 
@@ -1349,6 +1367,37 @@ func (m *BlockHeader) Unmarshal(dAtA []byte) error {
 				m.CandidatesHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UtxoRoot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UtxoRoot = append(m.UtxoRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.UtxoRoot == nil {
+				m.UtxoRoot = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
@@ -2436,48 +2485,49 @@ var (
 	ErrIntOverflowBlock   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("block.proto", fileDescriptor_block_58645f32f7e44a85) }
-
-var fileDescriptor_block_58645f32f7e44a85 = []byte{
-	// 636 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xad, 0x1b, 0x27, 0x71, 0x6e, 0x92, 0x16, 0x0d, 0x08, 0xb9, 0x3c, 0x4c, 0x6a, 0x51, 0x88,
-	0x84, 0xd4, 0x05, 0x54, 0x7c, 0x40, 0xcb, 0xa2, 0x08, 0xa1, 0x56, 0xd3, 0x22, 0x96, 0xd6, 0xc4,
-	0x1e, 0x92, 0x51, 0x9b, 0x19, 0xe3, 0x19, 0x47, 0xc9, 0x5f, 0xc0, 0xa7, 0xf0, 0x05, 0x6c, 0x59,
-	0x76, 0xc9, 0x12, 0xb5, 0x2b, 0xfe, 0x02, 0xcd, 0x9d, 0x49, 0x13, 0x21, 0xc1, 0x2e, 0xe7, 0xdc,
-	0xe3, 0x73, 0x9f, 0x13, 0xe8, 0x8e, 0x2e, 0x55, 0x7e, 0xb1, 0x5f, 0x56, 0xca, 0x28, 0xd2, 0xca,
-	0x55, 0xc5, 0xcb, 0x51, 0xfa, 0x3b, 0x80, 0xee, 0xa1, 0xe5, 0x8f, 0x39, 0x2b, 0x78, 0x45, 0x62,
-	0x68, 0xcf, 0x78, 0xa5, 0x85, 0x92, 0x71, 0x30, 0x08, 0x86, 0x4d, 0xba, 0x84, 0xe4, 0x19, 0x6c,
-	0x97, 0x15, 0x9f, 0x65, 0xe8, 0x92, 0x4d, 0x98, 0x9e, 0xc4, 0x9b, 0x83, 0x60, 0xd8, 0xa3, 0x7d,
-	0x4b, 0x3b, 0x0f, 0xa6, 0x27, 0x64, 0x07, 0x22, 0x33, 0xd7, 0x59, 0xa5, 0x94, 0x89, 0x1b, 0x28,
-	0x68, 0x9b, 0xb9, 0xa6, 0x4a, 0x19, 0xf2, 0x18, 0xc0, 0x88, 0x29, 0xcf, 0xb4, 0x61, 0xd3, 0x32,
-	0x0e, 0x07, 0xc1, 0xb0, 0x41, 0x3b, 0x96, 0x39, 0xb3, 0x04, 0xb9, 0x07, 0xcd, 0x29, 0x1b, 0x8b,
-	0x3c, 0x6e, 0x0e, 0x82, 0x61, 0x9f, 0x3a, 0x40, 0x9e, 0x40, 0xb7, 0xe4, 0x95, 0x50, 0x85, 0xcb,
-	0xd9, 0x42, 0x4b, 0x70, 0x14, 0x26, 0x7c, 0x0e, 0xdb, 0x39, 0x93, 0x85, 0x28, 0x98, 0xe1, 0xda,
-	0x89, 0xda, 0x28, 0xda, 0x5a, 0xd1, 0x56, 0x98, 0x7e, 0x0d, 0xa0, 0x89, 0x75, 0x92, 0x17, 0xd0,
-	0x9a, 0x60, 0xbf, 0xd8, 0x64, 0xf7, 0xe5, 0xdd, 0x7d, 0x37, 0x8e, 0xfd, 0xb5, 0x51, 0x50, 0x2f,
-	0x21, 0x7b, 0xd0, 0x30, 0x73, 0x1d, 0x6f, 0x0e, 0x1a, 0xeb, 0xca, 0xf3, 0x8a, 0x49, 0xcd, 0x72,
-	0x23, 0x94, 0xa4, 0x36, 0x4e, 0xee, 0x5b, 0x4f, 0x31, 0x9e, 0xb8, 0xae, 0xfb, 0xd4, 0x23, 0xf2,
-	0x08, 0x3a, 0x5a, 0x8c, 0x25, 0x33, 0x75, 0xc5, 0xb1, 0xe7, 0x1e, 0x5d, 0x11, 0xe9, 0xf7, 0x00,
-	0xba, 0x6b, 0x56, 0xff, 0x99, 0x7f, 0x02, 0x8d, 0x99, 0x90, 0xbe, 0x8c, 0xde, 0x6d, 0x19, 0xf3,
-	0xb7, 0x92, 0xda, 0x00, 0xd9, 0x85, 0x70, 0xa6, 0x6a, 0x9b, 0xdd, 0x0a, 0xfa, 0x2b, 0xc1, 0x49,
-	0x6d, 0x28, 0x86, 0xc8, 0x00, 0xc2, 0x82, 0x19, 0x86, 0x55, 0xac, 0x79, 0xbc, 0x61, 0x86, 0x51,
-	0x8c, 0xfc, 0x63, 0x05, 0x0f, 0xa1, 0x83, 0x4b, 0xb7, 0xab, 0xc2, 0x05, 0x34, 0x68, 0x64, 0x89,
-	0x73, 0x31, 0xe5, 0xe9, 0x02, 0x42, 0x5b, 0x04, 0x79, 0x0d, 0x5b, 0x78, 0x1f, 0xaa, 0x36, 0x59,
-	0xa9, 0x84, 0x34, 0x7e, 0xb6, 0x77, 0x96, 0x69, 0x4e, 0x6a, 0x73, 0x6a, 0x79, 0xda, 0xb3, 0xba,
-	0x25, 0xb2, 0x47, 0xa1, 0xf3, 0x4a, 0x94, 0x26, 0xd3, 0x62, 0xec, 0x4f, 0xaa, 0xe3, 0x98, 0x33,
-	0x31, 0x26, 0x0f, 0x20, 0xd2, 0xfc, 0x73, 0xcd, 0x65, 0xce, 0xfd, 0x60, 0x6f, 0x71, 0x7a, 0x04,
-	0x4d, 0x6c, 0xcf, 0x96, 0x3d, 0x63, 0x97, 0x35, 0xc7, 0x94, 0x21, 0x75, 0x80, 0x3c, 0x85, 0x2d,
-	0xef, 0x5c, 0xd6, 0xa3, 0xec, 0x82, 0x2f, 0xbc, 0x7b, 0xcf, 0xb1, 0xa7, 0xf5, 0xe8, 0x1d, 0x5f,
-	0xa4, 0x07, 0x10, 0xdd, 0xd6, 0x42, 0x20, 0xc4, 0xfb, 0x09, 0x50, 0x87, 0xbf, 0xad, 0xb7, 0x90,
-	0x05, 0x9f, 0xe3, 0xc7, 0x7d, 0xea, 0x40, 0x7a, 0x00, 0xa1, 0x1d, 0x9b, 0xfd, 0xc2, 0x2c, 0x4a,
-	0xee, 0x97, 0x85, 0xbf, 0xed, 0x0e, 0x73, 0x25, 0x0d, 0x97, 0xc6, 0x27, 0x5c, 0xc2, 0xf4, 0x5b,
-	0x00, 0xd1, 0x07, 0x33, 0x57, 0x1f, 0x2b, 0x56, 0x92, 0x3d, 0x68, 0xa9, 0xda, 0x94, 0xf5, 0x72,
-	0x50, 0x7f, 0xad, 0xcc, 0x07, 0xc9, 0x2e, 0xf4, 0xfc, 0x93, 0x73, 0xd7, 0xe5, 0xca, 0x70, 0x8f,
-	0xf9, 0xd8, 0x9d, 0xd8, 0x0e, 0x44, 0x42, 0x67, 0xba, 0xb4, 0x19, 0xed, 0x8c, 0x22, 0xda, 0x16,
-	0xfa, 0xcc, 0x42, 0xfb, 0x7a, 0x84, 0xce, 0x72, 0x25, 0xe4, 0x88, 0x69, 0x77, 0x7f, 0x11, 0x05,
-	0xa1, 0x8f, 0x3c, 0xe3, 0x05, 0x53, 0x55, 0x88, 0x4f, 0x82, 0x17, 0xb8, 0x77, 0x14, 0xbc, 0xf7,
-	0xcc, 0x61, 0xfc, 0xe3, 0x3a, 0x09, 0xae, 0xae, 0x93, 0xe0, 0xd7, 0x75, 0x12, 0x7c, 0xb9, 0x49,
-	0x36, 0xae, 0x6e, 0x92, 0x8d, 0x9f, 0x37, 0xc9, 0xc6, 0xa8, 0x85, 0x7f, 0x25, 0xaf, 0xfe, 0x04,
-	0x00, 0x00, 0xff, 0xff, 0xde, 0x0c, 0xce, 0x6f, 0x59, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("block.proto", fileDescriptor_block_77df8435b6e254a7) }
+
+var fileDescriptor_block_77df8435b6e254a7 = []byte{
+	// 649 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xc1, 0x6e, 0xd3, 0x4a,
+	0x14, 0xad, 0x1b, 0x27, 0x71, 0x6e, 0x92, 0xf6, 0x69, 0xde, 0xd3, 0x93, 0xa1, 0x60, 0x52, 0x8b,
+	0x42, 0x24, 0xa4, 0x2e, 0xa0, 0xe2, 0x03, 0x5a, 0x16, 0x45, 0x08, 0xb5, 0x9a, 0x16, 0xb1, 0xb4,
+	0x26, 0xf6, 0x90, 0x8c, 0xda, 0xcc, 0x18, 0xcf, 0x38, 0x72, 0x7e, 0x80, 0x35, 0x7c, 0x0a, 0x5f,
+	0xc0, 0x96, 0x65, 0x97, 0x2c, 0x51, 0xfb, 0x23, 0xe8, 0xce, 0x4c, 0xda, 0x08, 0x09, 0x76, 0xbe,
+	0x67, 0x8e, 0xcf, 0x3d, 0x3e, 0xf7, 0x8e, 0xa1, 0x3f, 0xb9, 0x54, 0xf9, 0xc5, 0x7e, 0x59, 0x29,
+	0xa3, 0x48, 0x27, 0x57, 0x15, 0x2f, 0x27, 0xe9, 0xa7, 0x4d, 0xe8, 0x1f, 0x22, 0x7e, 0xcc, 0x59,
+	0xc1, 0x2b, 0x12, 0x43, 0x77, 0xc1, 0x2b, 0x2d, 0x94, 0x8c, 0x83, 0x51, 0x30, 0x6e, 0xd3, 0x55,
+	0x49, 0x9e, 0xc0, 0x76, 0x59, 0xf1, 0x45, 0x66, 0x55, 0xb2, 0x19, 0xd3, 0xb3, 0x78, 0x73, 0x14,
+	0x8c, 0x07, 0x74, 0x88, 0xb0, 0xd3, 0x60, 0x7a, 0x46, 0xee, 0x41, 0x64, 0x1a, 0x9d, 0x55, 0x4a,
+	0x99, 0xb8, 0x65, 0x09, 0x5d, 0xd3, 0x68, 0xaa, 0x94, 0x21, 0x0f, 0x01, 0x8c, 0x98, 0xf3, 0x4c,
+	0x1b, 0x36, 0x2f, 0xe3, 0x70, 0x14, 0x8c, 0x5b, 0xb4, 0x87, 0xc8, 0x19, 0x02, 0xe4, 0x3f, 0x68,
+	0xcf, 0xd9, 0x54, 0xe4, 0x71, 0x7b, 0x14, 0x8c, 0x87, 0xd4, 0x15, 0xe4, 0x11, 0xf4, 0x4b, 0x5e,
+	0x09, 0x55, 0xb8, 0x9e, 0x1d, 0x2b, 0x09, 0x0e, 0xb2, 0x0d, 0x9f, 0xc2, 0x76, 0xce, 0x64, 0x21,
+	0x0a, 0x66, 0xb8, 0x76, 0xa4, 0xae, 0x25, 0x6d, 0xdd, 0xc1, 0x96, 0xb8, 0x03, 0xbd, 0xda, 0x34,
+	0xca, 0x59, 0x8b, 0x2c, 0x25, 0x42, 0x00, 0xbd, 0xa5, 0x5f, 0x02, 0x68, 0xdb, 0x8f, 0x20, 0xcf,
+	0xa0, 0x33, 0xb3, 0x61, 0xd8, 0x04, 0xfa, 0xcf, 0xff, 0xdd, 0x77, 0x59, 0xed, 0xaf, 0xe5, 0x44,
+	0x3d, 0x85, 0xec, 0x41, 0xcb, 0x34, 0x3a, 0xde, 0x1c, 0xb5, 0xd6, 0x99, 0xe7, 0x15, 0x93, 0x9a,
+	0xe5, 0x46, 0x28, 0x49, 0xf1, 0x9c, 0xfc, 0x8f, 0x9a, 0x62, 0x3a, 0x73, 0x91, 0x0c, 0xa9, 0xaf,
+	0xc8, 0x03, 0xe8, 0x69, 0x31, 0x95, 0xcc, 0xd4, 0x15, 0xb7, 0x81, 0x0c, 0xe8, 0x1d, 0x90, 0x7e,
+	0x0b, 0xa0, 0xbf, 0x26, 0xf5, 0x97, 0xe1, 0x24, 0xd0, 0x5a, 0x08, 0xe9, 0x6d, 0x0c, 0x6e, 0x6d,
+	0x34, 0xaf, 0x25, 0xc5, 0x03, 0xb2, 0x0b, 0xe1, 0x42, 0xd5, 0xd8, 0x1d, 0x09, 0xc3, 0x3b, 0xc2,
+	0x49, 0x6d, 0xa8, 0x3d, 0x22, 0x23, 0x08, 0x0b, 0x66, 0x98, 0x75, 0xb1, 0xa6, 0xf1, 0x8a, 0x19,
+	0x46, 0xed, 0xc9, 0x1f, 0xe6, 0xb3, 0x03, 0x3d, 0xbb, 0x11, 0x38, 0x47, 0x3b, 0x9d, 0x16, 0x8d,
+	0x10, 0x38, 0x17, 0x73, 0x9e, 0x2e, 0x21, 0x44, 0x13, 0xe4, 0x25, 0x6c, 0xd9, 0xe5, 0x51, 0xb5,
+	0xc9, 0x4a, 0x25, 0xa4, 0xf1, 0xd9, 0xfe, 0xb3, 0x6a, 0x73, 0x52, 0x9b, 0x53, 0xc4, 0xe9, 0x00,
+	0x79, 0xab, 0x0a, 0x37, 0x46, 0xe7, 0x95, 0x28, 0x4d, 0xa6, 0xc5, 0xd4, 0xef, 0x5b, 0xcf, 0x21,
+	0x67, 0x62, 0x4a, 0xee, 0x43, 0xa4, 0xf9, 0xc7, 0x9a, 0xcb, 0x9c, 0xfb, 0x60, 0x6f, 0xeb, 0xf4,
+	0x08, 0xda, 0xf6, 0xf3, 0xd0, 0xf6, 0x82, 0x5d, 0xd6, 0xdc, 0xb6, 0x0c, 0xa9, 0x2b, 0xc8, 0x63,
+	0xd8, 0xf2, 0xca, 0x65, 0x3d, 0xc9, 0x2e, 0xf8, 0xd2, 0xab, 0x0f, 0x1c, 0x7a, 0x5a, 0x4f, 0xde,
+	0xf0, 0x65, 0x7a, 0x00, 0xd1, 0xad, 0x17, 0x02, 0xa1, 0x5d, 0xae, 0xc0, 0xf2, 0xec, 0x33, 0x6a,
+	0x0b, 0x59, 0xf0, 0xc6, 0xbe, 0x3c, 0xa4, 0xae, 0x48, 0x0f, 0x20, 0xc4, 0xd8, 0xf0, 0x0d, 0xb3,
+	0x2c, 0xb9, 0x1f, 0x96, 0x7d, 0xc6, 0x19, 0xe6, 0x4a, 0x1a, 0x2e, 0x8d, 0x6f, 0xb8, 0x2a, 0xd3,
+	0xaf, 0x01, 0x44, 0xef, 0x4c, 0xa3, 0xde, 0x57, 0xac, 0x24, 0x7b, 0xd0, 0x51, 0xb5, 0x29, 0xeb,
+	0x55, 0x50, 0xbf, 0x8d, 0xcc, 0x1f, 0x92, 0x5d, 0x18, 0xf8, 0xfb, 0xe8, 0xb6, 0xcb, 0xd9, 0x70,
+	0x37, 0xfd, 0xd8, 0x42, 0x78, 0x1f, 0x85, 0xce, 0x74, 0x89, 0x1d, 0x31, 0xa3, 0x88, 0x76, 0x85,
+	0x3e, 0xc3, 0x12, 0xaf, 0x96, 0xd0, 0x59, 0xae, 0x84, 0x9c, 0x30, 0xed, 0xf6, 0x2f, 0xa2, 0x20,
+	0xf4, 0x91, 0x47, 0x3c, 0x61, 0xae, 0x0a, 0xf1, 0x41, 0xf0, 0x22, 0x6e, 0xaf, 0x08, 0x6f, 0x3d,
+	0x72, 0x18, 0x7f, 0xbf, 0x4e, 0x82, 0xab, 0xeb, 0x24, 0xf8, 0x79, 0x9d, 0x04, 0x9f, 0x6f, 0x92,
+	0x8d, 0xab, 0x9b, 0x64, 0xe3, 0xc7, 0x4d, 0xb2, 0x31, 0xe9, 0xd8, 0xff, 0xcc, 0x8b, 0x5f, 0x03,
+	0x00, 0x19, 0x9f, 0x38, 0x74, 0x76, 0x04, 0x00, 0x00,
 }
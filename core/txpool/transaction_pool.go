@@ -5,8 +5,10 @@
 package txpool
 
 import (
-	"errors"
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/BOXFoundation/boxd/boxd/eventbus"
@@ -18,6 +20,7 @@ import (
 	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/log"
 	"github.com/BOXFoundation/boxd/p2p"
+	"github.com/BOXFoundation/boxd/storage"
 	"github.com/BOXFoundation/boxd/util"
 	"github.com/jbenet/goprocess"
 )
@@ -28,14 +31,44 @@ const (
 	ChainUpdateMsgBufferChSize = 65536
 
 	metricsLoopInterval = 2 * time.Second
+	expireLoopInterval  = time.Minute
+	persistLoopInterval = time.Second
+
+	// default limits of tx pool
+	DefaultMaxTxs       = 50000
+	DefaultMaxSize      = 64 * 1024 * 1024
+	DefaultMaxOrphanTxs = 1000
+	DefaultTxExpiry     = 72 * time.Hour
+	DefaultOrphanExpiry = 20 * time.Minute
+
+	// the minimum fee rate is raised by incrementalRelayFeePerKB above the rate of evicted txs
+	incrementalRelayFeePerKB = 1000
+	// the raised minimum fee rate halves every rollingFeeHalfLife
+	rollingFeeHalfLife = 12 * time.Hour
+	// the lowest fee price recommended to clients, in box per byte
+	minFeePrice = 1
+	// the maximum number of txs a replacement can evict, including descendants of the replaced ones
+	maxReplacementEvictions = 100
 )
 
 var logger = log.NewLogger("txpool") // logger
 
 var _ service.TxHandler = (*TransactionPool)(nil)
 
+// Config defines the configurations of transaction pool.
+// Zero values are replaced with the defaults.
+type Config struct {
+	MaxTxs       int           `mapstructure:"max_txs"`
+	MaxSize      int           `mapstructure:"max_size"`
+	MaxOrphanTxs int           `mapstructure:"max_orphan_txs"`
+	Expiry       time.Duration `mapstructure:"expiry"`
+	OrphanExpiry time.Duration `mapstructure:"orphan_expiry"`
+	MinFeePerKB  uint64        `mapstructure:"min_fee_per_kb"`
+}
+
 // TransactionPool define struct.
 type TransactionPool struct {
+	cfg                 Config
 	notifiee            p2p.Net
 	newTxMsgCh          chan p2p.Message
 	newChainUpdateMsgCh chan *chain.UpdateMsg
@@ -48,18 +81,49 @@ type TransactionPool struct {
 	// types.OutPoint -> *types.Transaction
 	outPointToTx *sync.Map
 	txMutex      sync.Mutex
-	// crypto.HashType -> *types.Transaction
+	// number and total serialized size of txs in main pool
+	txCount int64
+	txSize  int64
+	// crypto.HashType -> *chain.TxWrap
 	hashToOrphanTx *sync.Map
 	// outpoint -> orphans spending it; outpoints can be arbitrary, valid or invalid
 	// Use map here since there can be multiple spending txs and we don't know which
 	// one will be accepted, unlike in outPointToTx where first seen tx is accepted
 	// types.OutPoint -> (crypto.HashType -> *types.Transaction)
 	outPointToOrphan *sync.Map
+
+	// minimum fee rate raised when txs are evicted from a full pool
+	rollingMinFeePerKB uint64
+	rollingFeeUpdated  time.Time
+	feeMutex           sync.Mutex
+
+	// persisted txs changed since last flush, db key -> marshaled tx or nil if
+	// removed, which are written in a batch periodically rather than one by one
+	// under txMutex
+	dirtyTxs   map[string][]byte
+	dirtyMutex sync.Mutex
 }
 
 // NewTransactionPool new a transaction pool.
-func NewTransactionPool(parent goprocess.Process, notifiee p2p.Net, c *chain.BlockChain, bus eventbus.Bus) *TransactionPool {
+func NewTransactionPool(parent goprocess.Process, notifiee p2p.Net, c *chain.BlockChain, bus eventbus.Bus, cfg *Config) *TransactionPool {
+	poolCfg := *cfg
+	if poolCfg.MaxTxs == 0 {
+		poolCfg.MaxTxs = DefaultMaxTxs
+	}
+	if poolCfg.MaxSize == 0 {
+		poolCfg.MaxSize = DefaultMaxSize
+	}
+	if poolCfg.MaxOrphanTxs == 0 {
+		poolCfg.MaxOrphanTxs = DefaultMaxOrphanTxs
+	}
+	if poolCfg.Expiry == 0 {
+		poolCfg.Expiry = DefaultTxExpiry
+	}
+	if poolCfg.OrphanExpiry == 0 {
+		poolCfg.OrphanExpiry = DefaultOrphanExpiry
+	}
 	return &TransactionPool{
+		cfg:                 poolCfg,
 		newTxMsgCh:          make(chan p2p.Message, TxMsgBufferChSize),
 		newChainUpdateMsgCh: make(chan *chain.UpdateMsg, ChainUpdateMsgBufferChSize),
 		proc:                goprocess.WithParent(parent),
@@ -70,6 +134,7 @@ func NewTransactionPool(parent goprocess.Process, notifiee p2p.Net, c *chain.Blo
 		hashToOrphanTx:      new(sync.Map),
 		outPointToOrphan:    new(sync.Map),
 		outPointToTx:        new(sync.Map),
+		dirtyTxs:            make(map[string][]byte),
 	}
 }
 
@@ -85,6 +150,9 @@ func (tx_pool *TransactionPool) Run() error {
 	// chain update msg
 	tx_pool.bus.Subscribe(eventbus.TopicChainUpdate, tx_pool.receiveChainUpdateMsg)
 
+	// recover txs pending before last shutdown
+	tx_pool.loadTxs()
+
 	tx_pool.proc.Go(tx_pool.loop).SetTeardown(tx_pool.teardown)
 	return nil
 }
@@ -115,6 +183,10 @@ func (tx_pool *TransactionPool) loop(p goprocess.Process) {
 	logger.Info("Waitting for new tx message...")
 	metricsTicker := time.NewTicker(metricsLoopInterval)
 	defer metricsTicker.Stop()
+	expireTicker := time.NewTicker(expireLoopInterval)
+	defer expireTicker.Stop()
+	persistTicker := time.NewTicker(persistLoopInterval)
+	defer persistTicker.Stop()
 	for {
 		select {
 		case msg := <-tx_pool.newTxMsgCh:
@@ -124,8 +196,13 @@ func (tx_pool *TransactionPool) loop(p goprocess.Process) {
 		case <-metricsTicker.C:
 			metrics.MetricsTxPoolSizeGauge.Update(int64(lengthOfSyncMap(tx_pool.hashToTx)))
 			metrics.MetricsOrphanTxPoolSizeGauge.Update(int64(lengthOfSyncMap(tx_pool.hashToOrphanTx)))
+		case <-expireTicker.C:
+			tx_pool.expireTxs()
+		case <-persistTicker.C:
+			tx_pool.flushTxs()
 		case <-p.Closing():
 			logger.Info("Quit transaction pool loop.")
+			tx_pool.flushTxs()
 			tx_pool.notifiee.UnSubscribe(tx_pool.txNotifee)
 			tx_pool.bus.Unsubscribe(eventbus.TopicChainUpdate, tx_pool.receiveChainUpdateMsg)
 			return
@@ -227,7 +304,9 @@ func (tx_pool *TransactionPool) maybeAcceptTx(tx *types.Transaction, broadcast,
 
 	// Quickly detects if the tx double spends with any transaction in the pool.
 	// Double spending with the main chain txs will be checked in ValidateTxInputs.
-	if err := tx_pool.checkPoolDoubleSpend(tx); err != nil {
+	// Conflicting txs signaling replace-by-fee may be replaced, see checkReplacement.
+	conflicts, err := tx_pool.checkPoolDoubleSpend(tx)
+	if err != nil {
 		logger.Debugf("Tx %v double spends outputs spent by other pending txs: %v", txHash.String(), err)
 		return err
 	}
@@ -252,33 +331,54 @@ func (tx_pool *TransactionPool) maybeAcceptTx(tx *types.Transaction, broadcast,
 		return err
 	}
 
+	// votes for candidates not registered, unvotes of forfeited stake and the
+	// like would never be packed
+	if err := tx_pool.chain.Consensus().VerifyCandidateTx(tx, utxoSet.GetUtxos()); err != nil {
+		logger.Debugf("Tx %v cannot be applied to candidates: %v", txHash.String(), err)
+		return err
+	}
+
 	// TODO: checkInputsStandard
 
 	// TODO: GetSigOpCost check
 
-	// TODO: Whether the minfee limit is needed？
-	// how to calc the minfee, or use a fixed value.
+	// The minimum fee rises when the pool is full, which also rate limits free or cheap txs
 	txSize, err := tx.SerializeSize()
 	if err != nil {
 		return err
 	}
-	minFee := calcRequiredMinFee(txSize)
+	minFee := tx_pool.calcRequiredMinFee(txSize)
 	if txFee < minFee {
-		return errors.New("txFee is less than minFee")
+		logger.Debugf("Tx %v pays fee %d less than the minimum %d", txHash.String(), txFee, minFee)
+		return core.ErrTxFeeTooLow
 	}
 
-	// TODO: priority check
-
-	// TODO: free-to-relay rate limit
+	replacedTxs, err := tx_pool.checkReplacement(tx, txFee, txSize, conflicts)
+	if err != nil {
+		logger.Debugf("Tx %v cannot replace conflicting txs: %v", txHash.String(), err)
+		return err
+	}
 
 	// verify crypto signatures for each input
 	if err = chain.ValidateTxScripts(utxoSet, tx); err != nil {
 		return err
 	}
 
-	feePerKB := txFee * 1000 / (uint64)(txSize)
+	// evict replaced txs along with their descendants
+	for _, replacedTx := range replacedTxs {
+		replacedTxHash, _ := replacedTx.TxHash()
+		logger.Debugf("Tx %v is replaced by %v", replacedTxHash.String(), txHash.String())
+		tx_pool.removeTx(replacedTx, false /* non-recursive */)
+	}
+
 	// add transaction to pool.
-	tx_pool.addTx(tx, nextBlockHeight, feePerKB)
+	tx_pool.addTx(tx, nextBlockHeight, txFee)
+
+	// make room for the new tx, which may be evicted itself if it pays the lowest fee
+	tx_pool.evictTxs()
+	if !tx_pool.isTransactionInPool(txHash) {
+		return core.ErrTxPoolFull
+	}
 
 	// Broadcast this tx.
 	if broadcast {
@@ -305,17 +405,81 @@ func (tx_pool *TransactionPool) isOrphanInPool(txHash *crypto.HashType) bool {
 }
 
 func (tx_pool *TransactionPool) checkTransactionStandard(tx *types.Transaction) error {
+	// evidence txs are only packed by miners from gossiped evidence
+	if chain.IsEvidenceTx(tx) {
+		return core.ErrNonStandardTransaction
+	}
 	// TODO:
 	return nil
 }
 
-func (tx_pool *TransactionPool) checkPoolDoubleSpend(tx *types.Transaction) error {
+// checkPoolDoubleSpend returns txs in the pool spending the same outputs as tx.
+// It fails if any of them does not signal replace-by-fee.
+func (tx_pool *TransactionPool) checkPoolDoubleSpend(tx *types.Transaction) ([]*types.Transaction, error) {
+	var conflicts []*types.Transaction
 	for _, txIn := range tx.Vin {
-		if _, exists := tx_pool.findTransaction(txIn.PrevOutPoint); exists {
-			return core.ErrOutPutAlreadySpent
+		conflict, exists := tx_pool.findTransaction(txIn.PrevOutPoint)
+		if !exists {
+			continue
+		}
+		if !conflict.SignalsReplacement() {
+			return nil, core.ErrOutPutAlreadySpent
 		}
+		conflicts = append(conflicts, conflict)
 	}
-	return nil
+	return conflicts, nil
+}
+
+// checkReplacement checks if tx paying txFee can replace the conflicting txs, and returns all txs
+// to be evicted, i.e., the conflicting txs and their descendants. The replacement must pay a strictly
+// higher fee rate than each conflicting tx, and a strictly higher absolute fee than all evicted txs,
+// the excess covering the minimum relay fee of itself.
+func (tx_pool *TransactionPool) checkReplacement(tx *types.Transaction, txFee uint64, txSize int,
+	conflicts []*types.Transaction) ([]*types.Transaction, error) {
+
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	feePerKB := txFee * 1000 / uint64(txSize)
+	replaced := make(map[crypto.HashType]bool)
+	var replacedTxs []*types.Transaction
+	var replacedFee uint64
+	for _, conflict := range conflicts {
+		conflictHash, _ := conflict.TxHash()
+		v, exists := tx_pool.hashToTx.Load(*conflictHash)
+		if !exists {
+			continue
+		}
+		if feePerKB <= v.(*chain.TxWrap).FeePerKB {
+			return nil, core.ErrReplacementFeeTooLow
+		}
+		for _, replacedTx := range tx_pool.txAndDescendants(conflict) {
+			replacedTxHash, _ := replacedTx.TxHash()
+			if replaced[*replacedTxHash] {
+				continue
+			}
+			v, exists := tx_pool.hashToTx.Load(*replacedTxHash)
+			if !exists {
+				continue
+			}
+			replaced[*replacedTxHash] = true
+			replacedTxs = append(replacedTxs, replacedTx)
+			replacedFee += v.(*chain.TxWrap).Fee
+		}
+	}
+	if len(replacedTxs) > maxReplacementEvictions {
+		return nil, core.ErrTooManyReplacements
+	}
+	// the replacement must not depend on txs it evicts
+	for _, txIn := range tx.Vin {
+		if replaced[txIn.PrevOutPoint.Hash] {
+			return nil, core.ErrReplacementSpendsConflict
+		}
+	}
+	if txFee <= replacedFee || txFee-replacedFee < tx_pool.calcRequiredMinFee(txSize) {
+		return nil, core.ErrReplacementFeeTooLow
+	}
+	return replacedTxs, nil
 }
 
 // ProcessOrphans used to handle orphan transactions
@@ -358,21 +522,28 @@ func (tx_pool *TransactionPool) processOrphans(tx *types.Transaction) error {
 }
 
 // Add transaction into tx pool
-func (tx_pool *TransactionPool) addTx(tx *types.Transaction, height uint32, feePerKB uint64) {
+func (tx_pool *TransactionPool) addTx(tx *types.Transaction, height uint32, fee uint64) {
 	txHash, _ := tx.TxHash()
+	var feePerKB uint64
+	if txSize, err := tx.SerializeSize(); err == nil && txSize > 0 {
+		feePerKB = fee * 1000 / uint64(txSize)
+	}
 
 	txWrap := &chain.TxWrap{
 		Tx:             tx,
 		AddedTimestamp: time.Now().Unix(),
 		Height:         height,
+		Fee:            fee,
 		FeePerKB:       feePerKB,
 	}
 	tx_pool.hashToTx.Store(*txHash, txWrap)
+	tx_pool.updatePoolSize(tx, 1)
 
 	// outputs spent by this new tx
 	for _, txIn := range tx.Vin {
 		tx_pool.outPointToTx.Store(txIn.PrevOutPoint, tx)
 	}
+	tx_pool.persistTx(chain.TxPoolKey(txHash), tx)
 
 	// TODO: build address - tx index.
 }
@@ -385,7 +556,11 @@ func (tx_pool *TransactionPool) removeTx(tx *types.Transaction, recursive bool)
 	for _, txIn := range tx.Vin {
 		tx_pool.outPointToTx.Delete(txIn.PrevOutPoint)
 	}
-	tx_pool.hashToTx.Delete(*txHash)
+	if _, exists := tx_pool.hashToTx.Load(*txHash); exists {
+		tx_pool.hashToTx.Delete(*txHash)
+		tx_pool.updatePoolSize(tx, -1)
+		tx_pool.unpersistTx(chain.TxPoolKey(txHash))
+	}
 
 	if !recursive {
 		return
@@ -416,6 +591,30 @@ func (tx_pool *TransactionPool) removeTx(tx *types.Transaction, recursive bool)
 	}
 }
 
+// txAndDescendants returns tx and all txs in the main pool spending its outputs directly or indirectly
+func (tx_pool *TransactionPool) txAndDescendants(tx *types.Transaction) []*types.Transaction {
+	txs := []*types.Transaction{tx}
+	// Note: use index here instead of range because txs can be extended inside the loop
+	for i := 0; i < len(txs); i++ {
+		txHash, _ := txs[i].TxHash()
+		outPoint := types.OutPoint{Hash: *txHash}
+		for txOutIdx := range txs[i].Vout {
+			outPoint.Index = uint32(txOutIdx)
+			if childTx, exists := tx_pool.findTransaction(outPoint); exists {
+				txs = append(txs, childTx)
+			}
+		}
+	}
+	return txs
+}
+
+// removeTxAndDescendants removes tx and all txs spending its outputs directly or indirectly from the main pool
+func (tx_pool *TransactionPool) removeTxAndDescendants(tx *types.Transaction) {
+	for _, removedTx := range tx_pool.txAndDescendants(tx) {
+		tx_pool.removeTx(removedTx, false /* non-recursive */)
+	}
+}
+
 // removeDoubleSpendTxs removes all txs from the main pool, which double spend the passed transaction.
 func (tx_pool *TransactionPool) removeDoubleSpendTxs(tx *types.Transaction) {
 	for _, txIn := range tx.Vin {
@@ -429,15 +628,38 @@ func (tx_pool *TransactionPool) removeDoubleSpendTxs(tx *types.Transaction) {
 func (tx_pool *TransactionPool) addOrphan(tx *types.Transaction) {
 
 	txHash, _ := tx.TxHash()
-	tx_pool.hashToOrphanTx.Store(*txHash, tx)
+	tx_pool.hashToOrphanTx.Store(*txHash, &chain.TxWrap{
+		Tx:             tx,
+		AddedTimestamp: time.Now().Unix(),
+	})
 	for _, txIn := range tx.Vin {
 		v := new(sync.Map)
 		v.Store(*txHash, tx)
 		tx_pool.outPointToOrphan.LoadOrStore(txIn.PrevOutPoint, v)
 
 	}
+	tx_pool.persistTx(chain.OrphanTxKey(txHash), tx)
 
 	logger.Debugf("Stored orphan transaction %v", txHash.String())
+
+	if lengthOfSyncMap(tx_pool.hashToOrphanTx) > tx_pool.cfg.MaxOrphanTxs {
+		tx_pool.evictOldestOrphan()
+	}
+}
+
+// evictOldestOrphan removes the orphan staying in pool the longest
+func (tx_pool *TransactionPool) evictOldestOrphan() {
+	var oldest *chain.TxWrap
+	tx_pool.hashToOrphanTx.Range(func(k, v interface{}) bool {
+		orphan := v.(*chain.TxWrap)
+		if oldest == nil || orphan.AddedTimestamp < oldest.AddedTimestamp {
+			oldest = orphan
+		}
+		return true
+	})
+	if oldest != nil {
+		tx_pool.removeOrphan(oldest.Tx)
+	}
 }
 
 // Remove orphan
@@ -468,6 +690,7 @@ func (tx_pool *TransactionPool) removeOrphan(tx *types.Transaction) {
 	}
 
 	tx_pool.hashToOrphanTx.Delete(*txHash)
+	tx_pool.unpersistTx(chain.OrphanTxKey(txHash))
 	logger.Debugf("Removed orphan transaction %v", txHash.String())
 }
 
@@ -507,8 +730,212 @@ func (tx_pool *TransactionPool) GetTransactionsInPool() []*types.Transaction {
 	return txs
 }
 
-func calcRequiredMinFee(txSize int) uint64 {
-	return 0
+// persistTx stores tx in db on next flush, so that it survives node restarts
+func (tx_pool *TransactionPool) persistTx(key []byte, tx *types.Transaction) {
+	data, err := tx.Marshal()
+	if err != nil {
+		logger.Errorf("Failed to marshal tx %s: %v", key, err)
+		return
+	}
+	tx_pool.dirtyMutex.Lock()
+	tx_pool.dirtyTxs[string(key)] = data
+	tx_pool.dirtyMutex.Unlock()
+}
+
+// unpersistTx removes tx from db on next flush
+func (tx_pool *TransactionPool) unpersistTx(key []byte) {
+	tx_pool.dirtyMutex.Lock()
+	tx_pool.dirtyTxs[string(key)] = nil
+	tx_pool.dirtyMutex.Unlock()
+}
+
+// flushTxs writes txs persisted or removed since last flush to db in a batch.
+// Txs changed within the last interval are lost on a crash, as are ones only
+// in memory in any case.
+func (tx_pool *TransactionPool) flushTxs() {
+	tx_pool.dirtyMutex.Lock()
+	dirtyTxs := tx_pool.dirtyTxs
+	tx_pool.dirtyTxs = make(map[string][]byte)
+	tx_pool.dirtyMutex.Unlock()
+	if len(dirtyTxs) == 0 {
+		return
+	}
+
+	batch := tx_pool.chain.DB().NewBatch()
+	defer batch.Close()
+	for key, data := range dirtyTxs {
+		if data == nil {
+			batch.Del([]byte(key))
+		} else {
+			batch.Put([]byte(key), data)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		logger.Errorf("Failed to persist %d txs: %v", len(dirtyTxs), err)
+	}
+}
+
+// loadTxs reloads pending and orphan txs persisted in db and revalidates them against
+// the current tail. Records are marked removed before revalidation and accepted txs
+// persist themselves again, so that only those becoming invalid in the meantime are
+// removed from db when flushed at last.
+func (tx_pool *TransactionPool) loadTxs() {
+	db := tx_pool.chain.DB()
+	var txs []*types.Transaction
+	for _, prefix := range []string{chain.TxPoolPrefix, chain.OrphanTxPrefix} {
+		iter := db.NewIterator(storage.BytesPrefix([]byte(prefix + "/")))
+		for iter.Next() {
+			key := iter.Key()
+			tx_pool.unpersistTx(key)
+			tx := new(types.Transaction)
+			if err := tx.Unmarshal(iter.Value()); err != nil {
+				logger.Errorf("Failed to unmarshal persisted tx %s: %v", key, err)
+				continue
+			}
+			txs = append(txs, tx)
+		}
+		if err := iter.Error(); err != nil {
+			logger.Errorf("Failed to load persisted txs: %v", err)
+		}
+		iter.Release()
+	}
+
+	var accepted int
+	for _, tx := range txs {
+		// parents may be loaded after their children, which become orphans first
+		// and are accepted once their parents are in the pool
+		if err := tx_pool.ProcessTx(tx, false /* do not broadcast */); err != nil &&
+			err != core.ErrOrphanTransaction && err != core.ErrDuplicateTxInPool {
+			txHash, _ := tx.TxHash()
+			logger.Infof("Drop persisted tx %v: %v", txHash, err)
+			continue
+		}
+		accepted++
+	}
+	tx_pool.flushTxs()
+	logger.Infof("Loaded %d of %d persisted txs into tx pool", accepted, len(txs))
+}
+
+// updatePoolSize adds (delta > 0) or removes (delta < 0) tx to the main pool size
+func (tx_pool *TransactionPool) updatePoolSize(tx *types.Transaction, delta int64) {
+	txSize, err := tx.SerializeSize()
+	if err != nil {
+		logger.Errorf("Failed to get tx size: %v", err)
+		return
+	}
+	atomic.AddInt64(&tx_pool.txCount, delta)
+	atomic.AddInt64(&tx_pool.txSize, delta*int64(txSize))
+}
+
+// isFull returns if the main pool exceeds the configured count or size limit
+func (tx_pool *TransactionPool) isFull() bool {
+	return atomic.LoadInt64(&tx_pool.txCount) > int64(tx_pool.cfg.MaxTxs) ||
+		atomic.LoadInt64(&tx_pool.txSize) > int64(tx_pool.cfg.MaxSize)
+}
+
+// evictTxs evicts txs paying the lowest fee rate together with their descendants until
+// the pool fits in its limits, and raises the minimum fee rate above the evicted ones
+func (tx_pool *TransactionPool) evictTxs() {
+	if !tx_pool.isFull() {
+		return
+	}
+	txs := tx_pool.GetAllTxs()
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].FeePerKB < txs[j].FeePerKB
+	})
+	for _, txWrap := range txs {
+		if !tx_pool.isFull() {
+			break
+		}
+		txHash, _ := txWrap.Tx.TxHash()
+		// already evicted as a descendant
+		if !tx_pool.isTransactionInPool(txHash) {
+			continue
+		}
+		logger.Debugf("Evict tx %v with fee rate %d from full pool", txHash.String(), txWrap.FeePerKB)
+		tx_pool.removeTxAndDescendants(txWrap.Tx)
+		tx_pool.raiseMinFeePerKB(txWrap.FeePerKB + incrementalRelayFeePerKB)
+	}
+}
+
+// expireTxs removes txs and orphans staying in pool longer than the configured expiry
+func (tx_pool *TransactionPool) expireTxs() {
+	tx_pool.txMutex.Lock()
+	defer tx_pool.txMutex.Unlock()
+
+	now := time.Now()
+	tx_pool.hashToTx.Range(func(k, v interface{}) bool {
+		txWrap := v.(*chain.TxWrap)
+		txHash := k.(crypto.HashType)
+		// skip descendants already removed along with their expired ancestors
+		if !tx_pool.isTransactionInPool(&txHash) {
+			return true
+		}
+		if now.Sub(time.Unix(txWrap.AddedTimestamp, 0)) > tx_pool.cfg.Expiry {
+			logger.Debugf("Tx %v expires", txHash.String())
+			tx_pool.removeTxAndDescendants(txWrap.Tx)
+		}
+		return true
+	})
+	tx_pool.hashToOrphanTx.Range(func(k, v interface{}) bool {
+		orphan := v.(*chain.TxWrap)
+		if now.Sub(time.Unix(orphan.AddedTimestamp, 0)) > tx_pool.cfg.OrphanExpiry {
+			tx_pool.removeOrphan(orphan.Tx)
+		}
+		return true
+	})
+}
+
+// raiseMinFeePerKB raises the minimum fee rate to feePerKB if it is lower
+func (tx_pool *TransactionPool) raiseMinFeePerKB(feePerKB uint64) {
+	tx_pool.feeMutex.Lock()
+	defer tx_pool.feeMutex.Unlock()
+
+	if decayed := tx_pool.decayedMinFeePerKB(); decayed > feePerKB {
+		feePerKB = decayed
+	}
+	tx_pool.rollingMinFeePerKB = feePerKB
+	tx_pool.rollingFeeUpdated = time.Now()
+}
+
+// decayedMinFeePerKB returns the raised minimum fee rate, which halves every rollingFeeHalfLife
+// so that it goes back to the configured one once the pool is no longer full
+func (tx_pool *TransactionPool) decayedMinFeePerKB() uint64 {
+	if tx_pool.rollingMinFeePerKB == 0 {
+		return 0
+	}
+	halfLives := float64(time.Since(tx_pool.rollingFeeUpdated)) / float64(rollingFeeHalfLife)
+	feePerKB := uint64(math.Round(float64(tx_pool.rollingMinFeePerKB) / math.Pow(2, halfLives)))
+	if feePerKB < incrementalRelayFeePerKB/2 {
+		return 0
+	}
+	return feePerKB
+}
+
+// minFeePerKB returns the minimum fee rate for a tx to be accepted into the pool
+func (tx_pool *TransactionPool) minFeePerKB() uint64 {
+	tx_pool.feeMutex.Lock()
+	defer tx_pool.feeMutex.Unlock()
+
+	if feePerKB := tx_pool.decayedMinFeePerKB(); feePerKB > tx_pool.cfg.MinFeePerKB {
+		return feePerKB
+	}
+	return tx_pool.cfg.MinFeePerKB
+}
+
+// calcRequiredMinFee returns the minimum fee for a tx of txSize bytes to be accepted into the pool
+func (tx_pool *TransactionPool) calcRequiredMinFee(txSize int) uint64 {
+	return tx_pool.minFeePerKB() * uint64(txSize) / 1000
+}
+
+// GetFeePrice returns the minimum fee in box per byte for a tx to be accepted into the pool
+func (tx_pool *TransactionPool) GetFeePrice() uint64 {
+	// round up to make sure txs paying the price are accepted
+	price := (tx_pool.minFeePerKB() + 999) / 1000
+	if price < minFeePrice {
+		return minFeePrice
+	}
+	return price
 }
 
 func lengthOfSyncMap(target *sync.Map) int {
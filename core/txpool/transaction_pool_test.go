@@ -7,6 +7,7 @@ package txpool
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/BOXFoundation/boxd/boxd/eventbus"
 	"github.com/BOXFoundation/boxd/core"
@@ -25,7 +26,7 @@ import (
 var (
 	proc        = goprocess.WithSignals(os.Interrupt)
 	bus         = eventbus.New()
-	txpool      = NewTransactionPool(proc, p2p.NewDummyPeer(), chain.NewTestBlockChain(), bus)
+	txpool      = NewTransactionPool(proc, p2p.NewDummyPeer(), chain.NewTestBlockChain(), bus, &Config{})
 	chainHeight = uint32(0)
 
 	txOutIdx = uint32(0)
@@ -35,11 +36,16 @@ var (
 	addr, _            = types.NewAddressFromPubKey(pubKey)
 	scriptAddr         = addr.Hash()
 	scriptPubKey       = script.PayToPubKeyHashScript(scriptAddr)
-	tx0, _             = chain.CreateCoinbaseTx(addr.Hash(), chainHeight)
+	tx0, _             = chain.CreateCoinbaseTx(addr.Hash(), chainHeight, 0)
 )
 
 // create a child tx spending parent tx's output
 func createChildTx(parentTx *types.Transaction) *types.Transaction {
+	return createTx(parentTx, 0, value)
+}
+
+// create a child tx spending parent tx's output with the given input sequence and output value
+func createTx(parentTx *types.Transaction, sequence uint32, outValue uint64) *types.Transaction {
 	outPoint := types.OutPoint{
 		Hash:  *getTxHash(parentTx),
 		Index: txOutIdx,
@@ -47,13 +53,13 @@ func createChildTx(parentTx *types.Transaction) *types.Transaction {
 	txIn := &types.TxIn{
 		PrevOutPoint: outPoint,
 		ScriptSig:    []byte{},
-		Sequence:     0,
+		Sequence:     sequence,
 	}
 	vIn := []*types.TxIn{
 		txIn,
 	}
 	txOut := &corepb.TxOut{
-		Value:        value,
+		Value:        outValue,
 		ScriptPubKey: *scriptPubKey,
 	}
 	vOut := []*corepb.TxOut{txOut}
@@ -179,3 +185,136 @@ func TestProcessTx(t *testing.T) {
 	ensure.DeepEqual(t, len(txpool.GetAllTxs()), 3)
 	verifyTxInPool(t, tx1, false, false)
 }
+
+func TestLoadPersistedTxs(t *testing.T) {
+	c := chain.NewTestBlockChain()
+	pool := NewTransactionPool(proc, p2p.NewDummyPeer(), c, bus, &Config{})
+
+	pool.addTx(tx0, chainHeight, 0)
+	tx1 := createChildTx(tx0)
+	ensure.Nil(t, pool.ProcessTx(tx1, false))
+	tx2 := createChildTx(tx1)
+	ensure.Nil(t, pool.ProcessTx(tx2, false))
+	tx3 := createChildTx(tx2)
+	tx4 := createChildTx(tx3)
+	ensure.DeepEqual(t, pool.ProcessTx(tx4, false), core.ErrOrphanTransaction)
+	// txs are written once flushed
+	ensure.DeepEqual(t, len(c.DB().KeysWithPrefix([]byte(chain.TxPoolPrefix+"/"))), 0)
+	pool.flushTxs()
+	ensure.DeepEqual(t, len(c.DB().KeysWithPrefix([]byte(chain.TxPoolPrefix+"/"))), 3)
+	ensure.DeepEqual(t, len(c.DB().KeysWithPrefix([]byte(chain.OrphanTxPrefix+"/"))), 1)
+
+	// restart: a new pool reloads txs persisted by the old one
+	pool = NewTransactionPool(proc, p2p.NewDummyPeer(), c, bus, &Config{})
+	pool.loadTxs()
+
+	// tx0 is a coinbase and dropped, so its descendants are orphaned
+	ok, _ := c.DB().Has(chain.TxPoolKey(getTxHash(tx0)))
+	ensure.False(t, ok)
+	ensure.False(t, pool.isTransactionInPool(getTxHash(tx0)))
+	for _, tx := range []*types.Transaction{tx1, tx2, tx4} {
+		ensure.True(t, pool.isOrphanInPool(getTxHash(tx)))
+		ok, _ := c.DB().Has(chain.OrphanTxKey(getTxHash(tx)))
+		ensure.True(t, ok)
+	}
+
+	// bootstrap tx0 again, tx1 and tx2 get accepted and persisted as pending
+	pool.addTx(tx0, chainHeight, 0)
+	ensure.Nil(t, pool.processOrphans(tx0))
+	pool.flushTxs()
+	for _, tx := range []*types.Transaction{tx1, tx2} {
+		ensure.True(t, pool.isTransactionInPool(getTxHash(tx)))
+		ok, _ := c.DB().Has(chain.TxPoolKey(getTxHash(tx)))
+		ensure.True(t, ok)
+		ok, _ = c.DB().Has(chain.OrphanTxKey(getTxHash(tx)))
+		ensure.False(t, ok)
+	}
+	ensure.True(t, pool.isOrphanInPool(getTxHash(tx4)))
+}
+
+func TestEvictTxs(t *testing.T) {
+	pool := NewTransactionPool(proc, p2p.NewDummyPeer(), chain.NewTestBlockChain(), bus, &Config{MaxTxs: 3})
+	ensure.DeepEqual(t, pool.GetFeePrice(), uint64(minFeePrice))
+
+	pool.addTx(tx0, chainHeight, 0)
+	tx1 := createChildTx(tx0)
+	ensure.Nil(t, pool.ProcessTx(tx1, false))
+	tx2 := createChildTx(tx1)
+	ensure.Nil(t, pool.ProcessTx(tx2, false))
+	ensure.DeepEqual(t, len(pool.GetAllTxs()), 3)
+
+	// no room for tx3 paying no more fee than others
+	tx3 := createChildTx(tx2)
+	ensure.DeepEqual(t, pool.ProcessTx(tx3, false), core.ErrTxPoolFull)
+	ensure.False(t, pool.isTransactionInPool(getTxHash(tx3)))
+	ensure.True(t, len(pool.GetAllTxs()) < 3)
+
+	// minimum fee rate is raised above evicted txs
+	ensure.DeepEqual(t, pool.minFeePerKB(), uint64(incrementalRelayFeePerKB))
+	ensure.DeepEqual(t, pool.calcRequiredMinFee(500), uint64(500))
+	pool.raiseMinFeePerKB(2500)
+	ensure.DeepEqual(t, pool.GetFeePrice(), uint64(3))
+
+	// and decays over time
+	pool.rollingFeeUpdated = time.Now().Add(-rollingFeeHalfLife)
+	ensure.DeepEqual(t, pool.GetFeePrice(), uint64(2))
+}
+
+func TestExpireTxs(t *testing.T) {
+	pool := NewTransactionPool(proc, p2p.NewDummyPeer(), chain.NewTestBlockChain(), bus, &Config{})
+
+	pool.addTx(tx0, chainHeight, 0)
+	tx1 := createChildTx(tx0)
+	ensure.Nil(t, pool.ProcessTx(tx1, false))
+	tx2 := createChildTx(tx1)
+	ensure.Nil(t, pool.ProcessTx(tx2, false))
+	tx4 := createChildTx(createChildTx(tx2))
+	ensure.DeepEqual(t, pool.ProcessTx(tx4, false), core.ErrOrphanTransaction)
+
+	// nothing expires yet
+	pool.expireTxs()
+	ensure.DeepEqual(t, len(pool.GetAllTxs()), 3)
+	ensure.True(t, pool.isOrphanInPool(getTxHash(tx4)))
+
+	// tx1 expires along with its descendant tx2
+	v, _ := pool.hashToTx.Load(*getTxHash(tx1))
+	v.(*chain.TxWrap).AddedTimestamp -= int64(DefaultTxExpiry/time.Second) + 1
+	v, _ = pool.hashToOrphanTx.Load(*getTxHash(tx4))
+	v.(*chain.TxWrap).AddedTimestamp -= int64(DefaultOrphanExpiry/time.Second) + 1
+	pool.expireTxs()
+	ensure.DeepEqual(t, len(pool.GetAllTxs()), 1)
+	for _, tx := range []*types.Transaction{tx1, tx2, tx4} {
+		ensure.False(t, pool.isTransactionInPool(getTxHash(tx)))
+		ensure.False(t, pool.isOrphanInPool(getTxHash(tx)))
+	}
+}
+
+func TestReplaceByFee(t *testing.T) {
+	pool := NewTransactionPool(proc, p2p.NewDummyPeer(), chain.NewTestBlockChain(), bus, &Config{})
+	pool.addTx(tx0, chainHeight, 0)
+	reward := tx0.Vout[txOutIdx].Value
+
+	// tx1 signals replace-by-fee and pays fee 100, so does its child tx2
+	tx1 := createTx(tx0, types.TxInSequenceReplaceable, reward-100)
+	ensure.Nil(t, pool.ProcessTx(tx1, false))
+	tx2 := createTx(tx1, 0, reward-200)
+	ensure.Nil(t, pool.ProcessTx(tx2, false))
+
+	// tx3 pays more than tx1, but less than tx1 and tx2 in total
+	tx3 := createTx(tx0, 0, reward-150)
+	ensure.DeepEqual(t, pool.ProcessTx(tx3, false), core.ErrReplacementFeeTooLow)
+	ensure.True(t, pool.isTransactionInPool(getTxHash(tx1)))
+
+	// tx4 replaces tx1 and its child tx2
+	tx4 := createTx(tx0, 0, reward-300)
+	ensure.Nil(t, pool.ProcessTx(tx4, false))
+	ensure.True(t, pool.isTransactionInPool(getTxHash(tx4)))
+	ensure.False(t, pool.isTransactionInPool(getTxHash(tx1)))
+	ensure.False(t, pool.isTransactionInPool(getTxHash(tx2)))
+	ensure.DeepEqual(t, len(pool.GetAllTxs()), 2)
+
+	// tx4 does not signal replace-by-fee
+	tx5 := createTx(tx0, types.TxInSequenceReplaceable, reward-1000)
+	ensure.DeepEqual(t, pool.ProcessTx(tx5, false), core.ErrOutPutAlreadySpent)
+	ensure.True(t, pool.isTransactionInPool(getTxHash(tx4)))
+}
@@ -50,10 +50,23 @@ func NewAddressFromPubKey(pubKey *crypto.PublicKey) (*AddressPubKeyHash, error)
 	return newAddressPubKeyHash(pkHash)
 }
 
-// NewAddress creates an address from string
+// NewAddress creates an address from string, the type of the returned address
+// is decided by its prefix
 func NewAddress(address string) (Address, error) {
-	addr := &AddressPubKeyHash{}
-	err := addr.SetString(address)
+	prefix, _, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	var addr Address
+	switch prefix {
+	case addressTypeP2PKHPrefix:
+		addr = &AddressPubKeyHash{}
+	case addressTypeP2SHPrefix:
+		addr = &AddressScriptHash{}
+	default:
+		return nil, core.ErrInvalidAddressString
+	}
+	err = addr.SetString(address)
 	return addr, err
 }
 
@@ -81,22 +94,14 @@ func (a *AddressPubKeyHash) String() string {
 // SetString sets the Address's internal byte array using byte array decoded from input
 // base58 format string, returns error if input string is invalid
 func (a *AddressPubKeyHash) SetString(in string) error {
-	if len(in) != EncodeAddressLength || in[0] != BoxPrefix {
-		return core.ErrInvalidAddressString
-	}
-	rawBytes, err := crypto.Base58CheckDecode(in)
+	prefix, hash, err := decodeAddress(in)
 	if err != nil {
 		return err
 	}
-	if len(rawBytes) != 22 {
-		return core.ErrInvalidAddressString
-	}
-	var prefix [2]byte
-	copy(prefix[:], rawBytes[:2])
-	if prefix != addressTypeP2PKHPrefix && prefix != addressTypeP2SHPrefix {
+	if prefix != addressTypeP2PKHPrefix {
 		return core.ErrInvalidAddressString
 	}
-	copy(a.hash[:], rawBytes[2:])
+	copy(a.hash[:], hash)
 	return nil
 }
 
@@ -105,9 +110,85 @@ func (a *AddressPubKeyHash) Hash160() *AddressHash {
 	return &a.hash
 }
 
+// AddressScriptHash is an Address for a pay-to-script-hash (P2SH) transaction.
+type AddressScriptHash struct {
+	hash AddressHash
+}
+
+// NewAddressScriptHash returns a new AddressScriptHash derived from a redeem script.
+func NewAddressScriptHash(redeemScript []byte) (*AddressScriptHash, error) {
+	return newAddressScriptHash(crypto.Hash160(redeemScript))
+}
+
+// NewAddressScriptHashFromHash returns a new AddressScriptHash. scriptHash must be 20 bytes.
+func NewAddressScriptHashFromHash(scriptHash []byte) (*AddressScriptHash, error) {
+	return newAddressScriptHash(scriptHash)
+}
+
+func newAddressScriptHash(scriptHash []byte) (*AddressScriptHash, error) {
+	// Check for a valid script hash length.
+	if len(scriptHash) != ripemd160.Size {
+		return nil, core.ErrInvalidScriptHash
+	}
+
+	addr := &AddressScriptHash{}
+	copy(addr.hash[:], scriptHash)
+	return addr, nil
+}
+
+// Hash returns the bytes to be included in a txout script to pay to a script hash.
+func (a *AddressScriptHash) Hash() []byte {
+	return a.hash[:]
+}
+
+// String returns a human-readable string for the pay-to-script-hash address.
+func (a *AddressScriptHash) String() string {
+	return encodeAddressWithPrefix(addressTypeP2SHPrefix, a.hash[:])
+}
+
+// SetString sets the Address's internal byte array using byte array decoded from input
+// base58 format string, returns error if input string is invalid
+func (a *AddressScriptHash) SetString(in string) error {
+	prefix, hash, err := decodeAddress(in)
+	if err != nil {
+		return err
+	}
+	if prefix != addressTypeP2SHPrefix {
+		return core.ErrInvalidAddressString
+	}
+	copy(a.hash[:], hash)
+	return nil
+}
+
+// Hash160 returns the underlying array of the script hash.
+func (a *AddressScriptHash) Hash160() *AddressHash {
+	return &a.hash
+}
+
 func encodeAddress(hash []byte) string {
+	return encodeAddressWithPrefix(addressTypeP2PKHPrefix, hash)
+}
+
+func encodeAddressWithPrefix(prefix [2]byte, hash []byte) string {
 	b := make([]byte, 0, len(hash)+2)
-	b = append(b, addressTypeP2PKHPrefix[:]...)
+	b = append(b, prefix[:]...)
 	b = append(b, hash[:]...)
 	return crypto.Base58CheckEncode(b)
 }
+
+// decodeAddress decodes a base58 format address string into its prefix and hash
+func decodeAddress(in string) ([2]byte, []byte, error) {
+	var prefix [2]byte
+	if len(in) != EncodeAddressLength || in[0] != BoxPrefix {
+		return prefix, nil, core.ErrInvalidAddressString
+	}
+	rawBytes, err := crypto.Base58CheckDecode(in)
+	if err != nil {
+		return prefix, nil, err
+	}
+	if len(rawBytes) != AddressPrefixLength+ripemd160.Size {
+		return prefix, nil, core.ErrInvalidAddressString
+	}
+	copy(prefix[:], rawBytes[:AddressPrefixLength])
+	return prefix, rawBytes[AddressPrefixLength:], nil
+}
@@ -166,3 +166,35 @@ func TestNewAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAddressScriptHash(t *testing.T) {
+	redeemScript := []byte{0x51, 0x21, 0x02, 0x03, 0x51, 0xae}
+	addr, err := NewAddressScriptHash(redeemScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(addr.Hash(), crypto.Hash160(redeemScript)) {
+		t.Errorf("NewAddressScriptHash() got hash: %v, want: %v", addr.Hash(), crypto.Hash160(redeemScript))
+	}
+
+	// NewAddress decodes the address type from its prefix
+	decoded, err := NewAddress(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, addr) {
+		t.Errorf("NewAddress() = %v, want %v", decoded, addr)
+	}
+
+	// a p2sh address is not a valid p2pkh address and vice versa
+	if err := new(AddressPubKeyHash).SetString(addr.String()); err != core.ErrInvalidAddressString {
+		t.Errorf("AddressPubKeyHash.SetString() error = %v, want %v", err, core.ErrInvalidAddressString)
+	}
+	if err := new(AddressScriptHash).SetString("b1ToofJ9HTLVywiTeyZmJ5dFUFmy24ksUhm"); err != core.ErrInvalidAddressString {
+		t.Errorf("AddressScriptHash.SetString() error = %v, want %v", err, core.ErrInvalidAddressString)
+	}
+
+	if _, err := NewAddressScriptHashFromHash(bytes.Repeat([]byte{1}, ripemd160.Size+1)); err != core.ErrInvalidScriptHash {
+		t.Errorf("NewAddressScriptHashFromHash() error = %v, want %v", err, core.ErrInvalidScriptHash)
+	}
+}
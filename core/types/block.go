@@ -114,6 +114,15 @@ func (block *Block) Unmarshal(data []byte) error {
 	return block.FromProtoMessage(msg)
 }
 
+// SerializeSize returns the serialized size of the block.
+func (block *Block) SerializeSize() (int, error) {
+	serializedBlock, err := block.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return len(serializedBlock), nil
+}
+
 // BlockHash returns the block identifier hash for the Block.
 func (block *Block) BlockHash() *crypto.HashType {
 	if block.Hash != nil {
@@ -162,6 +171,9 @@ type BlockHeader struct {
 	PeriodHash crypto.HashType
 
 	CandidatesHash crypto.HashType
+
+	// Hash of the commitment to utxo set after the block is connected.
+	UtxoRoot crypto.HashType
 }
 
 var _ conv.Convertible = (*BlockHeader)(nil)
@@ -170,6 +182,12 @@ var _ conv.Serializable = (*BlockHeader)(nil)
 // ToProtoMessage converts block header to proto message.
 func (header *BlockHeader) ToProtoMessage() (proto.Message, error) {
 
+	// a zero utxo root is left out, so that headers before utxo commitment encode
+	// and hash as they did
+	var utxoRoot []byte
+	if header.UtxoRoot != (crypto.HashType{}) {
+		utxoRoot = header.UtxoRoot[:]
+	}
 	// todo check error if necessary
 	return &corepb.BlockHeader{
 		Version:        header.Version,
@@ -179,6 +197,7 @@ func (header *BlockHeader) ToProtoMessage() (proto.Message, error) {
 		Magic:          header.Magic,
 		PeriodHash:     header.PeriodHash[:],
 		CandidatesHash: header.CandidatesHash[:],
+		UtxoRoot:       utxoRoot,
 	}, nil
 }
 
@@ -193,6 +212,7 @@ func (header *BlockHeader) FromProtoMessage(message proto.Message) error {
 			header.Magic = message.Magic
 			copy(header.PeriodHash[:], message.PeriodHash)
 			copy(header.CandidatesHash[:], message.CandidatesHash)
+			copy(header.UtxoRoot[:], message.UtxoRoot)
 			return nil
 		}
 		return core.ErrEmptyProtoMessage
@@ -7,6 +7,7 @@ package types
 import (
 	"testing"
 
+	corepb "github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/facebookgo/ensure"
 )
@@ -46,3 +47,22 @@ func TestBlockHeaderConvertWithProtoMessage(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.DeepEqual(t, header, header1)
 }
+
+func TestBlockHeaderUtxoRoot(t *testing.T) {
+	header := NewBlockHeader(crypto.HashType{0x0011}, crypto.HashType{0x0020}, 98765432100000)
+
+	// zero utxo root is not encoded
+	msg, err := header.ToProtoMessage()
+	ensure.Nil(t, err)
+	ensure.True(t, msg.(*corepb.BlockHeader).UtxoRoot == nil)
+	data, err := header.Marshal()
+	ensure.Nil(t, err)
+
+	header.UtxoRoot = crypto.HashType{0x0033}
+	data1, err := header.Marshal()
+	ensure.Nil(t, err)
+	ensure.NotDeepEqual(t, data1, data)
+	header1 := &BlockHeader{}
+	ensure.Nil(t, header1.Unmarshal(data1))
+	ensure.DeepEqual(t, header1, header)
+}
@@ -5,7 +5,7 @@
 package types
 
 import (
-	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/storage"
 	peer "github.com/libp2p/go-libp2p-peer"
 )
 
@@ -13,9 +13,12 @@ import (
 type Consensus interface {
 	Run() error
 	Stop()
-	StoreCandidateContext(*crypto.HashType) error
+	StoreCandidateContext(*Block, map[OutPoint]*UtxoWrap, storage.Batch) error
+	UpdatePeriodContext(*Block, storage.Batch) (func(), error)
+	RevertPeriodContext(*Block, storage.Batch) (func(), error)
 	VerifySign(*Block) (bool, error)
 	VerifyMinerEpoch(*Block) error
+	VerifyCandidateTx(*Transaction, map[OutPoint]*UtxoWrap) error
 	StopMint()
 	RecoverMint()
 	BroadcastEternalMsgToMiners(*Block) error
@@ -5,6 +5,8 @@
 package types
 
 import (
+	"math"
+
 	"github.com/BOXFoundation/boxd/core"
 	corepb "github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/crypto"
@@ -17,6 +19,10 @@ const (
 	GeneralTx = iota
 	RegisterCandidateTx
 	VoteTx
+	UnvoteTx
+	EvidenceTx
+	UnregisterCandidateTx
+	UpdateCandidateTx
 )
 
 // Transaction defines a transaction.
@@ -43,6 +49,21 @@ type TxIn struct {
 var _ conv.Convertible = (*TxIn)(nil)
 var _ conv.Serializable = (*TxIn)(nil)
 
+// TxInSequenceReplaceable is the flag in TxIn.Sequence signaling that the tx opts in
+// replace-by-fee, i.e., it can be replaced in tx pool by a conflicting tx paying a higher fee.
+// It does not apply to the maximum sequence, which marks a final input.
+const TxInSequenceReplaceable uint32 = 1 << 31
+
+// SignalsReplacement returns if any input of tx signals replace-by-fee
+func (tx *Transaction) SignalsReplacement() bool {
+	for _, txIn := range tx.Vin {
+		if txIn.Sequence != math.MaxUint32 && txIn.Sequence&TxInSequenceReplaceable != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // OutPoint defines a data type that is used to track previous transaction outputs.
 type OutPoint struct {
 	Hash  crypto.HashType
@@ -12,7 +12,16 @@ import (
 
 // SignUpContent identify the tx of signup type
 type SignUpContent struct {
-	addr AddressHash
+	addr   AddressHash
+	peerID string
+}
+
+// NewSignUpContent creates a SignUpContent of the candidate address and its peer id.
+func NewSignUpContent(addr AddressHash, peerID string) *SignUpContent {
+	return &SignUpContent{
+		addr:   addr,
+		peerID: peerID,
+	}
 }
 
 // Marshal marshals the SignUpContent to a binary representation of it.
@@ -22,6 +31,11 @@ func (sc *SignUpContent) Marshal() (data []byte, err error) {
 	if err := util.WriteVarBytes(&w, sc.addr[:]); err != nil {
 		return nil, err
 	}
+	if sc.peerID != "" {
+		if err := util.WriteVarBytes(&w, []byte(sc.peerID)); err != nil {
+			return nil, err
+		}
+	}
 
 	return w.Bytes(), nil
 }
@@ -35,6 +49,13 @@ func (sc *SignUpContent) Unmarshal(data []byte) error {
 		return err
 	}
 	copy(sc.addr[:], varbytes)
+	// peer id is absent in content created before it is introduced
+	if r.Len() > 0 {
+		if varbytes, err = util.ReadVarBytes(r); err != nil {
+			return err
+		}
+		sc.peerID = string(varbytes)
+	}
 
 	return nil
 }
@@ -44,10 +65,22 @@ func (sc *SignUpContent) Addr() AddressHash {
 	return sc.addr
 }
 
-// VoteContent identify the tx of vote type
+// PeerID returns peer id in signUpContent.
+func (sc *SignUpContent) PeerID() string {
+	return sc.peerID
+}
+
+// VoteContent identify the tx of vote type, whose votes are coins locked
+// in its vote outputs for the candidate.
 type VoteContent struct {
-	addr  AddressHash
-	votes int64
+	addr AddressHash
+}
+
+// NewVoteContent creates a VoteContent of votes for the candidate address.
+func NewVoteContent(addr AddressHash) *VoteContent {
+	return &VoteContent{
+		addr: addr,
+	}
 }
 
 // Marshal marshals the VoteContent to a binary representation of it.
@@ -57,9 +90,6 @@ func (vc *VoteContent) Marshal() (data []byte, err error) {
 	if err := util.WriteVarBytes(&w, vc.addr[:]); err != nil {
 		return nil, err
 	}
-	if err := util.WriteInt64(&w, vc.votes); err != nil {
-		return nil, err
-	}
 
 	return w.Bytes(), nil
 }
@@ -72,9 +102,6 @@ func (vc *VoteContent) Unmarshal(data []byte) error {
 		return err
 	}
 	copy(vc.addr[:], varbytes)
-	if vc.votes, err = util.ReadInt64(r); err != nil {
-		return err
-	}
 
 	return nil
 }
@@ -84,7 +111,116 @@ func (vc *VoteContent) Addr() AddressHash {
 	return vc.addr
 }
 
-// Votes returns votes in voteContent.
-func (vc *VoteContent) Votes() int64 {
-	return vc.votes
+// UnregisterContent identify the tx of unregister type, by which the candidate
+// withdraws from election.
+type UnregisterContent struct {
+	addr AddressHash
+}
+
+// NewUnregisterContent creates a UnregisterContent of the candidate address.
+func NewUnregisterContent(addr AddressHash) *UnregisterContent {
+	return &UnregisterContent{
+		addr: addr,
+	}
+}
+
+// Marshal marshals the UnregisterContent to a binary representation of it.
+func (uc *UnregisterContent) Marshal() (data []byte, err error) {
+
+	var w bytes.Buffer
+	if err := util.WriteVarBytes(&w, uc.addr[:]); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// Unmarshal unmarshals UnregisterContent from binary data.
+func (uc *UnregisterContent) Unmarshal(data []byte) error {
+	var r = bytes.NewBuffer(data)
+	varbytes, err := util.ReadVarBytes(r)
+	if err != nil {
+		return err
+	}
+	copy(uc.addr[:], varbytes)
+
+	return nil
+}
+
+// Addr returns addr in unregisterContent.
+func (uc *UnregisterContent) Addr() AddressHash {
+	return uc.addr
+}
+
+// CandidateInfoContent identify the tx of update candidate type, which publishes
+// peer id, endpoint and description of the candidate.
+type CandidateInfoContent struct {
+	addr        AddressHash
+	peerID      string
+	endpoint    string
+	description string
+}
+
+// NewCandidateInfoContent creates a CandidateInfoContent of the candidate address.
+func NewCandidateInfoContent(addr AddressHash, peerID, endpoint, description string) *CandidateInfoContent {
+	return &CandidateInfoContent{
+		addr:        addr,
+		peerID:      peerID,
+		endpoint:    endpoint,
+		description: description,
+	}
+}
+
+// Marshal marshals the CandidateInfoContent to a binary representation of it.
+func (cc *CandidateInfoContent) Marshal() (data []byte, err error) {
+
+	var w bytes.Buffer
+	for _, field := range [][]byte{cc.addr[:], []byte(cc.peerID),
+		[]byte(cc.endpoint), []byte(cc.description)} {
+		if err := util.WriteVarBytes(&w, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.Bytes(), nil
+}
+
+// Unmarshal unmarshals CandidateInfoContent from binary data.
+func (cc *CandidateInfoContent) Unmarshal(data []byte) error {
+
+	var r = bytes.NewBuffer(data)
+	fields := make([][]byte, 4)
+	for k := range fields {
+		varbytes, err := util.ReadVarBytes(r)
+		if err != nil {
+			return err
+		}
+		fields[k] = varbytes
+	}
+	copy(cc.addr[:], fields[0])
+	cc.peerID = string(fields[1])
+	cc.endpoint = string(fields[2])
+	cc.description = string(fields[3])
+
+	return nil
+}
+
+// Addr returns addr in candidateInfoContent.
+func (cc *CandidateInfoContent) Addr() AddressHash {
+	return cc.addr
+}
+
+// PeerID returns peer id in candidateInfoContent.
+func (cc *CandidateInfoContent) PeerID() string {
+	return cc.peerID
+}
+
+// Endpoint returns endpoint in candidateInfoContent.
+func (cc *CandidateInfoContent) Endpoint() string {
+	return cc.endpoint
+}
+
+// Description returns description in candidateInfoContent.
+func (cc *CandidateInfoContent) Description() string {
+	return cc.description
 }
@@ -5,6 +5,7 @@
 package types
 
 import (
+	"math"
 	"testing"
 
 	"github.com/BOXFoundation/boxd/crypto"
@@ -45,3 +46,24 @@ func TestTxConvertWithProtoMessage(t *testing.T) {
 	tx.hash, _ = calcProtoMsgDoubleHash(msg)
 	ensure.DeepEqual(t, tx, tx1)
 }
+
+func TestSignalsReplacement(t *testing.T) {
+	tx := NewTransaction(*NewOutPoint(crypto.HashType{0x0013}), 1, 0)
+	tx.Vin[0].Sequence = 1
+	ensure.False(t, tx.SignalsReplacement())
+	tx.Vin[0].Sequence = TxInSequenceReplaceable | 1
+	ensure.True(t, tx.SignalsReplacement())
+	// final input
+	tx.Vin[0].Sequence = math.MaxUint32
+	ensure.False(t, tx.SignalsReplacement())
+}
+
+func TestCandidateInfoContent(t *testing.T) {
+	content := NewCandidateInfoContent(AddressHash{0x01}, "peer", "127.0.0.1:19199", "")
+	data, err := content.Marshal()
+	ensure.Nil(t, err)
+	content1 := new(CandidateInfoContent)
+	ensure.Nil(t, content1.Unmarshal(data))
+	ensure.DeepEqual(t, content1, content)
+	ensure.NotNil(t, content1.Unmarshal(data[:len(data)-1]))
+}
@@ -6,6 +6,8 @@ package p2p
 
 import (
 	"time"
+
+	"github.com/BOXFoundation/boxd/crypto"
 )
 
 // Config for peer configuration
@@ -20,4 +22,7 @@ type Config struct {
 	AddPeers        []string      `mapstructure:"addpeer"`
 	ConnMaxCapacity uint32        `mapstructure:"conn_max_capacity"`
 	ConnLoadFactor  float32       `mapstructure:"conn_load_factor"`
+	// GenesisHash is set by the node from the genesis of its network, which is
+	// checked in handshake with remote peers.
+	GenesisHash crypto.HashType `mapstructure:"-"`
 }
@@ -5,6 +5,7 @@
 package p2p
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"sync"
@@ -189,25 +190,25 @@ func (conn *Conn) heartBeatService(p goprocess.Process) {
 
 // Ping the target node
 func (conn *Conn) Ping() error {
-	return conn.Write(Ping, []byte(PingBody))
+	return conn.Write(Ping, conn.handshakeBody(PingBody))
 }
 
 // OnPing respond the ping message
 func (conn *Conn) OnPing(data []byte) error {
-	if PingBody != string(data) {
-		return ErrMessageDataContent
+	if err := conn.checkHandshakeBody(PingBody, data); err != nil {
+		return err
 	}
 
 	conn.peer.bus.Publish(eventbus.TopicConnEvent, conn.remotePeer, eventbus.HeartBeatEvent)
 	conn.Establish() // establish connection
 
-	return conn.Write(Pong, []byte(PongBody))
+	return conn.Write(Pong, conn.handshakeBody(PongBody))
 }
 
 // OnPong respond the pong message
 func (conn *Conn) OnPong(data []byte) error {
-	if PongBody != string(data) {
-		return ErrMessageDataContent
+	if err := conn.checkHandshakeBody(PongBody, data); err != nil {
+		return err
 	}
 	conn.peer.bus.Publish(eventbus.TopicConnEvent, conn.remotePeer, eventbus.HeartBeatEvent)
 	if !conn.Establish() {
@@ -221,6 +222,23 @@ func (conn *Conn) OnPong(data []byte) error {
 	return nil
 }
 
+// handshakeBody appends genesis hash to body of ping and pong, so that peers
+// of different networks never establish connections.
+func (conn *Conn) handshakeBody(body string) []byte {
+	return append([]byte(body), conn.peer.config.GenesisHash[:]...)
+}
+
+func (conn *Conn) checkHandshakeBody(body string, data []byte) error {
+	if !bytes.HasPrefix(data, []byte(body)) {
+		return ErrMessageDataContent
+	}
+	if !bytes.Equal(data[len(body):], conn.peer.config.GenesisHash[:]) {
+		logger.Warnf("Peer %s is on another network with genesis %x", conn.remotePeer.Pretty(), data[len(body):])
+		return ErrGenesisMismatch
+	}
+	return nil
+}
+
 // PeerDiscover discover new peers from remoute peer.
 // TODO: we should discover other peers periodly via randomly
 // selected remote active peers. Now we only send peer discovery
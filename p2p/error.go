@@ -16,6 +16,7 @@ var (
 	ErrMessageDataContent        = errors.New("Invalid message data content")
 	ErrNoConnectionEstablished   = errors.New("No connection established")
 	ErrFailedToSendMessageToPeer = errors.New("Failed to send message to peer")
+	ErrGenesisMismatch           = errors.New("Genesis of remote peer mismatches")
 
 	//message.go
 	ErrMessageHeaderLength     = errors.New("Can not read p2p message header length")
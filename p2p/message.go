@@ -46,6 +46,16 @@ const (
 	LightSyncRequest = 0x17
 	LightSyncReponse = 0x18
 
+	HeadersRequest  = 0x19
+	HeadersResponse = 0x1A
+
+	SnapshotManifestRequest  = 0x1B
+	SnapshotManifestResponse = 0x1C
+	SnapshotChunkRequest     = 0x1D
+	SnapshotChunkResponse    = 0x1E
+
+	EvidenceMsg = 0x1F
+
 	MaxMessageDataLength = 1024 * 1024 * 1024 // 1GB
 )
 
@@ -59,21 +69,28 @@ const (
 var defaultMessageAttribute = &messageAttribute{compress: false, priority: midPriority}
 
 var msgToAttribute = map[uint32]*messageAttribute{
-	Ping:                    &messageAttribute{compress: false, priority: lowPriority},
-	Pong:                    &messageAttribute{compress: false, priority: lowPriority},
-	PeerDiscover:            &messageAttribute{compress: false, priority: lowPriority},
-	PeerDiscoverReply:       &messageAttribute{compress: true, priority: midPriority},
-	NewBlockMsg:             &messageAttribute{compress: true, priority: topPriority},
-	TransactionMsg:          &messageAttribute{compress: true, priority: highPriority},
-	LocateForkPointRequest:  &messageAttribute{compress: false, priority: midPriority},
-	LocateForkPointResponse: &messageAttribute{compress: true, priority: midPriority},
-	LocateCheckRequest:      &messageAttribute{compress: false, priority: midPriority},
-	LocateCheckResponse:     &messageAttribute{compress: false, priority: midPriority},
-	BlockChunkRequest:       &messageAttribute{compress: true, priority: midPriority},
-	BlockChunkResponse:      &messageAttribute{compress: true, priority: midPriority},
-	EternalBlockMsg:         &messageAttribute{compress: false, priority: highPriority},
-	LightSyncRequest:        &messageAttribute{compress: false, priority: midPriority},
-	LightSyncReponse:        &messageAttribute{compress: false, priority: midPriority},
+	Ping:                     &messageAttribute{compress: false, priority: lowPriority},
+	Pong:                     &messageAttribute{compress: false, priority: lowPriority},
+	PeerDiscover:             &messageAttribute{compress: false, priority: lowPriority},
+	PeerDiscoverReply:        &messageAttribute{compress: true, priority: midPriority},
+	NewBlockMsg:              &messageAttribute{compress: true, priority: topPriority},
+	TransactionMsg:           &messageAttribute{compress: true, priority: highPriority},
+	LocateForkPointRequest:   &messageAttribute{compress: false, priority: midPriority},
+	LocateForkPointResponse:  &messageAttribute{compress: true, priority: midPriority},
+	LocateCheckRequest:       &messageAttribute{compress: false, priority: midPriority},
+	LocateCheckResponse:      &messageAttribute{compress: false, priority: midPriority},
+	BlockChunkRequest:        &messageAttribute{compress: true, priority: midPriority},
+	BlockChunkResponse:       &messageAttribute{compress: true, priority: midPriority},
+	EternalBlockMsg:          &messageAttribute{compress: false, priority: highPriority},
+	LightSyncRequest:         &messageAttribute{compress: false, priority: midPriority},
+	LightSyncReponse:         &messageAttribute{compress: false, priority: midPriority},
+	HeadersRequest:           &messageAttribute{compress: false, priority: midPriority},
+	HeadersResponse:          &messageAttribute{compress: true, priority: midPriority},
+	SnapshotManifestRequest:  &messageAttribute{compress: false, priority: midPriority},
+	SnapshotManifestResponse: &messageAttribute{compress: true, priority: midPriority},
+	SnapshotChunkRequest:     &messageAttribute{compress: false, priority: midPriority},
+	SnapshotChunkResponse:    &messageAttribute{compress: true, priority: midPriority},
+	EvidenceMsg:              &messageAttribute{compress: false, priority: highPriority},
 }
 
 // NetworkNamtToMagic is a map from network name to magic number.
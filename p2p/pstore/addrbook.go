@@ -240,12 +240,11 @@ func (ab *addrBook) Addrs(p peer.ID) []ma.Multiaddr {
 	var buf []byte
 	// used to get the multiaddr with the prefix of the peer
 	earliestExp := maxTime
-	for _, k := range txn.KeysWithPrefix(prefix.Bytes()) {
-		if buf, err = txn.Get(k); err != nil {
-			logger.Errorf("failed to get value of key %s: %v", string(k), err)
-			return nil
-		}
-		addr, err := ma.NewMultiaddrBytes(buf)
+	iter := txn.NewIterator(storage.BytesPrefix(prefix.Bytes()))
+	defer iter.Release()
+	for iter.Next() {
+		// multiaddr keeps the bytes, which are reused by iterator
+		addr, err := ma.NewMultiaddrBytes(append([]byte(nil), iter.Value()...))
 		if err != nil {
 			logger.Errorf("failed to unmarshal addr: %v", err)
 			return nil
@@ -253,7 +252,7 @@ func (ab *addrBook) Addrs(p peer.ID) []ma.Multiaddr {
 		addrs = append(addrs, addr)
 
 		// get ttl
-		ttlKey := ttlBase.Child(key.NewKeyFromBytes(k))
+		ttlKey := ttlBase.Child(key.NewKeyFromBytes(iter.Key()))
 		if buf, err = txn.Get(ttlKey.Bytes()); err == nil && len(buf) > 0 {
 			var exp time.Time
 			if err := exp.UnmarshalBinary(buf); err == nil {
@@ -263,6 +262,10 @@ func (ab *addrBook) Addrs(p peer.ID) []ma.Multiaddr {
 			}
 		}
 	}
+	if err = iter.Error(); err != nil {
+		logger.Errorf("failed to iterate addrs of peer %s: %v", p.Pretty(), err)
+		return nil
+	}
 
 	// Store a copy in the cache.
 	if ab.cache != nil {
@@ -530,12 +533,17 @@ func (ab *addrBook) dbUpdateTTL(p peer.ID, oldTTL time.Duration, newTTL time.Dur
 		return err
 	}
 
-	for _, k := range txn.KeysWithPrefix(prefix.Bytes()) {
-		ttlkey := ttlBase.Child(key.NewKeyFromBytes(k))
+	iter := txn.NewIterator(storage.BytesPrefix(prefix.Bytes()))
+	defer iter.Release()
+	for iter.Next() {
+		ttlkey := ttlBase.Child(key.NewKeyFromBytes(iter.Key()))
 		if err = txn.Put(ttlkey.Bytes(), ttlbuf); err != nil {
 			return err
 		}
 	}
+	if err = iter.Error(); err != nil {
+		return err
+	}
 
 	return txn.Commit()
 }
@@ -544,23 +552,23 @@ func (ab *addrBook) dbGetTTL(p peer.ID) (time.Duration, error) {
 	var (
 		prefix = abBase.ChildString(p.Pretty())
 	)
-	keys := ab.store.KeysWithPrefix(prefix.Bytes())
-	if len(keys) == 0 {
-		return time.Duration(0), fmt.Errorf("no db record found for peer ttl")
-	}
+	// ttls of addrs of the peer are keyed by '/ttl' + their keys
 	var exps []*time.Time
-	for _, k := range keys {
-		ttlKey := ttlBase.Child(key.NewKeyFromBytes(k))
-		buf, err := ab.store.Get(ttlKey.Bytes())
-		if err != nil {
-			return time.Duration(0), err
-		}
+	iter := ab.store.NewIterator(storage.BytesPrefix(ttlBase.Child(prefix).Bytes()))
+	defer iter.Release()
+	for iter.Next() {
 		exp := new(time.Time)
-		if err := exp.UnmarshalBinary(buf); err != nil {
+		if err := exp.UnmarshalBinary(iter.Value()); err != nil {
 			return time.Duration(0), err
 		}
 		exps = append(exps, exp)
 	}
+	if err := iter.Error(); err != nil {
+		return time.Duration(0), err
+	}
+	if len(exps) == 0 {
+		return time.Duration(0), fmt.Errorf("no db record found for peer ttl")
+	}
 	sort.Slice(exps, func(i, j int) bool {
 		return exps[i].After(*exps[j])
 	})
@@ -580,16 +588,20 @@ func (ab *addrBook) dbDeleteIter(prefix key.Key) error {
 	}
 	defer txn.Discard()
 
-	for _, k := range txn.KeysWithPrefix(prefix.Bytes()) {
-		if err := txn.Del(k); err != nil {
+	iter := txn.NewIterator(storage.BytesPrefix(prefix.Bytes()))
+	defer iter.Release()
+	for iter.Next() {
+		ttlkey := ttlBase.Child(key.NewKeyFromBytes(iter.Key()))
+		if err := txn.Del(iter.Key()); err != nil {
 			return err
 		}
-
-		ttlkey := ttlBase.Child(key.NewKeyFromBytes(k))
 		if err := txn.Del(ttlkey.Bytes()); err != nil {
 			return err
 		}
 	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
 
 	return txn.Commit()
 }
@@ -605,17 +617,19 @@ func (ab *addrBook) dbRemoveExpired() error {
 	defer txn.Discard()
 
 	now := time.Now()
-	for _, k := range txn.KeysWithPrefix(ttlBase.Bytes()) {
-		if buf, err := txn.Get(k); err == nil && len(buf) > 0 {
+	iter := txn.NewIterator(storage.BytesPrefix(ttlBase.Bytes()))
+	defer iter.Release()
+	for iter.Next() {
+		if buf := iter.Value(); len(buf) > 0 {
 			var exp time.Time
 			if err := exp.UnmarshalBinary(buf); err == nil {
 				if !exp.IsZero() && exp.Before(now) {
-					if err = txn.Del(k); err != nil {
+					if err = txn.Del(iter.Key()); err != nil {
 						return err
 					}
 
 					// del key of multiaddr
-					ttlKey := key.NewKeyFromBytes(k)
+					ttlKey := key.NewKeyFromBytes(iter.Key())
 					n := ttlKey.List()
 					if len(n) > 1 {
 						addKey := key.NewKey(strings.Join(n[1:], "/"))
@@ -57,14 +57,19 @@ func uniquePeerIDs(store storage.Table, prefix []byte, parse func(key.Key) strin
 
 	idset := make(map[peer.ID]struct{})
 	// get all peer addrs in database
-	for _, k := range store.KeysWithPrefix(prefix) {
-		pk := key.NewKeyFromBytes(k)
+	iter := store.NewIterator(storage.BytesPrefix(prefix))
+	defer iter.Release()
+	for iter.Next() {
+		pk := key.NewKeyFromBytes(iter.Key())
 		pid, err := peer.IDB58Decode(parse(pk))
 		if err != nil {
 			return nil, err
 		}
 		idset[pid] = struct{}{}
 	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
 
 	pids := make([]peer.ID, len(idset))
 	i := 0
@@ -38,7 +38,7 @@ func (tp *TransferParam) getScript() ([]byte, error) {
 		}
 		return getTransferTokenScript(tp.addr.Hash(), &tp.token.Hash, tp.token.Index, tp.amount)
 	}
-	return getScriptAddressFromPubKeyHash(tp.addr.Hash())
+	return getScriptAddress(tp.addr), nil
 }
 
 func (tp *TransferParam) getTxOut() (*corepb.TxOut, error) {
@@ -361,7 +361,7 @@ func findUtxoScriptPubKey(utxos []*rpcpb.Utxo, outPoint *corepb.OutPoint) ([]byt
 }
 
 func getScriptAddress(address types.Address) []byte {
-	return *script.PayToPubKeyHashScript(address.Hash())
+	return *script.PayToAddressScript(address)
 }
 
 // NewConnectionWithViper initializes a grpc connection using configs parsed by viper
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/rpc/pb"
+	"google.golang.org/grpc"
+)
+
+// RegisterCandidate signs up fromAddress as candidate with its peer id
+func RegisterCandidate(conn *grpc.ClientConn, fromAddress types.Address, peerID string,
+	pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	content, err := types.NewSignUpContent(*fromAddress.Hash160(), peerID).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return createCandidateTx(conn, fromAddress, types.RegisterCandidateTx, content, pubKeyBytes, signer)
+}
+
+// UnregisterCandidate withdraws candidate fromAddress from election
+func UnregisterCandidate(conn *grpc.ClientConn, fromAddress types.Address,
+	pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	content, err := types.NewUnregisterContent(*fromAddress.Hash160()).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return createCandidateTx(conn, fromAddress, types.UnregisterCandidateTx, content, pubKeyBytes, signer)
+}
+
+// UpdateCandidate publishes peer id, endpoint and description of candidate fromAddress
+func UpdateCandidate(conn *grpc.ClientConn, fromAddress types.Address, peerID, endpoint, description string,
+	pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	content, err := types.NewCandidateInfoContent(*fromAddress.Hash160(), peerID, endpoint, description).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return createCandidateTx(conn, fromAddress, types.UpdateCandidateTx, content, pubKeyBytes, signer)
+}
+
+// createCandidateTx sends a tx of txType carrying content, which spends coins of
+// fromAddress to authorize it, and pays them back to fromAddress except the fee
+func createCandidateTx(conn *grpc.ClientConn, fromAddress types.Address, txType int, content []byte,
+	pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+
+	price, err := GetFeePrice(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []*TransferParam{{addr: fromAddress, amount: dustLimit}}
+	change := &corepb.TxOut{
+		Value:        0,
+		ScriptPubKey: getScriptAddress(fromAddress),
+	}
+	var tx *corepb.Transaction
+	amount := uint64(dustLimit)
+	for {
+		utxoResponse, err := FundTransaction(conn, fromAddress, amount)
+		if err != nil {
+			return nil, err
+		}
+		if tx, err = generateTx(fromAddress, utxoResponse.GetUtxos(), targets, change); err != nil {
+			return nil, err
+		}
+		tx.Data = &corepb.Data{Type: int32(txType), Content: content}
+		if err = signTransaction(tx, utxoResponse.GetUtxos(), pubKeyBytes, signer); err != nil {
+			return nil, err
+		}
+		ok, adjustedAmount := tryBalance(tx, change, utxoResponse.Utxos, price)
+		if ok {
+			signTransaction(tx, utxoResponse.GetUtxos(), pubKeyBytes, signer)
+			break
+		}
+		amount = adjustedAmount
+	}
+
+	c := rpcpb.NewTransactionCommandClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err = c.SendTransaction(ctx, &rpcpb.SendTransactionRequest{Tx: tx}); err != nil {
+		return nil, err
+	}
+	transaction := &types.Transaction{}
+	transaction.FromProtoMessage(tx)
+	return transaction, nil
+}
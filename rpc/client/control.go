@@ -6,9 +6,13 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/BOXFoundation/boxd/consensus/dpos"
 	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
 	pb "github.com/BOXFoundation/boxd/rpc/pb"
 	"google.golang.org/grpc"
 )
@@ -115,3 +119,119 @@ func GetBlock(conn *grpc.ClientConn, hash string) (*types.Block, error) {
 	err = block.FromProtoMessage(r.Block)
 	return block, err
 }
+
+// GenerateBlocks generates count blocks right away on a node in dev mode, and
+// returns hashes of the blocks generated
+func GenerateBlocks(conn *grpc.ClientConn, count uint32) ([]string, error) {
+	c := pb.NewContorlCommandClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger.Infof("Generate %d blocks", count)
+	r, err := c.GenerateBlocks(ctx, &pb.GenerateBlocksRequest{Count: count})
+	if err != nil {
+		return nil, err
+	}
+	if r.Code != 0 {
+		return r.Hashes, errors.New(r.Message)
+	}
+	return r.Hashes, nil
+}
+
+// GetUtxoRoot returns the tail block and the commitment to utxo set as of it,
+// which is recomputed from all utxos to verify the utxo set if verify is set
+func GetUtxoRoot(conn *grpc.ClientConn, verify bool) (*pb.GetUtxoRootResponse, error) {
+	c := pb.NewContorlCommandClient(conn)
+
+	timeout := 10 * time.Second
+	if verify {
+		// it takes a while to go through all utxos
+		timeout = time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logger.Infof("Query utxo root, verify: %t", verify)
+	return c.GetUtxoRoot(ctx, &pb.GetUtxoRootRequest{Verify: verify})
+}
+
+// GetValidators returns performance of producers of the current period and
+// other validators ever recorded
+func GetValidators(conn *grpc.ClientConn) ([]*pb.Validator, error) {
+	c := pb.NewContorlCommandClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	logger.Info("Query validators")
+	r, err := c.GetValidators(ctx, &pb.GetValidatorsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if r.Code != 0 {
+		return nil, errors.New(r.Message)
+	}
+	return r.Validators, nil
+}
+
+// GetCandidates returns candidates not withdrawn ordered by votes
+func GetCandidates(conn *grpc.ClientConn) ([]*pb.Candidate, error) {
+	c := pb.NewContorlCommandClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	logger.Info("Query candidates")
+	r, err := c.GetCandidates(ctx, &pb.GetCandidatesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if r.Code != 0 {
+		return nil, errors.New(r.Message)
+	}
+	return r.Candidates, nil
+}
+
+// GetFinalityCertificate returns finality certificate of the block of hash, or
+// the block at height on main chain if hash is empty
+func GetFinalityCertificate(conn *grpc.ClientConn, height uint32, hash string) (*pb.GetFinalityCertificateResponse, error) {
+	c := pb.NewContorlCommandClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	logger.Infof("Query finality certificate of block %d %s", height, hash)
+	r, err := c.GetFinalityCertificate(ctx, &pb.GetFinalityCertificateRequest{Height: height, Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	if r.Code != 0 {
+		return nil, errors.New(r.Message)
+	}
+	return r, nil
+}
+
+// VerifyFinalityCertificate checks the finality certificate proves the block in
+// it is eternal, i.e., the block is confirmed by more than two thirds of producers
+// of the period of periodHash, which the caller trusts and must not take from
+// the server, e.g., dpos.GenesisPeriodHash() for blocks in the first period
+func VerifyFinalityCertificate(cert *pb.GetFinalityCertificateResponse, periodHash *crypto.HashType) error {
+	header := new(types.BlockHeader)
+	if err := header.Unmarshal(cert.Header); err != nil {
+		return err
+	}
+	if hash := (&types.Block{Header: header}).BlockHash(); hash.String() != cert.Hash {
+		return fmt.Errorf("block hash %s mismatches header hash %s", cert.Hash, hash)
+	}
+	periodContext := new(dpos.PeriodContext)
+	if err := periodContext.Unmarshal(cert.PeriodContext); err != nil {
+		return err
+	}
+	return (&dpos.FinalityCertificate{
+		Header:        header,
+		Height:        cert.Height,
+		PeriodContext: periodContext,
+		Signatures:    cert.Signatures,
+	}).Verify(periodHash)
+}
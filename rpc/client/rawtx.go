@@ -0,0 +1,338 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/rpc/pb"
+	"github.com/BOXFoundation/boxd/script"
+	"google.golang.org/grpc"
+)
+
+// TxInfo is the human readable form of a decoded raw transaction
+type TxInfo struct {
+	Hash     string       `json:"hash"`
+	Version  int32        `json:"version"`
+	Vin      []*TxInInfo  `json:"vin"`
+	Vout     []*TxOutInfo `json:"vout"`
+	Data     *TxDataInfo  `json:"data,omitempty"`
+	Magic    uint32       `json:"magic"`
+	LockTime int64        `json:"lock_time"`
+	Size     int          `json:"size"`
+}
+
+// TxInInfo is the human readable form of a transaction input
+type TxInInfo struct {
+	PrevTxHash string `json:"prev_tx_hash"`
+	PrevIndex  uint32 `json:"prev_index"`
+	ScriptSig  string `json:"script_sig"`
+	ScriptHex  string `json:"script_hex"`
+	Sequence   uint32 `json:"sequence"`
+	Signed     bool   `json:"signed"`
+}
+
+// TxOutInfo is the human readable form of a transaction output
+type TxOutInfo struct {
+	Index         uint32              `json:"index"`
+	Value         uint64              `json:"value"`
+	Type          string              `json:"type"`
+	Address       string              `json:"address,omitempty"`
+	ScriptPubKey  string              `json:"script_pub_key"`
+	ScriptHex     string              `json:"script_hex"`
+	TokenIssue    *script.IssueParams `json:"token_issue,omitempty"`
+	TokenTransfer *TokenTransferInfo  `json:"token_transfer,omitempty"`
+}
+
+// TokenTransferInfo is the human readable form of token transfer parameters
+type TokenTransferInfo struct {
+	TokenTxHash   string `json:"token_tx_hash"`
+	TokenTxOutIdx uint32 `json:"token_tx_out_idx"`
+	Amount        uint64 `json:"amount"`
+}
+
+// TxDataInfo is the human readable form of the data attached to a transaction
+type TxDataInfo struct {
+	Type       int32  `json:"type"`
+	Content    string `json:"content"`
+	ContentHex string `json:"content_hex"`
+}
+
+// script output types shown in decoded transactions
+const (
+	scriptTypePubKeyHash    = "pubkeyhash"
+	scriptTypeScriptHash    = "scripthash"
+	scriptTypeTokenIssue    = "token_issue"
+	scriptTypeTokenTransfer = "token_transfer"
+	scriptTypeNonStandard   = "nonstandard"
+)
+
+// CreateRawTransaction creates an unsigned transaction spending the given outpoints.
+// The i-th output pays amounts[i] to addrs[i]; no change output is added, so the
+// caller has to list it explicitly. It does not need a connection to boxd.
+func CreateRawTransaction(outPoints []*types.OutPoint, addrs []types.Address, amounts []uint64) (*types.Transaction, error) {
+	if len(outPoints) == 0 {
+		return nil, fmt.Errorf("at least one input is required")
+	}
+	if len(addrs) == 0 || len(addrs) != len(amounts) {
+		return nil, fmt.Errorf("mismatched outputs: %d addresses, %d amounts", len(addrs), len(amounts))
+	}
+	utxos := make([]*rpcpb.Utxo, 0, len(outPoints))
+	for _, op := range outPoints {
+		utxos = append(utxos, &rpcpb.Utxo{
+			OutPoint: &corepb.OutPoint{Hash: op.Hash.GetBytes(), Index: op.Index},
+			TxOut:    &corepb.TxOut{},
+		})
+	}
+	targets := make([]*TransferParam, 0, len(addrs))
+	for i, addr := range addrs {
+		targets = append(targets, &TransferParam{
+			addr:    addr,
+			isToken: false,
+			amount:  amounts[i],
+		})
+	}
+	msg, err := generateTx(nil, utxos, targets, nil)
+	if err != nil {
+		return nil, err
+	}
+	tx := &types.Transaction{}
+	if err := tx.FromProtoMessage(msg); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SignRawTransaction signs all inputs of tx with signer. Every input must spend
+// a pay-to-pubkey-hash output of pubKeyBytes, which lets a cold wallet sign
+// without querying the referenced utxos from boxd.
+func SignRawTransaction(tx *types.Transaction, pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	pubKey, err := crypto.PublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := types.NewAddressFromPubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := tx.ToProtoMessage()
+	if err != nil {
+		return nil, err
+	}
+	txMsg, ok := msg.(*corepb.Transaction)
+	if !ok {
+		return nil, fmt.Errorf("invalid transaction message")
+	}
+	prevScriptPubKey := getScriptAddress(addr)
+	utxos := make([]*rpcpb.Utxo, 0, len(txMsg.Vin))
+	for _, txIn := range txMsg.Vin {
+		utxos = append(utxos, &rpcpb.Utxo{
+			OutPoint: txIn.PrevOutPoint,
+			TxOut:    &corepb.TxOut{ScriptPubKey: prevScriptPubKey},
+		})
+	}
+	if err := signTransaction(txMsg, utxos, pubKeyBytes, signer); err != nil {
+		return nil, err
+	}
+	signedTx := &types.Transaction{}
+	if err := signedTx.FromProtoMessage(txMsg); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// CreateMultisigAddress creates an m-of-n multisig redeem script of pubKeys and
+// the pay-to-script-hash address locking coins to it
+func CreateMultisigAddress(m int, pubKeys [][]byte) (*types.AddressScriptHash, []byte, error) {
+	redeemScript, err := script.MultisigScript(m, pubKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err := types.NewAddressScriptHash(*redeemScript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, *redeemScript, nil
+}
+
+// SignMultisigTransaction adds signer's signature to all inputs of tx, which spend
+// pay-to-script-hash outputs locked to the multisig redeemScript. Keyholders sign
+// one after another until the returned complete flag is set, then tx can be sent.
+func SignMultisigTransaction(tx *types.Transaction, redeemScript []byte, signer crypto.Signer) (*types.Transaction, bool, error) {
+	redeem := script.NewScriptFromBytes(redeemScript)
+	addr, err := types.NewAddressScriptHash(redeemScript)
+	if err != nil {
+		return nil, false, err
+	}
+	prevScriptPubKey := script.PayToAddressScript(addr)
+	complete := true
+	for txInIdx, txIn := range tx.Vin {
+		sigHash, err := script.CalcTxHashForSig(redeemScript, tx, txInIdx)
+		if err != nil {
+			return nil, false, err
+		}
+		sig, err := signer.Sign(sigHash)
+		if err != nil {
+			return nil, false, err
+		}
+		scriptSig, inputComplete, err := script.AddMultisigSignature(sig.Serialize(), redeem, tx, txInIdx)
+		if err != nil {
+			return nil, false, err
+		}
+		txIn.ScriptSig = *scriptSig
+		if inputComplete {
+			// test to ensure
+			if err := script.Validate(scriptSig, prevScriptPubKey, tx, txInIdx); err != nil {
+				return nil, false, err
+			}
+		}
+		complete = complete && inputComplete
+	}
+	// script sigs changed, recompute the hash instead of using the cached one
+	signedTx := &types.Transaction{}
+	msg, err := tx.ToProtoMessage()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := signedTx.FromProtoMessage(msg); err != nil {
+		return nil, false, err
+	}
+	return signedTx, complete, nil
+}
+
+// SendRawTransaction submits a signed transaction to boxd
+func SendRawTransaction(conn *grpc.ClientConn, tx *types.Transaction) error {
+	msg, err := tx.ToProtoMessage()
+	if err != nil {
+		return err
+	}
+	txMsg, ok := msg.(*corepb.Transaction)
+	if !ok {
+		return fmt.Errorf("invalid transaction message")
+	}
+	c := rpcpb.NewTransactionCommandClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	r, err := c.SendTransaction(ctx, &rpcpb.SendTransactionRequest{Tx: txMsg})
+	if err != nil {
+		return err
+	}
+	if r.Code != 0 {
+		return fmt.Errorf("send transaction failed: %s", r.Message)
+	}
+	return nil
+}
+
+// EncodeRawTransaction returns the hex encoded serialization of tx
+func EncodeRawTransaction(tx *types.Transaction) (string, error) {
+	data, err := tx.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// ParseRawTransaction parses a hex encoded transaction
+func ParseRawTransaction(rawTx string) (*types.Transaction, error) {
+	data, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	tx := &types.Transaction{}
+	if err := tx.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// DecodeRawTransaction decodes a hex encoded transaction into readable form
+func DecodeRawTransaction(rawTx string) (*TxInfo, error) {
+	tx, err := ParseRawTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := tx.TxHash()
+	if err != nil {
+		return nil, err
+	}
+	size, err := tx.SerializeSize()
+	if err != nil {
+		return nil, err
+	}
+	info := &TxInfo{
+		Hash:     hash.String(),
+		Version:  tx.Version,
+		Magic:    tx.Magic,
+		LockTime: tx.LockTime,
+		Size:     size,
+	}
+	for _, txIn := range tx.Vin {
+		info.Vin = append(info.Vin, decodeTxIn(txIn))
+	}
+	for i, txOut := range tx.Vout {
+		info.Vout = append(info.Vout, decodeTxOut(uint32(i), txOut))
+	}
+	if tx.Data != nil {
+		info.Data = &TxDataInfo{
+			Type:       tx.Data.Type,
+			Content:    string(tx.Data.Content),
+			ContentHex: hex.EncodeToString(tx.Data.Content),
+		}
+	}
+	return info, nil
+}
+
+func decodeTxIn(txIn *types.TxIn) *TxInInfo {
+	scriptSig := script.NewScriptFromBytes(txIn.ScriptSig)
+	info := &TxInInfo{
+		PrevTxHash: txIn.PrevOutPoint.Hash.String(),
+		PrevIndex:  txIn.PrevOutPoint.Index,
+		ScriptSig:  scriptSig.Disasm(),
+		ScriptHex:  hex.EncodeToString(txIn.ScriptSig),
+		Sequence:   txIn.Sequence,
+		Signed:     len(txIn.ScriptSig) > 0,
+	}
+	return info
+}
+
+func decodeTxOut(index uint32, txOut *corepb.TxOut) *TxOutInfo {
+	sc := script.NewScriptFromBytes(txOut.ScriptPubKey)
+	info := &TxOutInfo{
+		Index:        index,
+		Value:        txOut.Value,
+		Type:         scriptTypeNonStandard,
+		ScriptPubKey: sc.Disasm(),
+		ScriptHex:    hex.EncodeToString(txOut.ScriptPubKey),
+	}
+	switch {
+	case sc.IsTokenIssue():
+		info.Type = scriptTypeTokenIssue
+		info.TokenIssue, _ = sc.GetIssueParams()
+	case sc.IsTokenTransfer():
+		info.Type = scriptTypeTokenTransfer
+		if params, err := sc.GetTransferParams(); err == nil {
+			info.TokenTransfer = &TokenTransferInfo{
+				TokenTxHash:   params.Hash.String(),
+				TokenTxOutIdx: params.Index,
+				Amount:        params.Amount,
+			}
+		}
+	case sc.IsPayToPubKeyHash():
+		info.Type = scriptTypePubKeyHash
+	case sc.IsPayToScriptHash():
+		info.Type = scriptTypeScriptHash
+	}
+	if addr, err := sc.ExtractAddress(); err == nil {
+		info.Address = addr.String()
+	}
+	return info
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/BOXFoundation/boxd/crypto"
+	"github.com/BOXFoundation/boxd/rpc/pb"
+	"github.com/BOXFoundation/boxd/script"
+	"github.com/facebookgo/ensure"
+	"google.golang.org/grpc"
+)
+
+// keySigner signs with a private key as a wallet account does
+type keySigner crypto.PrivateKey
+
+func (s *keySigner) Sign(messageHash *crypto.HashType) (*crypto.Signature, error) {
+	return crypto.Sign((*crypto.PrivateKey)(s), messageHash)
+}
+
+// testTxServer records transactions sent to it
+type testTxServer struct {
+	rpcpb.TransactionCommandServer
+	code int32
+	txs  []*corepb.Transaction
+}
+
+func (s *testTxServer) SendTransaction(ctx context.Context, req *rpcpb.SendTransactionRequest) (*rpcpb.BaseResponse, error) {
+	s.txs = append(s.txs, req.Tx)
+	return &rpcpb.BaseResponse{Code: s.code, Message: "rejected"}, nil
+}
+
+func newTestTxServer(t *testing.T) (*testTxServer, *grpc.ClientConn, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	ensure.Nil(t, err)
+	srv := &testTxServer{}
+	s := grpc.NewServer()
+	rpcpb.RegisterTransactionCommandServer(s, srv)
+	go s.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	ensure.Nil(t, err)
+	return srv, conn, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestRawTransaction(t *testing.T) {
+	privKey, pubKey, _ := crypto.NewKeyPair()
+	addr, _ := types.NewAddressFromPubKey(pubKey)
+	_, toPubKey, _ := crypto.NewKeyPair()
+	toAddr, _ := types.NewAddressFromPubKey(toPubKey)
+	outPoints := []*types.OutPoint{
+		{Hash: crypto.HashType{0x01}, Index: 0},
+		{Hash: crypto.HashType{0x02}, Index: 3},
+	}
+
+	// create
+	_, err := CreateRawTransaction(nil, []types.Address{toAddr}, []uint64{100})
+	ensure.NotNil(t, err)
+	_, err = CreateRawTransaction(outPoints, []types.Address{toAddr, addr}, []uint64{100})
+	ensure.NotNil(t, err)
+	tx, err := CreateRawTransaction(outPoints, []types.Address{toAddr, addr}, []uint64{100, 20})
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(tx.Vin), 2)
+	ensure.DeepEqual(t, tx.Vin[1].PrevOutPoint, *outPoints[1])
+	ensure.DeepEqual(t, len(tx.Vout), 2)
+	ensure.DeepEqual(t, tx.Vout[0].Value, uint64(100))
+
+	rawTx, err := EncodeRawTransaction(tx)
+	ensure.Nil(t, err)
+	info, err := DecodeRawTransaction(rawTx)
+	ensure.Nil(t, err)
+	ensure.False(t, info.Vin[0].Signed)
+
+	// sign
+	parsed, err := ParseRawTransaction(rawTx)
+	ensure.Nil(t, err)
+	signedTx, err := SignRawTransaction(parsed, pubKey.Serialize(), (*keySigner)(privKey))
+	ensure.Nil(t, err)
+	prevScriptPubKey := script.PayToPubKeyHashScript(addr.Hash())
+	for i, txIn := range signedTx.Vin {
+		scriptSig := script.NewScriptFromBytes(txIn.ScriptSig)
+		ensure.Nil(t, script.Validate(scriptSig, prevScriptPubKey, signedTx, i))
+	}
+	hash, _ := signedTx.TxHash()
+	unsignedHash, _ := tx.TxHash()
+	ensure.NotDeepEqual(t, hash, unsignedHash)
+
+	// decode
+	rawTx, err = EncodeRawTransaction(signedTx)
+	ensure.Nil(t, err)
+	info, err = DecodeRawTransaction(rawTx)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, info.Hash, hash.String())
+	ensure.DeepEqual(t, len(info.Vin), 2)
+	ensure.True(t, info.Vin[0].Signed)
+	ensure.DeepEqual(t, info.Vin[1].PrevTxHash, outPoints[1].Hash.String())
+	ensure.DeepEqual(t, info.Vin[1].PrevIndex, uint32(3))
+	ensure.DeepEqual(t, len(info.Vout), 2)
+	ensure.DeepEqual(t, info.Vout[0].Type, scriptTypePubKeyHash)
+	ensure.DeepEqual(t, info.Vout[0].Address, toAddr.String())
+	ensure.DeepEqual(t, info.Vout[1].Value, uint64(20))
+	_, err = DecodeRawTransaction("not hex")
+	ensure.NotNil(t, err)
+
+	// send
+	srv, conn, stop := newTestTxServer(t)
+	defer stop()
+	parsed, err = ParseRawTransaction(rawTx)
+	ensure.Nil(t, err)
+	ensure.Nil(t, SendRawTransaction(conn, parsed))
+	ensure.DeepEqual(t, len(srv.txs), 1)
+	sent := &types.Transaction{}
+	ensure.Nil(t, sent.FromProtoMessage(srv.txs[0]))
+	sentHash, _ := sent.TxHash()
+	ensure.DeepEqual(t, sentHash, hash)
+
+	srv.code = 1
+	ensure.NotNil(t, SendRawTransaction(conn, parsed))
+}
+
+func TestMultisigTransaction(t *testing.T) {
+	var signers []crypto.Signer
+	var pubKeys [][]byte
+	for i := 0; i < 3; i++ {
+		privKey, pubKey, _ := crypto.NewKeyPair()
+		signers = append(signers, (*keySigner)(privKey))
+		pubKeys = append(pubKeys, pubKey.Serialize())
+	}
+	addr, redeemScript, err := CreateMultisigAddress(2, pubKeys)
+	ensure.Nil(t, err)
+	_, toPubKey, _ := crypto.NewKeyPair()
+	toAddr, _ := types.NewAddressFromPubKey(toPubKey)
+
+	outPoints := []*types.OutPoint{{Hash: crypto.HashType{0x01}, Index: 1}}
+	tx, err := CreateRawTransaction(outPoints, []types.Address{toAddr}, []uint64{100})
+	ensure.Nil(t, err)
+
+	// keyholders sign one after another through raw transactions
+	tx, complete, err := SignMultisigTransaction(tx, redeemScript, signers[0])
+	ensure.Nil(t, err)
+	ensure.False(t, complete)
+	rawTx, err := EncodeRawTransaction(tx)
+	ensure.Nil(t, err)
+	tx, err = ParseRawTransaction(rawTx)
+	ensure.Nil(t, err)
+	tx, complete, err = SignMultisigTransaction(tx, redeemScript, signers[2])
+	ensure.Nil(t, err)
+	ensure.True(t, complete)
+
+	scriptSig := script.NewScriptFromBytes(tx.Vin[0].ScriptSig)
+	prevScriptPubKey := script.PayToAddressScript(addr)
+	ensure.Nil(t, script.Validate(scriptSig, prevScriptPubKey, tx, 0))
+}
@@ -5,6 +5,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -87,6 +88,17 @@ func FundTokenTransaction(conn *grpc.ClientConn, addr types.Address, token *type
 
 // CreateTransaction retrieves all the utxo of a public key, and use some of them to send transaction
 func CreateTransaction(conn *grpc.ClientConn, fromAddress types.Address, targets map[types.Address]uint64, pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	return createTransaction(conn, fromAddress, targets, pubKeyBytes, signer, false)
+}
+
+// CreateReplaceableTransaction is like CreateTransaction, but the transaction signals
+// replace-by-fee, so that its fee can be bumped with BumpFee while pending
+func CreateReplaceableTransaction(conn *grpc.ClientConn, fromAddress types.Address, targets map[types.Address]uint64, pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	return createTransaction(conn, fromAddress, targets, pubKeyBytes, signer, true)
+}
+
+func createTransaction(conn *grpc.ClientConn, fromAddress types.Address, targets map[types.Address]uint64,
+	pubKeyBytes []byte, signer crypto.Signer, replaceable bool) (*types.Transaction, error) {
 	var totalAmount uint64
 	transferTargets := make([]*TransferParam, 0)
 	for addr, amount := range targets {
@@ -117,6 +129,11 @@ func CreateTransaction(conn *grpc.ClientConn, fromAddress types.Address, targets
 		if tx, err = generateTx(fromAddress, utxoResponse.GetUtxos(), transferTargets, change); err != nil {
 			return nil, err
 		}
+		if replaceable {
+			for _, txIn := range tx.Vin {
+				txIn.Sequence |= types.TxInSequenceReplaceable
+			}
+		}
 		if err = signTransaction(tx, utxoResponse.GetUtxos(), pubKeyBytes, signer); err != nil {
 			return nil, err
 		}
@@ -143,6 +160,59 @@ func CreateTransaction(conn *grpc.ClientConn, fromAddress types.Address, targets
 	return transaction, nil
 }
 
+// BumpFee replaces tx pending in tx pool with a copy paying more fee, which is taken from
+// the change output back to fromAddress. tx must signal replace-by-fee and only spend
+// pay-to-pubkey-hash utxos of fromAddress. The replacement signals replace-by-fee as well,
+// so it can be bumped again.
+func BumpFee(conn *grpc.ClientConn, tx *types.Transaction, fromAddress types.Address, pubKeyBytes []byte, signer crypto.Signer) (*types.Transaction, error) {
+	if !tx.SignalsReplacement() {
+		return nil, fmt.Errorf("transaction does not signal replace-by-fee")
+	}
+	price, err := GetFeePrice(conn)
+	if err != nil {
+		return nil, err
+	}
+	size, err := tx.SerializeSize()
+	if err != nil {
+		return nil, err
+	}
+	// the replacement pays for its own relay on top of the fee of the replaced one
+	extraFee := price * uint64(size)
+
+	// deep copy tx, leaving the original untouched
+	data, err := tx.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	newTx := &types.Transaction{}
+	if err := newTx.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	changeScript := getScriptAddress(fromAddress)
+	var change *corepb.TxOut
+	for _, txOut := range newTx.Vout {
+		if bytes.Equal(txOut.ScriptPubKey, changeScript) {
+			change = txOut
+		}
+	}
+	if change == nil {
+		return nil, fmt.Errorf("no change output to %s to pay the extra fee", fromAddress)
+	}
+	if change.Value <= extraFee {
+		return nil, fmt.Errorf("change %d is not enough to pay the extra fee %d", change.Value, extraFee)
+	}
+	change.Value -= extraFee
+
+	signedTx, err := SignRawTransaction(newTx, pubKeyBytes, signer)
+	if err != nil {
+		return nil, err
+	}
+	if err := SendRawTransaction(conn, signedTx); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
 // GetRawTransaction get the transaction info of given hash
 func GetRawTransaction(conn *grpc.ClientConn, hash []byte) (*types.Transaction, error) {
 	c := rpcpb.NewTransactionCommandClient(conn)
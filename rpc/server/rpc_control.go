@@ -9,7 +9,9 @@ import (
 	"fmt"
 
 	"github.com/BOXFoundation/boxd/boxd/eventbus"
+	"github.com/BOXFoundation/boxd/consensus/dpos"
 	"github.com/BOXFoundation/boxd/core/pb"
+	"github.com/BOXFoundation/boxd/core/types"
 	"github.com/BOXFoundation/boxd/crypto"
 	"github.com/BOXFoundation/boxd/p2p/pstore"
 	"github.com/BOXFoundation/boxd/rpc/pb"
@@ -169,3 +171,163 @@ func (s *ctlserver) GetBlock(ctx context.Context, req *rpcpb.GetBlockRequest) (*
 		Message: "Internal Error",
 	}, fmt.Errorf("Error converting proto message")
 }
+
+func (s *ctlserver) GenerateBlocks(ctx context.Context, req *rpcpb.GenerateBlocksRequest) (*rpcpb.GenerateBlocksResponse, error) {
+	bus := s.server.GetEventBus()
+	// buffered so that the replier never blocks if the request is cancelled
+	ch := make(chan []*crypto.HashType, 1)
+	errCh := make(chan error, 1)
+	bus.Send(eventbus.TopicGenerateBlocks, req.Count, ch, errCh)
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	resp := &rpcpb.GenerateBlocksResponse{Code: 0, Message: "ok"}
+	for _, hash := range <-ch {
+		resp.Hashes = append(resp.Hashes, hash.String())
+	}
+	if err != nil {
+		resp.Code = -1
+		resp.Message = fmt.Sprintf("Failed to generate blocks: %s", err)
+	}
+	return resp, nil
+}
+
+func (s *ctlserver) GetValidators(ctx context.Context, req *rpcpb.GetValidatorsRequest) (*rpcpb.GetValidatorsResponse, error) {
+	bus := s.server.GetEventBus()
+	// buffered so that the replier never blocks if the request is cancelled
+	ch := make(chan []*dpos.ValidatorStats, 1)
+	errCh := make(chan error, 1)
+	bus.Send(eventbus.TopicGetValidators, ch, errCh)
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return &rpcpb.GetValidatorsResponse{
+			Code:    -1,
+			Message: fmt.Sprintf("Failed to get validators: %s", err),
+		}, nil
+	}
+	resp := &rpcpb.GetValidatorsResponse{Code: 0, Message: "ok"}
+	for _, stats := range <-ch {
+		addr, err := types.NewAddressPubKeyHash(stats.Addr[:])
+		if err != nil {
+			return nil, err
+		}
+		resp.Validators = append(resp.Validators, &rpcpb.Validator{
+			Addr:      addr.String(),
+			Active:    stats.Active,
+			Produced:  stats.Produced,
+			Missed:    stats.Missed,
+			Reverted:  stats.Reverted,
+			Confirmed: stats.Confirmed,
+		})
+	}
+	return resp, nil
+}
+
+func (s *ctlserver) GetCandidates(ctx context.Context, req *rpcpb.GetCandidatesRequest) (*rpcpb.GetCandidatesResponse, error) {
+	bus := s.server.GetEventBus()
+	// buffered so that the replier never blocks if the request is cancelled
+	ch := make(chan []*dpos.Candidate, 1)
+	errCh := make(chan error, 1)
+	bus.Send(eventbus.TopicGetCandidates, ch, errCh)
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return &rpcpb.GetCandidatesResponse{
+			Code:    -1,
+			Message: fmt.Sprintf("Failed to get candidates: %s", err),
+		}, nil
+	}
+	resp := &rpcpb.GetCandidatesResponse{Code: 0, Message: "ok"}
+	for _, candidate := range <-ch {
+		candidateAddr := candidate.Addr()
+		addr, err := types.NewAddressPubKeyHash(candidateAddr[:])
+		if err != nil {
+			return nil, err
+		}
+		resp.Candidates = append(resp.Candidates, &rpcpb.Candidate{
+			Addr:        addr.String(),
+			Votes:       candidate.Votes(),
+			PeerId:      candidate.PeerID(),
+			Endpoint:    candidate.Endpoint(),
+			Description: candidate.Description(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *ctlserver) GetFinalityCertificate(ctx context.Context, req *rpcpb.GetFinalityCertificateRequest) (*rpcpb.GetFinalityCertificateResponse, error) {
+	var hash *crypto.HashType
+	if req.Hash != "" {
+		hash = new(crypto.HashType)
+		if err := hash.SetString(req.Hash); err != nil {
+			return &rpcpb.GetFinalityCertificateResponse{Code: -1, Message: err.Error()}, nil
+		}
+	}
+	bus := s.server.GetEventBus()
+	// buffered so that the replier never blocks if the request is cancelled
+	ch := make(chan *dpos.FinalityCertificate, 1)
+	errCh := make(chan error, 1)
+	bus.Send(eventbus.TopicGetFinalityCertificate, hash, req.Height, ch, errCh)
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return &rpcpb.GetFinalityCertificateResponse{
+			Code:    -1,
+			Message: fmt.Sprintf("Failed to get finality certificate: %s", err),
+		}, nil
+	}
+	cert := <-ch
+	header, err := cert.Header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	periodContext, err := cert.PeriodContext.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetFinalityCertificateResponse{
+		Code:          0,
+		Message:       "ok",
+		Hash:          (&types.Block{Header: cert.Header}).BlockHash().String(),
+		Height:        cert.Height,
+		Header:        header,
+		PeriodContext: periodContext,
+		Signatures:    cert.Signatures,
+	}, nil
+}
+
+func (s *ctlserver) GetUtxoRoot(ctx context.Context, req *rpcpb.GetUtxoRootRequest) (*rpcpb.GetUtxoRootResponse, error) {
+	chainReader := s.server.GetChainReader()
+	if req.Verify {
+		if err := chainReader.VerifyUtxoSet(); err != nil {
+			return &rpcpb.GetUtxoRootResponse{
+				Code:    -1,
+				Message: fmt.Sprintf("Failed to verify utxo set: %s", err),
+			}, err
+		}
+	}
+	block, utxoRoot := chainReader.UtxoRoot()
+	return &rpcpb.GetUtxoRootResponse{
+		Code:      0,
+		Message:   "ok",
+		BlockHash: block.BlockHash().String(),
+		Height:    block.Height,
+		UtxoRoot:  utxoRoot.String(),
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/BOXFoundation/boxd/boxd/service"
 	"github.com/BOXFoundation/boxd/core/chain"
 	"github.com/BOXFoundation/boxd/core/pb"
 	"github.com/BOXFoundation/boxd/script"
@@ -49,12 +50,20 @@ func (s *txServer) GetTransactionPool(ctx context.Context, req *rpcpb.GetTransac
 }
 
 func (s *txServer) GetFeePrice(ctx context.Context, req *rpcpb.GetFeePriceRequest) (*rpcpb.GetFeePriceResponse, error) {
-	return &rpcpb.GetFeePriceResponse{BoxPerByte: 1}, nil
+	return &rpcpb.GetFeePriceResponse{BoxPerByte: s.server.GetTxHandler().GetFeePrice()}, nil
 }
 
 func (s *txServer) ListUtxos(ctx context.Context, req *rpcpb.ListUtxosRequest) (*rpcpb.ListUtxosResponse, error) {
-	bc := s.server.GetChainReader()
-	utxos, err := bc.ListAllUtxos()
+	snapshot, err := s.server.GetChainReader().StateSnapshot()
+	if err != nil {
+		return &rpcpb.ListUtxosResponse{
+			Code:    1,
+			Message: err.Error(),
+		}, err
+	}
+	defer snapshot.Release()
+
+	utxos, err := snapshot.ListAllUtxos()
 	if err != nil {
 		return &rpcpb.ListUtxosResponse{
 			Code:    1,
@@ -74,13 +83,20 @@ func (s *txServer) ListUtxos(ctx context.Context, req *rpcpb.ListUtxosRequest) (
 }
 
 func (s *txServer) GetBalance(ctx context.Context, req *rpcpb.GetBalanceRequest) (*rpcpb.GetBalanceResponse, error) {
+	// balances of all addresses are read from the same snapshot
+	snapshot, err := s.server.GetChainReader().StateSnapshot()
+	if err != nil {
+		return &rpcpb.GetBalanceResponse{Code: -1, Message: err.Error()}, err
+	}
+	defer snapshot.Release()
+
 	balances := make(map[string]uint64)
 	for _, addrStr := range req.Addrs {
 		addr, err := types.NewAddress(addrStr)
 		if err != nil {
 			return &rpcpb.GetBalanceResponse{Code: -1, Message: err.Error()}, err
 		}
-		amount, err := s.getbalance(ctx, addr)
+		amount, err := s.getbalance(ctx, snapshot, addr)
 		if err != nil {
 			return &rpcpb.GetBalanceResponse{Code: -1, Message: err.Error()}, err
 		}
@@ -98,6 +114,12 @@ func (s *txServer) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBalan
 			Message: err.Error(),
 		}, err
 	}
+	snapshot, err := s.server.GetChainReader().StateSnapshot()
+	if err != nil {
+		return &rpcpb.GetTokenBalanceResponse{Code: -1, Message: err.Error()}, err
+	}
+	defer snapshot.Release()
+
 	for _, addrStr := range req.Addrs {
 		addr, err := types.NewAddress(addrStr)
 		if err != nil {
@@ -106,7 +128,7 @@ func (s *txServer) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBalan
 				Message: err.Error(),
 			}, err
 		}
-		amount, err := s.getTokenBalance(ctx, addr, token)
+		amount, err := s.getTokenBalance(ctx, snapshot, addr, token)
 		if err != nil {
 			return &rpcpb.GetTokenBalanceResponse{Code: -1, Message: err.Error()}, err
 		}
@@ -119,8 +141,8 @@ func (s *txServer) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBalan
 	}, nil
 }
 
-func (s *txServer) getbalance(ctx context.Context, addr types.Address) (uint64, error) {
-	utxos, err := s.server.GetChainReader().LoadUtxoByAddress(addr)
+func (s *txServer) getbalance(ctx context.Context, snapshot service.ChainSnapshot, addr types.Address) (uint64, error) {
+	utxos, err := snapshot.LoadUtxoByAddress(addr)
 	if err != nil {
 		return 0, err
 	}
@@ -131,8 +153,8 @@ func (s *txServer) getbalance(ctx context.Context, addr types.Address) (uint64,
 	return amount, nil
 }
 
-func (s *txServer) getTokenBalance(ctx context.Context, addr types.Address, token *types.OutPoint) (uint64, error) {
-	utxos, err := s.server.GetChainReader().LoadUtxoByAddress(addr)
+func (s *txServer) getTokenBalance(ctx context.Context, snapshot service.ChainSnapshot, addr types.Address, token *types.OutPoint) (uint64, error) {
+	utxos, err := snapshot.LoadUtxoByAddress(addr)
 	if err != nil {
 		return 0, err
 	}
@@ -166,29 +188,42 @@ func (s *txServer) getTokenBalance(ctx context.Context, addr types.Address, toke
 }
 
 func (s *txServer) FundTransaction(ctx context.Context, req *rpcpb.FundTransactionRequest) (*rpcpb.ListUtxosResponse, error) {
-	bc := s.server.GetChainReader()
 	addr, err := types.NewAddress(req.Addr)
-	payToPubKeyHashScript := *script.PayToPubKeyHashScript(addr.Hash())
 	if err != nil {
 		return &rpcpb.ListUtxosResponse{Code: 1, Message: err.Error()}, nil
 	}
-	utxos, err := bc.LoadUtxoByAddress(addr)
+	snapshot, err := s.server.GetChainReader().StateSnapshot()
 	if err != nil {
 		return &rpcpb.ListUtxosResponse{Code: 1, Message: err.Error()}, nil
 	}
+	defer snapshot.Release()
 
-	nextHeight := s.server.GetChainReader().GetBlockHeight() + 1
+	addrScript := *script.PayToAddressScript(addr)
+	utxos, err := snapshot.LoadUtxoByAddress(addr)
+	if err != nil {
+		return &rpcpb.ListUtxosResponse{Code: 1, Message: err.Error()}, nil
+	}
 
-	// apply mempool txs as if they were mined into a block with 0 confirmation
+	_, height := snapshot.Tail()
+	nextHeight := height + 1
+
+	// apply mempool txs as if they were mined into a block with 0 confirmation.
+	// The mempool is read after the snapshot is taken, so txs based on blocks
+	// connected since are skipped to keep utxos consistent with the snapshot.
+	// Utxos spent by txs mined in those blocks, which have left the mempool,
+	// may still be returned, and fail as inputs of the funded tx.
 	utxoSet := chain.NewUtxoSetFromMap(utxos)
-	memPoolTxs := s.server.GetTxHandler().GetTransactionsInPool()
+	memPoolTxs, err := txsBasedOnSnapshot(snapshot, s.server.GetTxHandler().GetTransactionsInPool())
+	if err != nil {
+		return &rpcpb.ListUtxosResponse{Code: 1, Message: err.Error()}, nil
+	}
 	// Note: we add utxo first and spend them later to maintain tx topological order within mempool. Since memPoolTxs may not
 	// be topologically ordered, if tx1 spends tx2 but tx1 comes after tx2, tx1's output is mistakenly marked as unspent
 	// Add utxos first
 	for _, tx := range memPoolTxs {
 		for txOutIdx, txOut := range tx.Vout {
 			// utxo for this address
-			if util.IsPrefixed(txOut.ScriptPubKey, payToPubKeyHashScript) {
+			if util.IsPrefixed(txOut.ScriptPubKey, addrScript) {
 				if err := utxoSet.AddUtxo(tx, uint32(txOutIdx), nextHeight); err != nil {
 					return &rpcpb.ListUtxosResponse{Code: 1, Message: err.Error()}, nil
 				}
@@ -251,6 +286,47 @@ func (s *txServer) FundTransaction(ctx context.Context, req *rpcpb.FundTransacti
 	return res, nil
 }
 
+// txsBasedOnSnapshot returns mempool txs spending only utxos in the snapshot or
+// outputs of other such txs
+func txsBasedOnSnapshot(snapshot service.ChainSnapshot, txs []*types.Transaction) ([]*types.Transaction, error) {
+	based := make(map[crypto.HashType]*types.Transaction, len(txs))
+	for _, tx := range txs {
+		txHash, err := tx.TxHash()
+		if err != nil {
+			return nil, err
+		}
+		based[*txHash] = tx
+	}
+	// drop txs with inputs not found until none is dropped, so that children
+	// of txs dropped are dropped too
+	for dropped := true; dropped; {
+		dropped = false
+		for txHash, tx := range based {
+			for _, txIn := range tx.Vin {
+				if _, ok := based[txIn.PrevOutPoint.Hash]; ok {
+					continue
+				}
+				utxo, err := snapshot.LoadUtxo(txIn.PrevOutPoint)
+				if err != nil {
+					return nil, err
+				}
+				if utxo == nil {
+					delete(based, txHash)
+					dropped = true
+					break
+				}
+			}
+		}
+	}
+	result := make([]*types.Transaction, 0, len(based))
+	for _, tx := range txs {
+		if txHash, _ := tx.TxHash(); based[*txHash] != nil {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
 func getTokenInfo(outpoint types.OutPoint, wrap *types.UtxoWrap) (types.OutPoint, uint64, bool) {
 	s := script.NewScriptFromBytes(wrap.Output.ScriptPubKey)
 	if issueParam, err := s.GetIssueParams(); err == nil {
@@ -29,8 +29,8 @@ type wltServer struct {
 }
 
 func (s *wltServer) ListTransactions(ctx context.Context, req *rpcpb.ListTransactionsRequest) (*rpcpb.ListTransactionsResponse, error) {
-	addr := &types.AddressPubKeyHash{}
-	if err := addr.SetString(req.Addr); err != nil {
+	addr, err := types.NewAddress(req.Addr)
+	if err != nil {
 		return &rpcpb.ListTransactionsResponse{Code: -1, Message: "Invalid Address"}, err
 	}
 	logger.Infof("Search Transaction related to address: %s", addr.String())
@@ -21,7 +21,14 @@ var (
 	ErrScriptEqualVerify         = errors.New("ScriptErrEqualVerify")
 	ErrScriptSignatureVerifyFail = errors.New("ScriptErrSignatureVerifyFail")
 	ErrInputIndexOutOfBound      = errors.New("input index out of bound")
-	ErrAddressNotApplicable      = errors.New("Address only applies to p2pkh and token txs")
+	ErrAddressNotApplicable      = errors.New("Address only applies to p2pkh, p2sh and token txs")
+	ErrNotPubKeyHashSigScript    = errors.New("Script is not a p2pkh signature script")
+
+	// multisig.go
+	ErrInvalidMultisigParams = errors.New("Invalid multisig parameters")
+	ErrNotMultisigScript     = errors.New("Script is not a standard multisig script")
+	ErrSigScriptNotPushOnly  = errors.New("Signature script of p2sh must only push data")
+	ErrEmptySigScript        = errors.New("Signature script is empty")
 
 	// stack.go
 	ErrFinalStackEmpty       = errors.New("Final stack empty")
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package script
+
+import (
+	"bytes"
+
+	"github.com/BOXFoundation/boxd/core/types"
+)
+
+// MaxMultisigPubKeys is the maximum number of public keys in a standard multisig script,
+// bounded by the largest small integer opcode OP_16
+const MaxMultisigPubKeys = 16
+
+// MultisigScript creates a standard m-of-n multisig script, usually used as a p2sh redeem script.
+func MultisigScript(m int, pubKeys [][]byte) (*Script, error) {
+	n := len(pubKeys)
+	if m < 1 || m > n || n > MaxMultisigPubKeys {
+		return nil, ErrInvalidMultisigParams
+	}
+	// m <Public Key 1> ... <Public Key n> n OP_CHECKMULTISIG
+	script := NewScript().AddOpCode(smallIntOpCode(m))
+	for _, pubKey := range pubKeys {
+		script.AddOperand(pubKey)
+	}
+	return script.AddOpCode(smallIntOpCode(n)).AddOpCode(OPCHECKMULTISIG), nil
+}
+
+// IsMultisig returns if the script is a standard multisig script
+func (s *Script) IsMultisig() bool {
+	_, _, err := s.GetMultisigParams()
+	return err == nil
+}
+
+// GetMultisigParams returns the number of required signatures and public keys of a multisig script
+func (s *Script) GetMultisigParams() (int, [][]byte, error) {
+	// m <Public Key 1> ... <Public Key n> n OP_CHECKMULTISIG
+	r := s.parse()
+	if len(r) < 4 || !isSmallIntOpCode(r[0]) || !isSmallIntOpCode(r[len(r)-2]) ||
+		r[len(r)-1] != OPCHECKMULTISIG {
+		return 0, nil, ErrNotMultisigScript
+	}
+	m := smallIntValue(r[0].(OpCode))
+	n := smallIntValue(r[len(r)-2].(OpCode))
+	if n != len(r)-3 || m > n {
+		return 0, nil, ErrNotMultisigScript
+	}
+	pubKeys := make([][]byte, 0, n)
+	for _, e := range r[1 : len(r)-2] {
+		pubKey, ok := e.(Operand)
+		if !ok {
+			return 0, nil, ErrNotMultisigScript
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return m, pubKeys, nil
+}
+
+// ScriptHashSignatureScript creates a script to unlock a p2sh utxo.
+func ScriptHashSignatureScript(sigs [][]byte, redeemScript []byte) *Script {
+	// <signature>... <serialized redeemScript>
+	script := NewScript()
+	for _, sig := range sigs {
+		script.AddOperand(sig)
+	}
+	return script.AddOperand(redeemScript)
+}
+
+// GetScriptHashSigParams returns signatures and redeem script embedded in a p2sh signature script
+func (s *Script) GetScriptHashSigParams() ([][]byte, *Script, error) {
+	r := s.parse()
+	if len(r) == 0 {
+		return nil, nil, ErrEmptySigScript
+	}
+	operands := make([][]byte, 0, len(r))
+	for _, e := range r {
+		switch v := e.(type) {
+		case Operand:
+			operands = append(operands, v)
+		case error:
+			return nil, nil, v
+		default:
+			return nil, nil, ErrSigScriptNotPushOnly
+		}
+	}
+	return operands[:len(operands)-1], NewScriptFromBytes(operands[len(operands)-1]), nil
+}
+
+// AddMultisigSignature adds sig to the p2sh signature script of tx.Vin[txInIdx], which spends
+// a multisig redeemScript. Signatures are kept in the order of their public keys as
+// OP_CHECKMULTISIG requires; those not matching any public key are dropped.
+// It returns the new signature script and if it carries enough signatures.
+func AddMultisigSignature(sig []byte, redeemScript *Script, tx *types.Transaction, txInIdx int) (*Script, bool, error) {
+	if txInIdx >= len(tx.Vin) {
+		return nil, false, ErrInputIndexOutOfBound
+	}
+	m, pubKeys, err := redeemScript.GetMultisigParams()
+	if err != nil {
+		return nil, false, err
+	}
+	sigs := [][]byte{sig}
+	if scriptSig := NewScriptFromBytes(tx.Vin[txInIdx].ScriptSig); len(*scriptSig) > 0 {
+		existingSigs, existingRedeemScript, err := scriptSig.GetScriptHashSigParams()
+		if err != nil {
+			return nil, false, err
+		}
+		if !bytes.Equal(*existingRedeemScript, *redeemScript) {
+			return nil, false, ErrInvalidMultisigParams
+		}
+		sigs = append(existingSigs, sig)
+	}
+
+	orderedSigs := make([][]byte, 0, m)
+	for _, pubKey := range pubKeys {
+		for _, s := range sigs {
+			if verifySig(s, pubKey, *redeemScript, tx, txInIdx) {
+				orderedSigs = append(orderedSigs, s)
+				break
+			}
+		}
+		if len(orderedSigs) == m {
+			break
+		}
+	}
+	return ScriptHashSignatureScript(orderedSigs, *redeemScript), len(orderedSigs) == m, nil
+}
+
+// smallIntOpCode returns the opcode pushing small integer n, 1 <= n <= 16
+func smallIntOpCode(n int) OpCode {
+	return OpCode(int(OP1) + n - 1)
+}
+
+// smallIntValue returns the small integer pushed by opcode OP_1 to OP_16
+func smallIntValue(opCode OpCode) int {
+	return int(opCode) - int(OP1) + 1
+}
+
+// is i an opcode pushing small integer 1 to 16
+func isSmallIntOpCode(i interface{}) bool {
+	opCode, ok := i.(OpCode)
+	return ok && opCode >= OP1 && opCode <= OP16
+}
@@ -29,6 +29,19 @@ func PayToPubKeyHashScript(pubKeyHash []byte) *Script {
 	return NewScript().AddOpCode(OPDUP).AddOpCode(OPHASH160).AddOperand(pubKeyHash).AddOpCode(OPEQUALVERIFY).AddOpCode(OPCHECKSIG)
 }
 
+// PayToScriptHashScript creates a script to lock a transaction output to the specified script hash.
+func PayToScriptHashScript(scriptHash []byte) *Script {
+	return NewScript().AddOpCode(OPHASH160).AddOperand(scriptHash).AddOpCode(OPEQUAL)
+}
+
+// PayToAddressScript creates a standard script to lock a transaction output to the specified address.
+func PayToAddressScript(addr types.Address) *Script {
+	if _, ok := addr.(*types.AddressScriptHash); ok {
+		return PayToScriptHashScript(addr.Hash())
+	}
+	return PayToPubKeyHashScript(addr.Hash())
+}
+
 // SignatureScript creates a script to unlock a utxo.
 func SignatureScript(sig *crypto.Signature, pubKey []byte) *Script {
 	return NewScript().AddOperand(sig.Serialize()).AddOperand(pubKey)
@@ -104,18 +117,17 @@ func Validate(scriptSig, scriptPubKey *Script, tx *types.Transaction, txInIdx in
 	}
 
 	// Handle p2sh
-	// scriptSig: signature <serialized redeemScript>
-	//
-
-	// First operand is signature
-	_, sig, newPc, _ := scriptSig.parseNextOp(0)
-	newScriptSig := NewScript().AddOperand(sig)
-
-	// Second operand is serialized redeem script
-	_, redeemScriptBytes, _, _ := scriptSig.parseNextOp(newPc)
-	redeemScript := NewScriptFromBytes(redeemScriptBytes)
+	// scriptSig: <signature>... <serialized redeemScript>
+	sigs, redeemScript, err := scriptSig.GetScriptHashSigParams()
+	if err != nil {
+		return err
+	}
+	newScriptSig := NewScript()
+	for _, sig := range sigs {
+		newScriptSig.AddOperand(sig)
+	}
 
-	// signature becomes the new scriptSig, redeemScript becomes the new scriptPubKey
+	// signatures become the new scriptSig, redeemScript becomes the new scriptPubKey
 	catScript = NewScript().AddScript(newScriptSig).AddOpCode(OPCODESEPARATOR).AddScript(redeemScript)
 	return catScript.evaluate(tx, txInIdx)
 }
@@ -390,7 +402,7 @@ func (s *Script) execOp(opCode OpCode, pushData Operand, tx *types.Transaction,
 		} else {
 			stack.push(operandFalse)
 		}
-		if opCode == OPCHECKSIGVERIFY {
+		if opCode == OPCHECKMULTISIGVERIFY {
 			if isVerified {
 				stack.pop()
 			} else {
@@ -524,6 +536,23 @@ func (s *Script) IsPayToScriptHash() bool {
 	return len(r) == 3 && reflect.DeepEqual(r[0], OPHASH160) && isOperandOfLen(r[1], 20) && reflect.DeepEqual(r[2], OPEQUAL)
 }
 
+// GetSigScriptPubKey returns the public key in a p2pkh signature script
+func (s *Script) GetSigScriptPubKey() ([]byte, error) {
+	// <signature> <public key>
+	r := s.parse()
+	if len(r) != 2 {
+		return nil, ErrNotPubKeyHashSigScript
+	}
+	if _, ok := r[0].(Operand); !ok {
+		return nil, ErrNotPubKeyHashSigScript
+	}
+	pubKey, ok := r[1].(Operand)
+	if !ok {
+		return nil, ErrNotPubKeyHashSigScript
+	}
+	return pubKey, nil
+}
+
 // is i of type Operand and of specified length
 func isOperandOfLen(i interface{}, length int) bool {
 	operand, ok := i.(Operand)
@@ -546,12 +575,21 @@ func (s *Script) getNthOp(pcStart, n int) (OpCode, Operand, int /* pc */, error)
 
 // ExtractAddress returns address within the script
 func (s *Script) ExtractAddress() (types.Address, error) {
-	// only applies to p2pkh & token txs
-	if !s.IsPayToPubKeyHash() && !s.IsTokenIssue() && !s.IsTokenTransfer() {
+	if s.IsPayToScriptHash() {
+		// p2sh scriptPubKey: OPHASH160 <scriptHash> OPEQUAL
+		_, scriptHash, _, err := s.getNthOp(0, 1)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewAddressScriptHashFromHash(scriptHash)
+	}
+
+	// only applies to p2pkh, p2sh, token & vote txs
+	if !s.IsPayToPubKeyHash() && !s.IsTokenIssue() && !s.IsTokenTransfer() && !s.IsVote() && !s.IsUnbond() {
 		return nil, ErrAddressNotApplicable
 	}
 
-	// p2pkh scriptPubKey: OPDUP OPHASH160 <pubKeyHash> OPEQUALVERIFY OPCHECKSIG [token parameters]
+	// p2pkh scriptPubKey: OPDUP OPHASH160 <pubKeyHash> OPEQUALVERIFY OPCHECKSIG [token or vote parameters]
 	_, pubKeyHash, _, err := s.getNthOp(0, 2)
 	if err != nil {
 		return nil, err
@@ -579,3 +617,32 @@ func (s *Script) GetSigOpCount() int {
 
 	return numSigs
 }
+
+// GetPreciseSigOpCount returns number of signature operations in a script like GetSigOpCount,
+// but counts a multisig operation as the number of its public keys if preceded by it,
+// or MaxMultisigPubKeys otherwise. It is used for redeem scripts, which are known when spent.
+func (s *Script) GetPreciseSigOpCount() int {
+	numSigs := 0
+
+	var prev interface{}
+	elements := s.parse()
+	for _, e := range elements {
+		switch v := e.(type) {
+		case OpCode:
+			if v == OPCHECKSIG || v == OPCHECKSIGVERIFY {
+				numSigs++
+			} else if v == OPCHECKMULTISIG || v == OPCHECKMULTISIGVERIFY {
+				if isSmallIntOpCode(prev) {
+					numSigs += smallIntValue(prev.(OpCode))
+				} else {
+					numSigs += MaxMultisigPubKeys
+				}
+			}
+		default:
+			// Not a opcode
+		}
+		prev = e
+	}
+
+	return numSigs
+}
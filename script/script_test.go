@@ -120,6 +120,16 @@ func TestP2PKH(t *testing.T) {
 	ensure.Nil(t, err)
 }
 
+func TestGetSigScriptPubKey(t *testing.T) {
+	scriptSig, _, _ := genP2PKHScript(false)
+	pubKey, err := scriptSig.GetSigScriptPubKey()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, pubKey, testPubKeyBytes)
+
+	_, err = NewScriptFromBytes(p2SHScriptBytes).GetSigScriptPubKey()
+	ensure.DeepEqual(t, err, ErrNotPubKeyHashSigScript)
+}
+
 func genP2SHScript() (*Script, *Script) {
 	// redeem script
 	redeemScript := NewScript().AddOperand(testPubKeyBytes).AddOpCode(OPCHECKSIG)
@@ -197,6 +207,80 @@ func TestMultisig(t *testing.T) {
 	}
 }
 
+// test m-of-n multisig redeem script wrapped in p2sh, signed by keyholders one by one
+func TestP2SHMultisig(t *testing.T) {
+	privKeys := []*crypto.PrivateKey{testPrivKey}
+	pubKeys := [][]byte{testPubKeyBytes}
+	for i := 0; i < 2; i++ {
+		privKey, pubKey, _ := crypto.NewKeyPair()
+		privKeys = append(privKeys, privKey)
+		pubKeys = append(pubKeys, pubKey.Serialize())
+	}
+	redeemScript, err := MultisigScript(2, pubKeys)
+	ensure.Nil(t, err)
+	m, gotPubKeys, err := redeemScript.GetMultisigParams()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, m, 2)
+	ensure.DeepEqual(t, gotPubKeys, pubKeys)
+	ensure.DeepEqual(t, redeemScript.GetSigOpCount(), 1)
+	ensure.DeepEqual(t, redeemScript.GetPreciseSigOpCount(), 3)
+	addr, _ := types.NewAddressScriptHash(*redeemScript)
+	scriptPubKey := PayToAddressScript(addr)
+	ensure.True(t, scriptPubKey.IsPayToScriptHash())
+
+	hash, _ := CalcTxHashForSig(*redeemScript, tx, 0)
+	defer func() { tx.Vin[0].ScriptSig = []byte{} }()
+
+	// sign in reverse key order, the signature script keeps key order
+	sig, _ := crypto.Sign(privKeys[2], hash)
+	scriptSig, complete, err := AddMultisigSignature(sig.Serialize(), redeemScript, tx, 0)
+	ensure.Nil(t, err)
+	ensure.False(t, complete)
+	ensure.NotNil(t, Validate(scriptSig, scriptPubKey, tx, 0))
+	tx.Vin[0].ScriptSig = *scriptSig
+
+	// signature of a foreign key is dropped
+	foreignPrivKey, _, _ := crypto.NewKeyPair()
+	sig, _ = crypto.Sign(foreignPrivKey, hash)
+	scriptSig, complete, err = AddMultisigSignature(sig.Serialize(), redeemScript, tx, 0)
+	ensure.Nil(t, err)
+	ensure.False(t, complete)
+	ensure.DeepEqual(t, []byte(*scriptSig), tx.Vin[0].ScriptSig)
+
+	sig, _ = crypto.Sign(privKeys[0], hash)
+	scriptSig, complete, err = AddMultisigSignature(sig.Serialize(), redeemScript, tx, 0)
+	ensure.Nil(t, err)
+	ensure.True(t, complete)
+	ensure.Nil(t, Validate(scriptSig, scriptPubKey, tx, 0))
+
+	// wrong redeem script
+	otherRedeemScript, _ := MultisigScript(1, pubKeys)
+	tx.Vin[0].ScriptSig = *scriptSig
+	_, _, err = AddMultisigSignature(sig.Serialize(), otherRedeemScript, tx, 0)
+	ensure.NotNil(t, err)
+	ensure.NotNil(t, Validate(ScriptHashSignatureScript(nil, *otherRedeemScript), scriptPubKey, tx, 0))
+}
+
+func TestMultisigScript(t *testing.T) {
+	_, err := MultisigScript(0, [][]byte{testPubKeyBytes})
+	ensure.NotNil(t, err)
+	_, err = MultisigScript(2, [][]byte{testPubKeyBytes})
+	ensure.NotNil(t, err)
+	pubKeys := make([][]byte, MaxMultisigPubKeys+1)
+	for i := range pubKeys {
+		pubKeys[i] = testPubKeyBytes
+	}
+	_, err = MultisigScript(1, pubKeys)
+	ensure.NotNil(t, err)
+
+	script, err := MultisigScript(MaxMultisigPubKeys, pubKeys[:MaxMultisigPubKeys])
+	ensure.Nil(t, err)
+	ensure.True(t, script.IsMultisig())
+
+	_, scriptPubKey, _ := genP2PKHScript(false)
+	ensure.False(t, scriptPubKey.IsMultisig())
+}
+
 func TestDisasm(t *testing.T) {
 	script := NewScript().AddOpCode(OP8).AddOpCode(OP6).AddOpCode(OPADD).AddOpCode(OP14).AddOpCode(OPEQUAL)
 	ensure.DeepEqual(t, script.Disasm(), "OP_8 OP_6 OP_ADD OP_14 OP_EQUAL")
@@ -262,15 +346,20 @@ func TestExtractAddress(t *testing.T) {
 	ensure.Nil(t, err)
 	expectedAddr, _ := types.NewAddressFromPubKey(testPubKey)
 	ensure.DeepEqual(t, expectedAddr, addr)
+	ensure.DeepEqual(t, PayToAddressScript(addr), scriptPubKey)
 
 	_, scriptPubKey, _ = genP2PKHScript(true)
 	_, err = scriptPubKey.ExtractAddress()
 	ensure.NotNil(t, err)
 
 	// p2sh
-	_, scriptPubKey = genP2SHScript()
-	_, err = scriptPubKey.ExtractAddress()
-	ensure.NotNil(t, err)
+	scriptSig, scriptPubKey := genP2SHScript()
+	addr, err = scriptPubKey.ExtractAddress()
+	ensure.Nil(t, err)
+	_, redeemScript, _ := scriptSig.GetScriptHashSigParams()
+	expectedScriptHashAddr, _ := types.NewAddressScriptHash(*redeemScript)
+	ensure.DeepEqual(t, expectedScriptHashAddr, addr)
+	ensure.DeepEqual(t, PayToAddressScript(addr), scriptPubKey)
 }
 
 func TestGetNthOp(t *testing.T) {
@@ -0,0 +1,89 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package script
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/BOXFoundation/boxd/core/types"
+)
+
+var (
+	// VoteCandidateKey is the key for writing the candidate voted for onchain
+	VoteCandidateKey = []byte("VoteCandidate")
+
+	// UnbondKey is the key marking coins unbonded from votes onchain
+	UnbondKey = []byte("Unbond")
+)
+
+// VoteScript creates a script to lock coins of the specified address as votes for the candidate.
+func VoteScript(pubKeyHash []byte, candidate types.AddressHash) *Script {
+	// Regular p2pkh
+	script := PayToPubKeyHashScript(pubKeyHash)
+	// Append parameters to p2pkh:
+	// VoteCandidateKey OP_DROP <candidate address hash> OP_DROP
+	return script.AddOperand(VoteCandidateKey).AddOpCode(OPDROP).AddOperand(candidate[:]).AddOpCode(OPDROP)
+}
+
+// GetVoteCandidate returns the candidate voted for embedded in the script
+func (s *Script) GetVoteCandidate() (*types.AddressHash, error) {
+	// OPDUP OPHASH160 pubKeyHash OPEQUALVERIFY OPCHECKSIG
+	// VoteCandidateKey OP_DROP <candidate address hash> OP_DROP
+	_, operand, _, err := s.getNthOp(0, 7)
+	if err != nil {
+		return nil, err
+	}
+	candidate := new(types.AddressHash)
+	if numOfBytesRead := copy(candidate[:], operand); numOfBytesRead != len(candidate) {
+		return nil, fmt.Errorf("candidate address size not %d: %d", len(candidate), numOfBytesRead)
+	}
+	return candidate, nil
+}
+
+// UnbondScript creates a script to pay coins unbonded from votes to the specified address,
+// which are only spendable after unbonding period.
+func UnbondScript(pubKeyHash []byte) *Script {
+	// Regular p2pkh
+	script := PayToPubKeyHashScript(pubKeyHash)
+	// Append marker to p2pkh:
+	// UnbondKey OP_DROP
+	return script.AddOperand(UnbondKey).AddOpCode(OPDROP)
+}
+
+// IsVote returns if the script locks coins as votes
+func (s *Script) IsVote() bool {
+	// two parts: p2pkh + vote parameters
+	if len(*s) < p2PKHScriptLen {
+		return false
+	}
+
+	p2PKHSubScript := NewScriptFromBytes((*s)[:p2PKHScriptLen])
+	if !p2PKHSubScript.IsPayToPubKeyHash() {
+		return false
+	}
+
+	paramsSubScript := NewScriptFromBytes((*s)[p2PKHScriptLen:])
+	r := paramsSubScript.parse()
+	return len(r) == 4 && isOperandOfValue(r[0], VoteCandidateKey) && reflect.DeepEqual(r[1], OPDROP) &&
+		isOperandOfLen(r[2], len(types.AddressHash{})) && reflect.DeepEqual(r[3], OPDROP)
+}
+
+// IsUnbond returns if the script pays coins unbonded from votes
+func (s *Script) IsUnbond() bool {
+	// two parts: p2pkh + unbond marker
+	if len(*s) < p2PKHScriptLen {
+		return false
+	}
+
+	p2PKHSubScript := NewScriptFromBytes((*s)[:p2PKHScriptLen])
+	if !p2PKHSubScript.IsPayToPubKeyHash() {
+		return false
+	}
+
+	paramsSubScript := NewScriptFromBytes((*s)[p2PKHScriptLen:])
+	r := paramsSubScript.parse()
+	return len(r) == 2 && isOperandOfValue(r[0], UnbondKey) && reflect.DeepEqual(r[1], OPDROP)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package script
+
+import (
+	"testing"
+
+	"github.com/BOXFoundation/boxd/core/types"
+	"github.com/facebookgo/ensure"
+)
+
+func TestVoteScript(t *testing.T) {
+	candidate := types.AddressHash{0x01, 0x02, 0x03}
+	script := VoteScript(testPubKeyHash, candidate)
+
+	ensure.True(t, script.IsVote())
+	ensure.False(t, script.IsUnbond())
+	ensure.False(t, script.IsTokenIssue())
+	ensure.True(t, script.P2PKHScriptPrefix().IsPayToPubKeyHash())
+
+	candidate2, err := script.GetVoteCandidate()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, *candidate2, candidate)
+
+	_, err = script.ExtractAddress()
+	ensure.Nil(t, err)
+}
+
+func TestUnbondScript(t *testing.T) {
+	script := UnbondScript(testPubKeyHash)
+
+	ensure.True(t, script.IsUnbond())
+	ensure.False(t, script.IsVote())
+	ensure.False(t, PayToPubKeyHashScript(testPubKeyHash).IsUnbond())
+	ensure.True(t, script.P2PKHScriptPrefix().IsPayToPubKeyHash())
+
+	_, err := script.ExtractAddress()
+	ensure.Nil(t, err)
+}
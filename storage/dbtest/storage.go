@@ -729,3 +729,144 @@ func StorageIterKeysWithPrefixCancel(t *testing.T, s storage.Operations) func(*t
 		ensure.True(t, i < 100)
 	}
 }
+
+// StorageIterator is a dbtest helper method
+func StorageIterator(t *testing.T, s storage.Operations) func(*testing.T, storage.Operations) {
+	for i := 0; i < 1000; i++ {
+		k := []byte(fmt.Sprintf("key-%04d", i))
+		v := []byte(fmt.Sprintf("value-%d", i))
+		ensure.Nil(t, s.Put(k, v))
+	}
+
+	return func(t *testing.T, s storage.Operations) {
+		iter := s.NewIterator(nil)
+		defer iter.Release()
+
+		ensure.False(t, iter.Prev())
+		var i = 0
+		for iter.Next() {
+			ensure.DeepEqual(t, iter.Key(), []byte(fmt.Sprintf("key-%04d", i)))
+			ensure.DeepEqual(t, iter.Value(), []byte(fmt.Sprintf("value-%d", i)))
+			i++
+		}
+		ensure.Nil(t, iter.Error())
+		ensure.DeepEqual(t, i, 1000)
+		ensure.True(t, iter.Prev())
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0999"))
+
+		ranged := s.NewIterator(&storage.Range{Start: []byte("key-0100"), Limit: []byte("key-0200")})
+		defer ranged.Release()
+
+		ensure.True(t, ranged.First())
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0100"))
+		ensure.True(t, ranged.Last())
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0199"))
+		ensure.True(t, ranged.Prev())
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0198"))
+		ensure.True(t, ranged.Seek([]byte("key-0050")))
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0100"))
+		ensure.True(t, ranged.Seek([]byte("key-0150x")))
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0151"))
+		ensure.DeepEqual(t, ranged.Value(), []byte("value-151"))
+		ensure.False(t, ranged.Seek([]byte("key-0200")))
+		ensure.True(t, ranged.Key() == nil)
+		ensure.True(t, ranged.Prev())
+		ensure.DeepEqual(t, ranged.Key(), []byte("key-0199"))
+
+		prefixed := s.NewIterator(storage.BytesPrefix([]byte("key-09")))
+		defer prefixed.Release()
+
+		i = 0
+		for prefixed.Next() {
+			ensure.True(t, bytes.HasPrefix(prefixed.Key(), []byte("key-09")))
+			i++
+		}
+		ensure.DeepEqual(t, i, 100)
+	}
+}
+
+// StorageReverseIterator is a dbtest helper method
+func StorageReverseIterator(t *testing.T, s storage.Operations) func(*testing.T, storage.Operations) {
+	for i := 0; i < 1000; i++ {
+		k := []byte(fmt.Sprintf("key-%04d", i))
+		v := []byte(fmt.Sprintf("value-%d", i))
+		ensure.Nil(t, s.Put(k, v))
+	}
+
+	return func(t *testing.T, s storage.Operations) {
+		iter := storage.NewReverseIterator(s.NewIterator(storage.BytesPrefix([]byte("key-01"))))
+		defer iter.Release()
+
+		var i = 199
+		for iter.Next() {
+			ensure.DeepEqual(t, iter.Key(), []byte(fmt.Sprintf("key-%04d", i)))
+			ensure.DeepEqual(t, iter.Value(), []byte(fmt.Sprintf("value-%d", i)))
+			i--
+		}
+		ensure.Nil(t, iter.Error())
+		ensure.DeepEqual(t, i, 99)
+
+		ensure.True(t, iter.Seek([]byte("key-0150x")))
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0150"))
+		ensure.True(t, iter.Next())
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0149"))
+		ensure.True(t, iter.Prev())
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0150"))
+		ensure.True(t, iter.Seek([]byte("key-0120")))
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0120"))
+		ensure.True(t, iter.Seek([]byte("key-1")))
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0199"))
+		ensure.False(t, iter.Seek([]byte("key-0")))
+		ensure.True(t, iter.Last())
+		ensure.DeepEqual(t, iter.Key(), []byte("key-0100"))
+	}
+}
+
+// StorageSnapshot is a dbtest helper method
+func StorageSnapshot(t *testing.T, s storage.Table) {
+	for i := 0; i < 100; i++ {
+		k := []byte(fmt.Sprintf("key-%04d", i))
+		v := []byte(fmt.Sprintf("value-%d", i))
+		ensure.Nil(t, s.Put(k, v))
+	}
+
+	snapshot, err := s.Snapshot()
+	ensure.Nil(t, err)
+	defer snapshot.Release()
+
+	// writes after the snapshot is taken
+	ensure.Nil(t, s.Put([]byte("key-0000"), []byte("changed")))
+	ensure.Nil(t, s.Del([]byte("key-0001")))
+	batch := s.NewBatch()
+	defer batch.Close()
+	batch.Put([]byte("key-0100"), []byte("value-100"))
+	batch.Del([]byte("key-0002"))
+	ensure.Nil(t, batch.Write())
+
+	value, err := snapshot.Get([]byte("key-0000"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, value, []byte("value-0"))
+	has, err := snapshot.Has([]byte("key-0001"))
+	ensure.Nil(t, err)
+	ensure.True(t, has)
+	has, err = snapshot.Has([]byte("key-0100"))
+	ensure.Nil(t, err)
+	ensure.False(t, has)
+	ensure.DeepEqual(t, len(snapshot.Keys()), 100)
+	ensure.DeepEqual(t, len(snapshot.KeysWithPrefix([]byte("key-000"))), 10)
+
+	iter := snapshot.NewIterator(nil)
+	defer iter.Release()
+	var i = 0
+	for iter.Next() {
+		ensure.DeepEqual(t, iter.Key(), []byte(fmt.Sprintf("key-%04d", i)))
+		ensure.DeepEqual(t, iter.Value(), []byte(fmt.Sprintf("value-%d", i)))
+		i++
+	}
+	ensure.DeepEqual(t, i, 100)
+
+	value, err = s.Get([]byte("key-0000"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, value, []byte("changed"))
+	ensure.DeepEqual(t, len(s.Keys()), 99)
+}
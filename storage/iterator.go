@@ -0,0 +1,143 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package storage
+
+import "bytes"
+
+// Range defines the key range [Start, Limit) to iterate over. Iteration starts
+// from the first key if Start is nil, and ends at the last key if Limit is nil.
+type Range struct {
+	Start []byte
+	Limit []byte
+}
+
+// BytesPrefix returns the range of all keys with the prefix
+func BytesPrefix(prefix []byte) *Range {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return &Range{Start: prefix, Limit: limit}
+}
+
+// Contains checks if the key is within the range
+func (r *Range) Contains(key []byte) bool {
+	if r == nil {
+		return true
+	}
+	return (r.Start == nil || bytes.Compare(key, r.Start) >= 0) &&
+		(r.Limit == nil || bytes.Compare(key, r.Limit) < 0)
+}
+
+// Iterator iterates over key/value pairs in key order. A new iterator is
+// positioned before the first pair, so Next moves it to the first pair. Moving
+// past either end leaves it there, from which Prev moves back to the last pair
+// or Next to the first one. Writes after the iterator is created are not
+// visible to it, so it is safe to write while iterating.
+//
+// Key and Value return nil if the iterator is not positioned at a pair. The
+// slices returned must not be modified, and are only valid until the iterator
+// moves or is released.
+type Iterator interface {
+	// moves to the first pair, and returns whether it exists
+	First() bool
+
+	// moves to the last pair, and returns whether it exists
+	Last() bool
+
+	// moves to the first pair whose key is not less than key, and returns
+	// whether it exists
+	Seek(key []byte) bool
+
+	// moves to the next pair, and returns whether it exists
+	Next() bool
+
+	// moves to the previous pair, and returns whether it exists
+	Prev() bool
+
+	// return the key of the current pair
+	Key() []byte
+
+	// return the value of the current pair
+	Value() []byte
+
+	// return the error occurred during iteration if any
+	Error() error
+
+	// release the iterator, it must be called to release the iterator
+	Release()
+}
+
+// NewReverseIterator returns an iterator visiting pairs of iter in reverse key
+// order, i.e., Next moves to the pair with the previous key, and Seek moves to
+// the last pair whose key is not greater than key.
+func NewReverseIterator(iter Iterator) Iterator {
+	return &reverseIterator{Iterator: iter}
+}
+
+type reverseIterator struct {
+	Iterator
+
+	started bool
+}
+
+func (it *reverseIterator) First() bool {
+	it.started = true
+	return it.Iterator.Last()
+}
+
+func (it *reverseIterator) Last() bool {
+	it.started = true
+	return it.Iterator.First()
+}
+
+func (it *reverseIterator) Seek(key []byte) bool {
+	it.started = true
+	if !it.Iterator.Seek(key) {
+		return it.Iterator.Last()
+	}
+	if bytes.Equal(it.Iterator.Key(), key) {
+		return true
+	}
+	return it.Iterator.Prev()
+}
+
+func (it *reverseIterator) Next() bool {
+	if !it.started {
+		return it.First()
+	}
+	return it.Iterator.Prev()
+}
+
+func (it *reverseIterator) Prev() bool {
+	if !it.started {
+		return false
+	}
+	return it.Iterator.Next()
+}
+
+// NewEmptyIterator returns an iterator without any pair, whose Error returns err
+func NewEmptyIterator(err error) Iterator {
+	return &emptyIterator{err: err}
+}
+
+type emptyIterator struct {
+	err error
+}
+
+func (it *emptyIterator) First() bool          { return false }
+func (it *emptyIterator) Last() bool           { return false }
+func (it *emptyIterator) Seek(key []byte) bool { return false }
+func (it *emptyIterator) Next() bool           { return false }
+func (it *emptyIterator) Prev() bool           { return false }
+func (it *emptyIterator) Key() []byte          { return nil }
+func (it *emptyIterator) Value() []byte        { return nil }
+func (it *emptyIterator) Error() error         { return it.err }
+func (it *emptyIterator) Release()             {}
@@ -0,0 +1,48 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	storage "github.com/BOXFoundation/boxd/storage"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+)
+
+type lbatch struct {
+	*ltable
+
+	batch *goleveldb.Batch
+}
+
+var _ storage.Batch = (*lbatch)(nil)
+
+// put the value to entry associate with the key
+func (b *lbatch) Put(key, value []byte) {
+	b.batch.Put(b.realkey(key), value)
+}
+
+// delete the entry associate with the key in the Storage
+func (b *lbatch) Del(key []byte) {
+	b.batch.Delete(b.realkey(key))
+}
+
+// remove all the enqueued put/delete
+func (b *lbatch) Clear() {
+	b.batch.Reset()
+}
+
+// returns the number of updates in the batch
+func (b *lbatch) Count() int {
+	return b.batch.Len()
+}
+
+// atomic writes all enqueued put/delete
+func (b *lbatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
+
+// close the batch, it must be called to close the batch
+func (b *lbatch) Close() {
+	b.batch.Reset()
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	"github.com/BOXFoundation/boxd/log"
+	storage "github.com/BOXFoundation/boxd/storage"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+var logger = log.NewLogger("leveldb")
+
+func init() {
+	// register leveldb impl
+	storage.Register("leveldb", NewLevelDB)
+}
+
+// NewLevelDB creates a leveldb instance, which is pure go and needs no cgo
+func NewLevelDB(name string, o *storage.Options) (storage.Storage, error) {
+	logger.Infof("Creating leveldb at %s", name)
+
+	options := &opt.Options{}
+	db, err := goleveldb.OpenFile(name, options)
+	if errors.IsCorrupted(err) {
+		logger.Warnf("Recovering corrupted leveldb at %s. Err: %s", name, err.Error())
+		db, err = goleveldb.RecoverFile(name, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ldb{
+		db:     db,
+		tables: map[string]*ltable{},
+	}
+	d.ltable = newTable(d, "")
+	return d, nil
+}
+
+// helper function to copy key or value out of iterators, whose buffers are reused
+func data(s []byte) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var buf = make([]byte, len(s))
+	copy(buf, s)
+	return buf
+}
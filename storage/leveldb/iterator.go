@@ -0,0 +1,35 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	storage "github.com/BOXFoundation/boxd/storage"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+// liter strips the table prefix off keys of the leveldb iterator, which is
+// bounded by the range already.
+type liter struct {
+	iterator.Iterator
+
+	prefix []byte
+}
+
+var _ storage.Iterator = (*liter)(nil)
+
+func (it *liter) Seek(key []byte) bool {
+	var k = make([]byte, len(it.prefix)+len(key))
+	copy(k, it.prefix)
+	copy(k[len(it.prefix):], key)
+
+	return it.Iterator.Seek(k)
+}
+
+func (it *liter) Key() []byte {
+	if key := it.Iterator.Key(); key != nil {
+		return key[len(it.prefix):]
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	storage "github.com/BOXFoundation/boxd/storage"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+)
+
+// lsnapshot reads a leveldb snapshot through a read-only table of the prefix
+type lsnapshot struct {
+	storage.Reader
+
+	snapshot *goleveldb.Snapshot
+}
+
+var _ storage.Snapshot = (*lsnapshot)(nil)
+
+// Release releases the leveldb snapshot
+func (s *lsnapshot) Release() {
+	s.snapshot.Release()
+}
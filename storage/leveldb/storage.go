@@ -0,0 +1,97 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	storage "github.com/BOXFoundation/boxd/storage"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ldb keeps all tables in one leveldb, and keys of a table are prefixed with
+// the table name. Operations on ldb itself act on keys without prefix.
+type ldb struct {
+	*ltable
+
+	sm sync.Mutex
+	db *goleveldb.DB
+
+	smtables sync.Mutex
+	tables   map[string]*ltable
+}
+
+var _ storage.Storage = (*ldb)(nil)
+
+// Create or Get the table associate with the name
+func (db *ldb) Table(name string) (storage.Table, error) {
+	db.smtables.Lock()
+	defer db.smtables.Unlock()
+
+	t, ok := db.tables[name]
+	if !ok {
+		t = newTable(db, fmt.Sprintf("%s.", name))
+		db.tables[name] = t
+	}
+
+	return t, nil
+}
+
+// Drop the table associate with the name
+func (db *ldb) DropTable(name string) error {
+	db.smtables.Lock()
+	defer db.smtables.Unlock()
+
+	if t, ok := db.tables[name]; ok {
+		waitLock(t.writeLock)
+		defer t.close()
+		delete(db.tables, name)
+	}
+
+	var batch = new(goleveldb.Batch)
+	var iter = db.db.NewIterator(util.BytesPrefix([]byte(fmt.Sprintf("%s.", name))), nil)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return db.db.Write(batch, nil)
+}
+
+func waitLock(c chan<- struct{}) {
+	timer := time.NewTimer(time.Second * 3)
+	defer timer.Stop()
+	select {
+	case c <- struct{}{}:
+	case <-timer.C:
+		logger.Warn("Locking db write timeout...")
+	}
+}
+
+// Close closes the database
+func (db *ldb) Close() error {
+	db.sm.Lock()
+	defer db.sm.Unlock()
+
+	waitLock(db.writeLock)
+	db.smtables.Lock()
+	for _, t := range db.tables {
+		waitLock(t.writeLock)
+	}
+
+	for _, t := range db.tables {
+		t.close()
+	}
+	db.tables = nil
+	db.smtables.Unlock()
+	db.ltable.close()
+
+	return db.db.Close()
+}
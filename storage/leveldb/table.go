@@ -0,0 +1,196 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package leveldb
+
+import (
+	"context"
+
+	storage "github.com/BOXFoundation/boxd/storage"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// reader defines read operations of both leveldb and its snapshots
+type reader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	Has(key []byte, ro *opt.ReadOptions) (bool, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+type ltable struct {
+	db     *goleveldb.DB
+	reader reader
+	prefix []byte
+
+	writeLock chan struct{}
+}
+
+var _ storage.Table = (*ltable)(nil)
+
+func newTable(db *ldb, prefix string) *ltable {
+	return &ltable{
+		db:        db.db,
+		reader:    db.db,
+		prefix:    []byte(prefix),
+		writeLock: make(chan struct{}, 1),
+	}
+}
+
+func (t *ltable) realkey(key []byte) []byte {
+	var k = make([]byte, len(t.prefix)+len(key))
+	copy(k, t.prefix)
+	copy(k[len(t.prefix):], key)
+
+	return k
+}
+
+// create a new write batch
+func (t *ltable) NewBatch() storage.Batch {
+	return &lbatch{
+		ltable: t,
+		batch:  new(goleveldb.Batch),
+	}
+}
+
+func (t *ltable) NewTransaction() (tr storage.Transaction, err error) {
+	defer func() {
+		if recover() != nil {
+			tr = nil
+			err = storage.ErrDatabasePanic
+		}
+	}()
+
+	// lock all write operations
+	t.writeLock <- struct{}{}
+	tr = &dbtx{
+		db:        t,
+		batch:     t.NewBatch(),
+		closed:    false,
+		writeLock: t.writeLock,
+	}
+
+	return tr, nil
+}
+
+// put the value to entry associate with the key
+func (t *ltable) Put(key, value []byte) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = storage.ErrDatabasePanic
+		}
+	}()
+
+	t.writeLock <- struct{}{}
+	err = t.db.Put(t.realkey(key), value, nil)
+	<-t.writeLock
+	return err
+}
+
+// delete the entry associate with the key in the Storage
+func (t *ltable) Del(key []byte) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = storage.ErrDatabasePanic
+		}
+	}()
+
+	t.writeLock <- struct{}{}
+	err = t.db.Delete(t.realkey(key), nil)
+	<-t.writeLock
+	return err
+}
+
+// return value associate with the key in the Storage
+func (t *ltable) Get(key []byte) ([]byte, error) {
+	value, err := t.reader.Get(t.realkey(key), nil)
+	if err == goleveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data(value), nil
+}
+
+// check if the entry associate with key exists
+func (t *ltable) Has(key []byte) (bool, error) {
+	return t.reader.Has(t.realkey(key), nil)
+}
+
+// return a set of keys in the Storage
+func (t *ltable) Keys() [][]byte {
+	return t.KeysWithPrefix(nil)
+}
+
+func (t *ltable) KeysWithPrefix(prefix []byte) [][]byte {
+	var iter = t.reader.NewIterator(util.BytesPrefix(t.realkey(prefix)), nil)
+	defer iter.Release()
+
+	var keys [][]byte
+	for iter.Next() {
+		keys = append(keys, data(iter.Key()[len(t.prefix):]))
+	}
+	return keys
+}
+
+// return a chan to iter all keys
+func (t *ltable) IterKeys(ctx context.Context) <-chan []byte {
+	return t.IterKeysWithPrefix(ctx, nil)
+}
+
+// return a set of keys with specified prefix in the Storage
+func (t *ltable) IterKeysWithPrefix(ctx context.Context, prefix []byte) <-chan []byte {
+	var iter = t.reader.NewIterator(util.BytesPrefix(t.realkey(prefix)), nil)
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		defer iter.Release()
+
+		for iter.Next() {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- data(iter.Key()[len(t.prefix):]):
+			}
+		}
+	}()
+	return out
+}
+
+// return an iterator over key/value pairs within the range
+func (t *ltable) NewIterator(r *storage.Range) storage.Iterator {
+	var rng = util.BytesPrefix(t.prefix)
+	if r != nil && r.Start != nil {
+		rng.Start = t.realkey(r.Start)
+	}
+	if r != nil && r.Limit != nil {
+		rng.Limit = t.realkey(r.Limit)
+	}
+
+	return &liter{
+		Iterator: t.reader.NewIterator(rng, nil),
+		prefix:   t.prefix,
+	}
+}
+
+// take a read-only snapshot of the table
+func (t *ltable) Snapshot() (storage.Snapshot, error) {
+	snapshot, err := t.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lsnapshot{
+		Reader:   &ltable{reader: snapshot, prefix: t.prefix},
+		snapshot: snapshot,
+	}, nil
+}
+
+func (t *ltable) close() {
+	close(t.writeLock)
+}
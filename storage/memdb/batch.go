@@ -100,6 +100,7 @@ func (b *mbatch) write(wlock bool) error {
 	b.sm.Lock()
 	defer b.sm.Unlock()
 
+	b.own()
 	for _, o := range b.ops {
 		k := b.realkey(o.k)
 		switch o.o {
@@ -143,3 +143,27 @@ func TestDBTransKeysWithPrefixRand(t *testing.T) {
 	defer tx.Discard()
 	verify(t, tx)
 }
+
+func TestDBIterator(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	dbtest.StorageIterator(t, db)(t, db)
+}
+
+func TestDBReverseIterator(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	dbtest.StorageReverseIterator(t, db)(t, db)
+}
+
+func TestDBSnapshot(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	dbtest.StorageSnapshot(t, db)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package memdb
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	storage "github.com/BOXFoundation/boxd/storage"
+)
+
+type pair struct {
+	k []byte
+	v []byte
+}
+
+type miter struct {
+	pairs []*pair
+	pos   int
+}
+
+var _ storage.Iterator = (*miter)(nil)
+
+// newIterator collects and sorts pairs within the range, whose keys are
+// stripped of the prefix, so that writes afterwards do not affect iteration.
+func (db *memorydb) newIterator(prefix string, r *storage.Range) *miter {
+	db.sm.RLock()
+	defer db.sm.RUnlock()
+
+	var pairs []*pair
+	for key, value := range db.db {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if k := []byte(key[len(prefix):]); r.Contains(k) {
+			pairs = append(pairs, &pair{k: k, v: value})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].k, pairs[j].k) < 0
+	})
+
+	return &miter{pairs: pairs, pos: -1}
+}
+
+func (it *miter) valid() bool {
+	return it.pos >= 0 && it.pos < len(it.pairs)
+}
+
+func (it *miter) First() bool {
+	it.pos = 0
+	return it.valid()
+}
+
+func (it *miter) Last() bool {
+	it.pos = len(it.pairs) - 1
+	return it.valid()
+}
+
+func (it *miter) Seek(key []byte) bool {
+	it.pos = sort.Search(len(it.pairs), func(i int) bool {
+		return bytes.Compare(it.pairs[i].k, key) >= 0
+	})
+	return it.valid()
+}
+
+func (it *miter) Next() bool {
+	if it.pos < len(it.pairs) {
+		it.pos++
+	}
+	return it.valid()
+}
+
+func (it *miter) Prev() bool {
+	if it.pos >= 0 {
+		it.pos--
+	}
+	return it.valid()
+}
+
+func (it *miter) Key() []byte {
+	if !it.valid() {
+		return nil
+	}
+	return it.pairs[it.pos].k
+}
+
+func (it *miter) Value() []byte {
+	if !it.valid() {
+		return nil
+	}
+	return it.pairs[it.pos].v
+}
+
+func (it *miter) Error() error {
+	return nil
+}
+
+func (it *miter) Release() {
+	it.pairs = nil
+	it.pos = -1
+}
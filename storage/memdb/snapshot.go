@@ -0,0 +1,51 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package memdb
+
+import (
+	storage "github.com/BOXFoundation/boxd/storage"
+)
+
+// msnapshot reads the map of memorydb as of the time it is taken, which is
+// copied on the next write instead.
+type msnapshot struct {
+	storage.Reader
+}
+
+var _ storage.Snapshot = (*msnapshot)(nil)
+
+// snapshot shares the map with a read-only table of the prefix
+func (db *memorydb) snapshot(prefix string) *msnapshot {
+	db.sm.Lock()
+	defer db.sm.Unlock()
+
+	db.shared = true
+	return &msnapshot{
+		Reader: &mtable{
+			memorydb: &memorydb{db: db.db},
+			prefix:   prefix,
+		},
+	}
+}
+
+// own copies the map if it is shared with snapshots, which must be called
+// with sm locked before writing.
+func (db *memorydb) own() {
+	if !db.shared {
+		return
+	}
+
+	var m = make(map[string][]byte, len(db.db))
+	for k, v := range db.db {
+		m[k] = v
+	}
+	db.db = m
+	db.shared = false
+}
+
+// Release releases the snapshot, and the map is collected once unreferenced
+func (s *msnapshot) Release() {
+	s.Reader = nil
+}
@@ -18,6 +18,8 @@ type memorydb struct {
 	sm        sync.RWMutex
 	writeLock chan struct{}
 	db        map[string][]byte
+	// whether db is shared with snapshots, and has to be copied before writing
+	shared bool
 }
 
 var _ storage.Storage = (*memorydb)(nil)
@@ -45,6 +47,7 @@ func (db *memorydb) DropTable(name string) error {
 		keys = append(keys, []byte(key))
 	}
 
+	db.own()
 	var prefix = fmt.Sprintf("%s.", name)
 	for _, key := range keys {
 		if bytes.HasPrefix(key, []byte(prefix)) {
@@ -102,6 +105,7 @@ func (db *memorydb) Put(key, value []byte) error {
 	db.sm.Lock()
 	defer db.sm.Unlock()
 
+	db.own()
 	db.db[string(key)] = value
 	return nil
 }
@@ -116,6 +120,7 @@ func (db *memorydb) Del(key []byte) error {
 	db.sm.Lock()
 	defer db.sm.Unlock()
 
+	db.own()
 	delete(db.db, string(key))
 
 	return nil
@@ -207,3 +212,13 @@ func (db *memorydb) IterKeysWithPrefix(ctx context.Context, prefix []byte) <-cha
 	}()
 	return out
 }
+
+// return an iterator over key/value pairs within the range
+func (db *memorydb) NewIterator(r *storage.Range) storage.Iterator {
+	return db.newIterator("", r)
+}
+
+// take a read-only snapshot
+func (db *memorydb) Snapshot() (storage.Snapshot, error) {
+	return db.snapshot(""), nil
+}
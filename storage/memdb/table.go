@@ -62,6 +62,7 @@ func (t *mtable) Put(key, value []byte) error {
 	t.sm.Lock()
 	defer t.sm.Unlock()
 
+	t.own()
 	t.db[string(t.realkey(key))] = value
 
 	return nil
@@ -77,6 +78,7 @@ func (t *mtable) Del(key []byte) error {
 	t.sm.Lock()
 	defer t.sm.Unlock()
 
+	t.own()
 	delete(t.db, string(t.realkey(key)))
 
 	return nil
@@ -159,3 +161,13 @@ func (t *mtable) IterKeysWithPrefix(ctx context.Context, prefix []byte) <-chan [
 	}()
 	return out
 }
+
+// return an iterator over key/value pairs within the range
+func (t *mtable) NewIterator(r *storage.Range) storage.Iterator {
+	return t.newIterator(t.prefix, r)
+}
+
+// take a read-only snapshot of the table
+func (t *mtable) Snapshot() (storage.Snapshot, error) {
+	return t.snapshot(t.prefix), nil
+}
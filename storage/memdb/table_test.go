@@ -218,3 +218,46 @@ func TestTableTransIterKeysWithPrefix(t *testing.T) {
 	defer tx.Discard()
 	verify(t, tx)
 }
+
+func TestTableIterator(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	table, _ := db.Table("t1")
+	// keys of other tables are not iterated
+	other, _ := db.Table("t0")
+	ensure.Nil(t, other.Put([]byte("key-0000"), []byte("other")))
+	dbtest.StorageIterator(t, table)(t, table)
+}
+
+func TestTableReverseIterator(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	table, _ := db.Table("t1")
+	dbtest.StorageReverseIterator(t, table)(t, table)
+}
+
+func TestTableTransIterator(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	table, _ := db.Table("t1")
+	verify := dbtest.StorageIterator(t, table)
+
+	tx, _ := table.NewTransaction()
+	defer tx.Discard()
+	verify(t, tx)
+}
+
+func TestTableSnapshot(t *testing.T) {
+	var db, err = NewMemoryDB("", nil)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	table, _ := db.Table("t1")
+	dbtest.StorageSnapshot(t, table)
+}
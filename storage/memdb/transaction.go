@@ -143,3 +143,15 @@ func (tx *mtx) Discard() {
 	tx.closed = true
 	<-tx.writeLock
 }
+
+// return an iterator over key/value pairs within the range
+func (tx *mtx) NewIterator(r *storage.Range) storage.Iterator {
+	tx.txsm.Lock()
+	defer tx.txsm.Unlock()
+
+	if tx.closed {
+		return storage.NewEmptyIterator(storage.ErrTransactionClosed)
+	}
+
+	return tx.db.NewIterator(r)
+}
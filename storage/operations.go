@@ -36,6 +36,10 @@ type Reader interface {
 
 	// return a chan to iter all keys with specified prefix
 	IterKeysWithPrefix(ctx context.Context, prefix []byte) <-chan []byte
+
+	// return an iterator over key/value pairs within the range, or all pairs
+	// if the range is nil
+	NewIterator(r *Range) Iterator
 }
 
 // Operations defines common data operations on database/table
@@ -356,3 +356,27 @@ func BenchmarkParallelGetData(b *testing.B) {
 		}
 	})
 }
+
+func TestDBIterator(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	dbtest.StorageIterator(t, db)(t, db)
+}
+
+func TestDBReverseIterator(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	dbtest.StorageReverseIterator(t, db)(t, db)
+}
+
+func TestDBSnapshot(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	dbtest.StorageSnapshot(t, db)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rocksdb
+
+import (
+	"bytes"
+
+	storage "github.com/BOXFoundation/boxd/storage"
+	"github.com/tecbot/gorocksdb"
+)
+
+type position int
+
+const (
+	posBefore position = iota
+	posAt
+	posAfter
+)
+
+// riter bounds the rocksdb iterator by the range, and keeps copies of the
+// current pair since slices of rocksdb have to be freed.
+type riter struct {
+	iter *gorocksdb.Iterator
+	r    *storage.Range
+	pos  position
+
+	key   []byte
+	value []byte
+}
+
+var _ storage.Iterator = (*riter)(nil)
+
+func newIterator(iter *gorocksdb.Iterator, r *storage.Range) *riter {
+	return &riter{iter: iter, r: r, pos: posBefore}
+}
+
+// settle checks if the rocksdb iterator is at a pair within the range after
+// moving forward or backward, otherwise it is past the end or the start.
+func (it *riter) settle(forward bool) bool {
+	it.key, it.value = nil, nil
+	if it.iter.Valid() {
+		key := data(it.iter.Key())
+		if it.r.Contains(key) {
+			it.pos = posAt
+			it.key = key
+			it.value = data(it.iter.Value())
+			return true
+		}
+	}
+
+	if forward {
+		it.pos = posAfter
+	} else {
+		it.pos = posBefore
+	}
+	return false
+}
+
+func (it *riter) First() bool {
+	if it.r != nil && it.r.Start != nil {
+		it.iter.Seek(it.r.Start)
+	} else {
+		it.iter.SeekToFirst()
+	}
+	return it.settle(true)
+}
+
+func (it *riter) Last() bool {
+	if it.r != nil && it.r.Limit != nil {
+		if it.iter.Seek(it.r.Limit); it.iter.Valid() {
+			it.iter.Prev()
+		} else {
+			it.iter.SeekToLast()
+		}
+	} else {
+		it.iter.SeekToLast()
+	}
+	return it.settle(false)
+}
+
+func (it *riter) Seek(key []byte) bool {
+	if it.r != nil && it.r.Start != nil && bytes.Compare(key, it.r.Start) < 0 {
+		key = it.r.Start
+	}
+	it.iter.Seek(key)
+	return it.settle(true)
+}
+
+func (it *riter) Next() bool {
+	switch it.pos {
+	case posBefore:
+		return it.First()
+	case posAfter:
+		return false
+	}
+	it.iter.Next()
+	return it.settle(true)
+}
+
+func (it *riter) Prev() bool {
+	switch it.pos {
+	case posBefore:
+		return false
+	case posAfter:
+		return it.Last()
+	}
+	it.iter.Prev()
+	return it.settle(false)
+}
+
+func (it *riter) Key() []byte {
+	return it.key
+}
+
+func (it *riter) Value() []byte {
+	return it.value
+}
+
+func (it *riter) Error() error {
+	if it.iter == nil {
+		return nil
+	}
+	return it.iter.Err()
+}
+
+func (it *riter) Release() {
+	if it.iter != nil {
+		it.iter.Close()
+		it.iter = nil
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rocksdb
+
+import (
+	storage "github.com/BOXFoundation/boxd/storage"
+	"github.com/tecbot/gorocksdb"
+)
+
+// rsnapshot reads a rocksdb snapshot through read options set to it
+type rsnapshot struct {
+	storage.Reader
+
+	rocksdb     *gorocksdb.DB
+	snapshot    *gorocksdb.Snapshot
+	readOptions *gorocksdb.ReadOptions
+}
+
+var _ storage.Snapshot = (*rsnapshot)(nil)
+
+func newSnapshot(db *gorocksdb.DB) *rsnapshot {
+	snapshot := db.NewSnapshot()
+	readOptions := gorocksdb.NewDefaultReadOptions()
+	readOptions.SetSnapshot(snapshot)
+
+	return &rsnapshot{
+		rocksdb:     db,
+		snapshot:    snapshot,
+		readOptions: readOptions,
+	}
+}
+
+// Release releases the rocksdb snapshot
+func (s *rsnapshot) Release() {
+	s.rocksdb.ReleaseSnapshot(s.snapshot)
+	s.readOptions.Destroy()
+}
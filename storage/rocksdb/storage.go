@@ -281,3 +281,18 @@ func (db *rocksdb) IterKeysWithPrefix(ctx context.Context, prefix []byte) <-chan
 	}()
 	return out
 }
+
+// return an iterator over key/value pairs within the range
+func (db *rocksdb) NewIterator(r *storage.Range) storage.Iterator {
+	return newIterator(db.rocksdb.NewIterator(db.readOptions), r)
+}
+
+// take a read-only snapshot
+func (db *rocksdb) Snapshot() (storage.Snapshot, error) {
+	s := newSnapshot(db.rocksdb)
+	s.Reader = &rocksdb{
+		rocksdb:     db.rocksdb,
+		readOptions: s.readOptions,
+	}
+	return s, nil
+}
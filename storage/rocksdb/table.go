@@ -188,6 +188,22 @@ func (t *rtable) IterKeysWithPrefix(ctx context.Context, prefix []byte) <-chan [
 	return out
 }
 
+// return an iterator over key/value pairs within the range
+func (t *rtable) NewIterator(r *storage.Range) storage.Iterator {
+	return newIterator(t.rocksdb.NewIteratorCF(t.readOptions, t.cf), r)
+}
+
+// take a read-only snapshot of the table
+func (t *rtable) Snapshot() (storage.Snapshot, error) {
+	s := newSnapshot(t.rocksdb)
+	s.Reader = &rtable{
+		rocksdb:     t.rocksdb,
+		cf:          t.cf,
+		readOptions: s.readOptions,
+	}
+	return s, nil
+}
+
 func (t *rtable) Close() {
 	close(t.writeLock)
 	t.cf.Destroy()
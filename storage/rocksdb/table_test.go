@@ -310,3 +310,43 @@ func TestTableTransactionIterKeys(t *testing.T) {
 	defer tx.Discard()
 	verify(t, tx)
 }
+
+func TestTableIterator(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	table, _ := db.Table("t1")
+	dbtest.StorageIterator(t, table)(t, table)
+}
+
+func TestTableReverseIterator(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	table, _ := db.Table("t1")
+	dbtest.StorageReverseIterator(t, table)(t, table)
+}
+
+func TestTableTransactionIterator(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	table, _ := db.Table("trans")
+	verify := dbtest.StorageIterator(t, table)
+
+	tx, _ := table.NewTransaction()
+	defer tx.Discard()
+	verify(t, tx)
+}
+
+func TestTableSnapshot(t *testing.T) {
+	dbpath, db, err := getDatabase()
+	ensure.Nil(t, err)
+	defer releaseDatabase(dbpath, db)
+
+	table, _ := db.Table("t1")
+	dbtest.StorageSnapshot(t, table)
+}
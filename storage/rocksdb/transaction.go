@@ -145,3 +145,15 @@ func (tr *dbtx) Discard() {
 		<-tr.writeLock
 	}
 }
+
+// return an iterator over key/value pairs within the range
+func (tr *dbtx) NewIterator(r *storage.Range) storage.Iterator {
+	tr.sm.Lock()
+	defer tr.sm.Unlock()
+
+	if tr.closed {
+		return storage.NewEmptyIterator(storage.ErrTransactionClosed)
+	}
+
+	return tr.db.NewIterator(r)
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2018 ContentBox Authors.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package storage
+
+// Snapshot is a read-only view of a table as of the time it is taken, which is
+// not affected by writes afterwards.
+type Snapshot interface {
+	Reader
+
+	// Release releases the snapshot, it must be called to release the snapshot
+	Release()
+}
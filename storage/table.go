@@ -13,4 +13,7 @@ type Table interface {
 
 	// NewTransaction creates a new transaction on the Storage.
 	NewTransaction() (Transaction, error)
+
+	// Snapshot takes a read-only snapshot of the table.
+	Snapshot() (Snapshot, error)
 }